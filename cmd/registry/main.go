@@ -34,6 +34,11 @@ var (
 )
 
 func main() {
+	// Subcommands are handled before the server's flags
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(os.Args[2:]))
+	}
+
 	// Parse command line flags
 	showVersion := flag.Bool("version", false, "Display version information")
 	flag.Parse()
@@ -61,19 +66,32 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Connect to PostgreSQL
-	db, err = database.NewPostgreSQL(ctx, cfg.DatabaseURL)
-	if err != nil {
-		log.Printf("Failed to connect to PostgreSQL: %v", err)
-		return
+	// Connect to PostgreSQL, or keep everything in memory for local runs
+	if cfg.DatabaseURL == database.MemoryURL {
+		log.Println("Using in-memory database: data will be lost when the registry stops")
+		db = database.NewMemory()
+	} else {
+		var opts []database.PostgreSQLOption
+		if !cfg.AutoMigrate {
+			opts = append(opts, database.WithoutAutoMigrate())
+		}
+		if cfg.DatabaseReadURL != "" {
+			log.Println("Routing reads to the PostgreSQL read replica")
+			opts = append(opts, database.WithReadReplica(cfg.DatabaseReadURL, cfg.DatabaseReadMaxLag))
+		}
+		db, err = database.NewPostgreSQL(ctx, cfg.DatabaseURL, opts...)
+		if err != nil {
+			log.Printf("Failed to connect to PostgreSQL: %v", err)
+			return
+		}
 	}
 
-	// Store the PostgreSQL instance for later cleanup
+	// Store the database instance for later cleanup
 	defer func() {
 		if err := db.Close(); err != nil {
-			log.Printf("Error closing PostgreSQL connection: %v", err)
+			log.Printf("Error closing database connection: %v", err)
 		} else {
-			log.Println("PostgreSQL connection closed successfully")
+			log.Println("Database connection closed successfully")
 		}
 	}()
 
@@ -113,6 +131,12 @@ func main() {
 	// Initialize HTTP server
 	server := api.NewServer(cfg, registryService, metrics, versionInfo)
 
+	// Send webhook deliveries and prune old publish events in the background until shutdown
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	go registryService.RunWebhookDispatcher(backgroundCtx)
+	go registryService.RunPublishEventPruner(backgroundCtx)
+
 	// Start server in a goroutine so it doesn't block signal handling
 	go func() {
 		if err := server.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -136,6 +160,7 @@ func main() {
 	if err := server.Shutdown(sctx); err != nil {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
+	stopBackground()
 
 	log.Println("Server exiting")
 }
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// runMigrate implements 'registry migrate', returning the process exit code
+func runMigrate(args []string) int {
+	// Flags may appear anywhere after the subcommand
+	var positional []string
+	dryRun := false
+	for _, arg := range args {
+		switch arg {
+		case "--dry-run", "-dry-run":
+			dryRun = true
+		case "--help", "-h", "help":
+			printMigrateUsage()
+			return 0
+		default:
+			if strings.HasPrefix(arg, "-") {
+				fmt.Fprintf(os.Stderr, "Unknown flag: %s\n\n", arg)
+				printMigrateUsage()
+				return 2
+			}
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) == 0 {
+		printMigrateUsage()
+		return 2
+	}
+
+	cfg := config.NewConfig()
+	if cfg.DatabaseURL == database.MemoryURL {
+		fmt.Fprintln(os.Stderr, "Error: the in-memory database has no migrations")
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	conn, err := pgx.Connect(ctx, cfg.DatabaseURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to connect to PostgreSQL: %v\n", err)
+		return 1
+	}
+	defer conn.Close(context.Background())
+
+	if err := migrateCommand(ctx, database.NewMigrator(conn), positional[0], positional[1:], dryRun); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// migrateCommand runs a single migrate command against the database
+func migrateCommand(ctx context.Context, migrator *database.Migrator, command string, args []string, dryRun bool) error {
+	var steps []database.MigrationStep
+	var err error
+	switch command {
+	case "status":
+		return printMigrationStatus(ctx, migrator)
+	case "up":
+		if len(args) != 0 {
+			return errors.New("usage: registry migrate up [--dry-run]")
+		}
+		steps, err = migrator.PlanUp(ctx)
+	case "down":
+		n := 1
+		if len(args) > 1 {
+			return errors.New("usage: registry migrate down [N] [--dry-run]")
+		}
+		if len(args) == 1 {
+			if n, err = strconv.Atoi(args[0]); err != nil {
+				return fmt.Errorf("invalid number of migrations to revert: %s", args[0])
+			}
+		}
+		steps, err = migrator.PlanDown(ctx, n)
+	case "to":
+		if len(args) != 1 {
+			return errors.New("usage: registry migrate to <version> [--dry-run]")
+		}
+		target, convErr := strconv.Atoi(args[0])
+		if convErr != nil {
+			return fmt.Errorf("invalid migration version: %s", args[0])
+		}
+		steps, err = migrator.PlanTo(ctx, target)
+	default:
+		return fmt.Errorf("unknown migrate command: %s", command)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(steps) == 0 {
+		_, _ = fmt.Fprintln(os.Stdout, "Nothing to do: the database is already at the requested version")
+		return nil
+	}
+
+	if dryRun {
+		_, _ = fmt.Fprintln(os.Stdout, "Dry run, no changes made. Would run:")
+		for _, step := range steps {
+			direction := "apply "
+			if step.Down {
+				direction = "revert"
+			}
+			_, _ = fmt.Fprintf(os.Stdout, "  %s %03d %s\n", direction, step.Migration.Version, step.Migration.Name)
+		}
+		return nil
+	}
+
+	return migrator.Apply(ctx, steps)
+}
+
+// printMigrationStatus lists every migration and whether it is applied
+func printMigrationStatus(ctx context.Context, migrator *database.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT\tDOWN")
+	for _, status := range statuses {
+		state, appliedAt, down := "pending", "-", "no"
+		if status.Applied {
+			state = "applied"
+			appliedAt = status.AppliedAt.UTC().Format("2006-01-02 15:04:05Z")
+		}
+		switch {
+		case status.Missing:
+			state = "applied (unknown to this binary)"
+		case status.Modified:
+			state = "applied (modified since)"
+		}
+		if status.Reversible {
+			down = "yes"
+		}
+		_, _ = fmt.Fprintf(w, "%03d\t%s\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt, down)
+	}
+	return w.Flush()
+}
+
+func printMigrateUsage() {
+	_, _ = fmt.Fprintln(os.Stdout, "Manage the registry's PostgreSQL schema (using MCP_REGISTRY_DATABASE_URL)")
+	_, _ = fmt.Fprintln(os.Stdout)
+	_, _ = fmt.Fprintln(os.Stdout, "Usage:")
+	_, _ = fmt.Fprintln(os.Stdout, "  registry migrate <command> [arguments] [--dry-run]")
+	_, _ = fmt.Fprintln(os.Stdout)
+	_, _ = fmt.Fprintln(os.Stdout, "Commands:")
+	_, _ = fmt.Fprintln(os.Stdout, "  status        List migrations and whether they are applied")
+	_, _ = fmt.Fprintln(os.Stdout, "  up            Apply all pending migrations")
+	_, _ = fmt.Fprintln(os.Stdout, "  down [N]      Revert the last N applied migrations (default 1)")
+	_, _ = fmt.Fprintln(os.Stdout, "  to <version>  Apply or revert migrations until the given version is the latest applied")
+	_, _ = fmt.Fprintln(os.Stdout)
+	_, _ = fmt.Fprintln(os.Stdout, "Flags:")
+	_, _ = fmt.Fprintln(os.Stdout, "  --dry-run     Print the migrations that would run without running them")
+}
@@ -0,0 +1,135 @@
+package v0
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListAuditEventsInput represents the input for querying the audit log
+type ListAuditEventsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	Cursor        string `query:"cursor" doc:"Pagination cursor" required:"false" example:"1234"`
+	Limit         int    `query:"limit" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100" example:"50"`
+	ServerName    string `query:"server_name" doc:"Only events for this server" required:"false" example:"com.example/my-server"`
+	Actor         string `query:"actor" doc:"Only events by this actor (the auth method subject, e.g. a GitHub username)" required:"false" example:"octocat"`
+	Since         string `query:"since" doc:"Only events at or after this time (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
+	Until         string `query:"until" doc:"Only events before this time (RFC3339 datetime)" required:"false" example:"2025-08-08T13:15:04.280Z"`
+}
+
+// AuditActor identifies who made a change
+type AuditActor struct {
+	AuthMethod string `json:"authMethod" doc:"Auth method of the token used, or 'system' for changes made by the registry itself" example:"github-at"`
+	Subject    string `json:"subject,omitempty" doc:"Subject of the auth method, e.g. a GitHub username" example:"octocat"`
+}
+
+// AuditEvent is an entry of the audit log
+type AuditEvent struct {
+	ID         int64           `json:"id" doc:"Event ID, increasing with time" example:"1234"`
+	OccurredAt time.Time       `json:"occurredAt" doc:"When the change was made"`
+	Action     string          `json:"action" doc:"Kind of change" example:"publish"`
+	ServerName string          `json:"serverName" example:"com.example/my-server"`
+	Version    string          `json:"version" example:"1.0.0"`
+	Actor      AuditActor      `json:"actor"`
+	SourceIP   string          `json:"sourceIp,omitempty" doc:"Client IP of the request that made the change" example:"203.0.113.7"`
+	Reason     string          `json:"reason,omitempty" doc:"Justification given for the change"`
+	Before     json.RawMessage `json:"before,omitempty" doc:"Full snapshot of the server version before the change, kept for restores and investigations (absent for publishes)"`
+	After      json.RawMessage `json:"after,omitempty" doc:"Full snapshot of the server version after the change"`
+	Diff       json.RawMessage `json:"diff,omitempty" doc:"RFC 6902 JSON Patch from before to after (absent for publishes and for events recorded before diffs were kept)"`
+}
+
+// AuditEventListResponse is a page of audit log entries
+type AuditEventListResponse struct {
+	Events   []AuditEvent   `json:"events"`
+	Metadata apiv0.Metadata `json:"metadata"`
+}
+
+// RegisterAuditEndpoints registers the admin audit log endpoints with a custom path prefix
+func RegisterAuditEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-audit-events" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/audit-events",
+		Summary:     "List audit events",
+		Description: "Query the append-only log of registry changes, newest first (admin only).",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListAuditEventsInput) (*Response[AuditEventListResponse], error) {
+		ctx, _, err := authenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		filter := &database.AuditEventFilter{}
+		if input.ServerName != "" {
+			filter.ServerName = &input.ServerName
+		}
+		if input.Actor != "" {
+			filter.ActorSubject = &input.Actor
+		}
+		if input.Since != "" {
+			since, err := time.Parse(time.RFC3339, input.Since)
+			if err != nil {
+				return nil, huma.Error400BadRequest("Invalid since format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04.280Z)")
+			}
+			filter.Since = &since
+		}
+		if input.Until != "" {
+			until, err := time.Parse(time.RFC3339, input.Until)
+			if err != nil {
+				return nil, huma.Error400BadRequest("Invalid until format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04.280Z)")
+			}
+			filter.Until = &until
+		}
+
+		events, nextCursor, err := registry.ListAuditEvents(ctx, filter, input.Cursor, input.Limit)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Invalid cursor", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to get audit events", err)
+		}
+
+		body := AuditEventListResponse{
+			Events: make([]AuditEvent, len(events)),
+			Metadata: apiv0.Metadata{
+				NextCursor: nextCursor,
+				Count:      len(events),
+			},
+		}
+		for i, event := range events {
+			body.Events[i] = AuditEvent{
+				ID:         event.ID,
+				OccurredAt: event.OccurredAt,
+				Action:     event.Action,
+				ServerName: event.ServerName,
+				Version:    event.Version,
+				Actor: AuditActor{
+					AuthMethod: event.ActorMethod,
+					Subject:    event.ActorSubject,
+				},
+				SourceIP: event.SourceIP,
+				Reason:   event.Reason,
+				Before:   event.Before,
+				After:    event.After,
+				Diff:     event.Diff,
+			}
+		}
+
+		return &Response[AuditEventListResponse]{Body: body}, nil
+	})
+}
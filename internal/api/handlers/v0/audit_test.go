@@ -0,0 +1,106 @@
+package v0_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestAuditEventsEndpoint(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.EnableRegistryValidation = false
+	registryService := service.NewRegistryService(database.NewMemory(), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, cfg)
+	v0.RegisterEditEndpoints(api, "/v0", registryService, cfg)
+	v0.RegisterAuditEndpoints(api, "/v0", registryService, cfg)
+
+	publisherToken := testAuthHeader(t, cfg, "publisher", auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.publisher/*"})
+	adminToken := testAuthHeader(t, cfg, "admin", auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"})
+	serve := func(method, target, authHeader string, body any) *httptest.ResponseRecorder {
+		return serveTestRequest(t, mux, method, target, authHeader, body)
+	}
+
+	// Publish as the publisher, then take the server down as the admin
+	server := apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.publisher/audited",
+		Description: "Audited server",
+		Version:     "1.0.0",
+	}
+	w := serve(http.MethodPost, "/v0/publish", publisherToken, server)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	editPath := "/v0/servers/" + url.PathEscape(server.Name) + "/versions/1.0.0?status=deleted&reason=" + url.QueryEscape("Malware report")
+	w = serve(http.MethodPut, editPath, adminToken, server)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	t.Run("admin sees both changes newest first", func(t *testing.T) {
+		w := serve(http.MethodGet, "/v0/admin/audit-events?server_name="+url.QueryEscape(server.Name), adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp v0.AuditEventListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(t, resp.Events, 2)
+
+		edit, publish := resp.Events[0], resp.Events[1]
+		assert.Equal(t, service.AuditActionEdit, edit.Action)
+		assert.Equal(t, v0.AuditActor{AuthMethod: string(auth.MethodGitHubAT), Subject: "admin"}, edit.Actor)
+		assert.Equal(t, "Malware report", edit.Reason)
+		assert.Contains(t, string(edit.Before), `"status":"active"`)
+		assert.Contains(t, string(edit.After), `"status":"deleted"`)
+		assert.Contains(t, string(edit.Diff), `{"op":"replace","path":"/_meta/io.modelcontextprotocol.registry~1official/status","value":"deleted"}`)
+
+		assert.Equal(t, service.AuditActionPublish, publish.Action)
+		assert.Equal(t, "publisher", publish.Actor.Subject)
+		assert.Empty(t, publish.Before)
+		assert.Empty(t, publish.Diff)
+		assert.Contains(t, string(publish.After), `"version":"1.0.0"`)
+	})
+
+	t.Run("filter by actor", func(t *testing.T) {
+		w := serve(http.MethodGet, "/v0/admin/audit-events?actor=publisher", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp v0.AuditEventListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(t, resp.Events, 1)
+		assert.Equal(t, service.AuditActionPublish, resp.Events[0].Action)
+	})
+
+	t.Run("filter by time range", func(t *testing.T) {
+		w := serve(http.MethodGet, "/v0/admin/audit-events?until=2020-01-01T00:00:00Z", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp v0.AuditEventListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Empty(t, resp.Events)
+
+		w = serve(http.MethodGet, "/v0/admin/audit-events?since=yesterday", adminToken, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("requires admin", func(t *testing.T) {
+		w := serve(http.MethodGet, "/v0/admin/audit-events", publisherToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = serve(http.MethodGet, "/v0/admin/audit-events", "Bearer invalid-token", nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
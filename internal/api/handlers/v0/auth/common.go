@@ -137,12 +137,14 @@ func (pki *PublicKeyInfo) VerifySignature(message, signature []byte) error {
 // BuildPermissions builds permissions for a domain with optional subdomain support
 func BuildPermissions(domain string, includeSubdomains bool) []auth.Permission {
 	reverseDomain := ReverseString(domain)
+	owner := &auth.Identity{Type: auth.IdentityTypeDomain, ID: strings.ToLower(domain)}
 
 	permissions := []auth.Permission{
 		// Grant permissions for the exact domain (e.g., com.example/*)
 		{
 			Action:          auth.PermissionActionPublish,
 			ResourcePattern: fmt.Sprintf("%s/*", reverseDomain),
+			Owner:           owner,
 		},
 	}
 
@@ -150,6 +152,7 @@ func BuildPermissions(domain string, includeSubdomains bool) []auth.Permission {
 		permissions = append(permissions, auth.Permission{
 			Action:          auth.PermissionActionPublish,
 			ResourcePattern: fmt.Sprintf("%s.*", reverseDomain),
+			Owner:           owner,
 		})
 	}
 
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
@@ -82,7 +83,7 @@ func (h *GitHubHandler) ExchangeToken(ctx context.Context, githubToken string) (
 	}
 
 	// Build permissions based on user and organizations
-	permissions := h.buildPermissions(user.Login, orgs)
+	permissions := h.buildPermissions(*user, orgs)
 
 	// Create JWT claims with GitHub user info
 	claims := auth.JWTClaims{
@@ -165,11 +166,11 @@ func (h *GitHubHandler) getGitHubUserOrgs(ctx context.Context, username string,
 }
 
 // buildPermissions builds permissions based on GitHub user and their organizations
-func (h *GitHubHandler) buildPermissions(username string, orgs []GitHubUserOrOrg) []auth.Permission {
+func (h *GitHubHandler) buildPermissions(user GitHubUserOrOrg, orgs []GitHubUserOrOrg) []auth.Permission {
 	permissions := []auth.Permission{}
 
 	// Assert user and org names match expected regex, to harden against people doing weird things in names
-	if !isValidGitHubName(username) {
+	if !isValidGitHubName(user.Login) {
 		return nil
 	}
 	for _, org := range orgs {
@@ -181,7 +182,8 @@ func (h *GitHubHandler) buildPermissions(username string, orgs []GitHubUserOrOrg
 	// Add permission for user's own namespace
 	permissions = append(permissions, auth.Permission{
 		Action:          auth.PermissionActionPublish,
-		ResourcePattern: fmt.Sprintf("io.github.%s/*", username),
+		ResourcePattern: fmt.Sprintf("io.github.%s/*", user.Login),
+		Owner:           gitHubIdentity(user.Login, user.ID),
 	})
 
 	// Add permissions for each organization
@@ -189,12 +191,21 @@ func (h *GitHubHandler) buildPermissions(username string, orgs []GitHubUserOrOrg
 		permissions = append(permissions, auth.Permission{
 			Action:          auth.PermissionActionPublish,
 			ResourcePattern: fmt.Sprintf("io.github.%s/*", org.Login),
+			Owner:           gitHubIdentity(org.Login, org.ID),
 		})
 	}
 
 	return permissions
 }
 
+// gitHubIdentity returns the identity of a GitHub account, or nil if its ID is unknown
+func gitHubIdentity(login string, id int) *auth.Identity {
+	if id <= 0 {
+		return nil
+	}
+	return &auth.Identity{Type: auth.IdentityTypeGitHub, ID: strconv.Itoa(id), Name: login}
+}
+
 func isValidGitHubName(name string) bool {
 	return regexp.MustCompile(`^[a-zA-Z0-9-]+$`).MatchString(name)
 }
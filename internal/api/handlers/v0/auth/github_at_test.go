@@ -82,6 +82,8 @@ func TestGitHubHandler_ExchangeToken(t *testing.T) {
 		assert.Len(t, claims.Permissions, 1)
 		assert.Equal(t, auth.PermissionActionPublish, claims.Permissions[0].Action)
 		assert.Equal(t, "io.github.testuser/*", claims.Permissions[0].ResourcePattern)
+		// The namespace owner is recorded by account ID, which survives renames
+		assert.Equal(t, &auth.Identity{Type: auth.IdentityTypeGitHub, ID: "12345", Name: "testuser"}, claims.Permissions[0].Owner)
 	})
 
 	t.Run("successful token exchange with organizations", func(t *testing.T) {
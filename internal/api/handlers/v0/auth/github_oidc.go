@@ -9,6 +9,7 @@ import (
 	"io"
 	"math/big"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
@@ -28,7 +29,8 @@ type GitHubOIDCTokenExchangeInput struct {
 // GitHubOIDCClaims represents the claims we need from a GitHub OIDC token
 type GitHubOIDCClaims struct {
 	jwt.RegisteredClaims
-	RepositoryOwner string `json:"repository_owner"` // e.g., "octo-org"
+	RepositoryOwner   string `json:"repository_owner"`    // e.g., "octo-org"
+	RepositoryOwnerID string `json:"repository_owner_id"` // e.g., "123456", stable across renames
 }
 
 // JWKS represents a JSON Web Key Set
@@ -291,7 +293,17 @@ func (h *GitHubOIDCHandler) buildPermissions(claims *GitHubOIDCClaims) []auth.Pe
 	permissions = append(permissions, auth.Permission{
 		Action:          auth.PermissionActionPublish,
 		ResourcePattern: fmt.Sprintf("io.github.%s/*", claims.RepositoryOwner),
+		Owner:           gitHubIdentity(claims.RepositoryOwner, repositoryOwnerID(claims.RepositoryOwnerID)),
 	})
 
 	return permissions
 }
+
+// repositoryOwnerID parses the numeric repository_owner_id claim, returning 0 if it is missing or invalid
+func repositoryOwnerID(claim string) int {
+	id, err := strconv.Atoi(claim)
+	if err != nil {
+		return 0
+	}
+	return id
+}
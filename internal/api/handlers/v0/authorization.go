@@ -0,0 +1,68 @@
+package v0
+
+import (
+	"context"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// authenticate validates the Registry JWT in a "Bearer <token>" Authorization header. The
+// returned context records the token's holder as the actor of any changes made with it, and as
+// an admin if the token has admin permissions, and lets it read the drafts of servers the token
+// can publish or edit.
+func authenticate(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) (context.Context, *auth.JWTClaims, error) {
+	// Extract bearer token
+	const bearerPrefix = "Bearer "
+	if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
+		return ctx, nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
+	}
+	token := authHeader[len(bearerPrefix):]
+
+	// Validate Registry JWT token
+	claims, err := jwtManager.ValidateToken(ctx, token)
+	if err != nil {
+		return ctx, nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+	}
+
+	ctx = service.WithDraftAccess(ctx, func(serverName string) bool {
+		return jwtManager.HasPermission(serverName, auth.PermissionActionPublish, claims.Permissions) ||
+			jwtManager.HasPermission(serverName, auth.PermissionActionEdit, claims.Permissions)
+	})
+	ctx = service.WithActor(ctx, string(claims.AuthMethod), claims.AuthMethodSubject)
+	if isAdmin(jwtManager, claims) {
+		ctx = service.WithAdmin(ctx)
+	}
+	return ctx, claims, nil
+}
+
+// isAdmin reports whether a token has admin permissions, which is edit permission on all servers
+func isAdmin(jwtManager *auth.JWTManager, claims *auth.JWTClaims) bool {
+	return jwtManager.HasPermission("*", auth.PermissionActionEdit, claims.Permissions)
+}
+
+// authenticateOptional is authenticate for public endpoints, where a token is only needed to
+// see drafts. Requests without an Authorization header are anonymous.
+func authenticateOptional(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) (context.Context, error) {
+	if authHeader == "" {
+		return ctx, nil
+	}
+	ctx, _, err := authenticate(ctx, jwtManager, authHeader)
+	return ctx, err
+}
+
+// authenticateAdmin is authenticate for admin-only endpoints, which require edit permission on all servers
+func authenticateAdmin(ctx context.Context, jwtManager *auth.JWTManager, authHeader string) (context.Context, *auth.JWTClaims, error) {
+	ctx, claims, err := authenticate(ctx, jwtManager, authHeader)
+	if err != nil {
+		return ctx, nil, err
+	}
+
+	if !isAdmin(jwtManager, claims) {
+		return ctx, nil, huma.Error403Forbidden("This operation requires admin permissions")
+	}
+
+	return ctx, claims, nil
+}
@@ -0,0 +1,129 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// BulkStatusBody is the requested status change of a bulk status endpoint
+type BulkStatusBody struct {
+	Status             string `json:"status" doc:"New status of the versions" enum:"active,deprecated,deleted" example:"deleted"`
+	DeprecationMessage string `json:"deprecationMessage,omitempty" doc:"Why the versions are deprecated or deleted, shown to consumers" required:"false" maxLength:"1000" example:"Removed for distributing malware"`
+	Reason             string `json:"reason,omitempty" doc:"Why the change is being made, recorded in the audit log of every changed version" required:"false" maxLength:"1000" example:"Takedown: malware reported in #123"`
+	DryRun             bool   `json:"dryRun,omitempty" doc:"Only report the versions that would change, without changing them" required:"false"`
+}
+
+// BulkServerStatusInput represents the input for changing the status of every version of a server
+type BulkServerStatusInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Body          BulkStatusBody
+}
+
+// BulkNamespaceStatusInput represents the input for changing the status of every server in a namespace
+type BulkNamespaceStatusInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	Namespace     string `path:"namespace" doc:"Namespace, the part of server names before the slash" example:"com.example"`
+	Body          BulkStatusBody
+}
+
+// BulkStatusChange is a server version whose status was, or would be, changed
+type BulkStatusChange struct {
+	ServerName     string       `json:"serverName" example:"com.example/my-server"`
+	Version        string       `json:"version" example:"1.0.0"`
+	PreviousStatus model.Status `json:"previousStatus" example:"active"`
+	Status         model.Status `json:"status" example:"deleted"`
+}
+
+// BulkStatusResponse reports the outcome of a bulk status change
+type BulkStatusResponse struct {
+	DryRun  bool               `json:"dryRun" doc:"Whether the changes were only reported"`
+	Count   int                `json:"count" doc:"Number of versions changed"`
+	Changes []BulkStatusChange `json:"changes" doc:"Versions whose status or deprecation message changed"`
+}
+
+// RegisterBulkStatusEndpoints registers the admin endpoints that change the status of many versions at once with a custom path prefix
+func RegisterBulkStatusEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	security := []map[string][]string{
+		{"bearer": {}},
+	}
+
+	// setStatus applies a bulk status change to a server or a namespace
+	setStatus := func(ctx context.Context, authHeader, serverName, namespace string, body BulkStatusBody) (*Response[BulkStatusResponse], error) {
+		ctx, _, err := authenticateAdmin(ctx, jwtManager, authHeader)
+		if err != nil {
+			return nil, err
+		}
+		if body.Reason != "" {
+			ctx = service.WithAuditReason(ctx, body.Reason)
+		}
+
+		changes, err := registry.BulkSetServerStatus(ctx, serverName, namespace, model.Status(body.Status), body.DeprecationMessage, body.DryRun)
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				return nil, huma.Error404NotFound("Server not found")
+			case errors.Is(err, database.ErrInvalidInput):
+				return nil, huma.Error400BadRequest("Invalid status change", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to change server status", err)
+		}
+
+		response := BulkStatusResponse{
+			DryRun:  body.DryRun,
+			Count:   len(changes),
+			Changes: make([]BulkStatusChange, len(changes)),
+		}
+		for i, change := range changes {
+			response.Changes[i] = BulkStatusChange{
+				ServerName:     change.ServerName,
+				Version:        change.Version,
+				PreviousStatus: change.PreviousStatus,
+				Status:         change.Status,
+			}
+		}
+
+		return &Response[BulkStatusResponse]{Body: response}, nil
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-server-status-bulk" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/admin/servers/{serverName}/status",
+		Summary:     "Set status of all versions of a server",
+		Description: "Change the status of every version of a server in one transaction, recording an audit event per changed version (admin only). " +
+			"Drafts are left alone, and deleted versions are only affected when deleting. With dryRun, only reports the versions that would change.",
+		Tags:     []string{"admin"},
+		Security: security,
+	}, func(ctx context.Context, input *BulkServerStatusInput) (*Response[BulkStatusResponse], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+		return setStatus(ctx, input.Authorization, serverName, "", input.Body)
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-namespace-status-bulk" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/admin/namespaces/{namespace}/status",
+		Summary:     "Set status of all servers in a namespace",
+		Description: "Change the status of every version of every server in a namespace in one transaction, recording an audit event per changed version (admin only). " +
+			"Drafts are left alone, and deleted versions are only affected when deleting. With dryRun, only reports the versions that would change.",
+		Tags:     []string{"admin"},
+		Security: security,
+	}, func(ctx context.Context, input *BulkNamespaceStatusInput) (*Response[BulkStatusResponse], error) {
+		return setStatus(ctx, input.Authorization, "", input.Namespace, input.Body)
+	})
+}
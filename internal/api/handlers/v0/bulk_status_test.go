@@ -0,0 +1,100 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestBulkStatusEndpoints(t *testing.T) {
+	ctx := context.Background()
+	cfg := newTestConfig(t)
+	cfg.EnableRegistryValidation = false
+	registryService := service.NewRegistryService(database.NewMemory(), cfg)
+
+	for _, server := range []struct{ name, version string }{
+		{"com.example/bulk", "1.0.0"},
+		{"com.example/bulk", "2.0.0"},
+		{"com.example/bulk-too", "1.0.0"},
+	} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        server.name,
+			Description: "Bulk test server",
+			Version:     server.version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterBulkStatusEndpoints(api, "/v0", registryService, cfg)
+
+	admin := testAuthHeader(t, cfg, "moderator", auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"})
+	post := func(authHeader, path string, body map[string]any) (*httptest.ResponseRecorder, v0.BulkStatusResponse) {
+		w := serveTestRequest(t, mux, http.MethodPost, "/v0/admin"+path, authHeader, body)
+		var resp v0.BulkStatusResponse
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		}
+		return w, resp
+	}
+
+	t.Run("requires admin permission", func(t *testing.T) {
+		publisher := testAuthHeader(t, cfg, "moderator", auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "*"})
+		w, _ := post(publisher, "/servers/com.example%2Fbulk/status", map[string]any{"status": "deleted"})
+		assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	})
+
+	t.Run("dry run reports the versions of a namespace", func(t *testing.T) {
+		w, resp := post(admin, "/namespaces/com.example/status", map[string]any{"status": "deleted", "dryRun": true})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.True(t, resp.DryRun)
+		assert.Equal(t, 3, resp.Count)
+
+		latest, err := registryService.GetServerByName(ctx, "com.example/bulk")
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusActive, latest.Meta.Official.Status)
+	})
+
+	t.Run("deletes every version of a server", func(t *testing.T) {
+		w, resp := post(admin, "/servers/com.example%2Fbulk/status", map[string]any{"status": "deleted", "reason": "Takedown: malware"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.False(t, resp.DryRun)
+		assert.Equal(t, []v0.BulkStatusChange{
+			{ServerName: "com.example/bulk", Version: "2.0.0", PreviousStatus: model.StatusActive, Status: model.StatusDeleted},
+			{ServerName: "com.example/bulk", Version: "1.0.0", PreviousStatus: model.StatusActive, Status: model.StatusDeleted},
+		}, resp.Changes)
+
+		serverName := "com.example/bulk"
+		events, _, err := registryService.ListAuditEvents(ctx, &database.AuditEventFilter{ServerName: &serverName}, "", 10)
+		require.NoError(t, err)
+		require.Len(t, events, 4)
+		for _, event := range events[:2] {
+			assert.Equal(t, service.AuditActionBulkStatus, event.Action)
+			assert.Equal(t, "Takedown: malware", event.Reason)
+			assert.Equal(t, "moderator", event.ActorSubject)
+		}
+	})
+
+	t.Run("rejects invalid changes", func(t *testing.T) {
+		w, _ := post(admin, "/servers/com.example%2Fbulk/status", map[string]any{"status": "active", "deprecationMessage": "Back"})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		w, _ = post(admin, "/servers/com.example%2Fmissing/status", map[string]any{"status": "deleted"})
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+}
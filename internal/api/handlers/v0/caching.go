@@ -0,0 +1,110 @@
+package v0
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ServersSurrogateKey is the surrogate key of every server listing, to purge from a CDN when a
+// server is published for the first time
+const ServersSurrogateKey = "servers"
+
+// ServerSurrogateKey returns the surrogate key of every response containing a version of a
+// server, to purge from a CDN when the server is published or edited
+func ServerSurrogateKey(serverName string) string {
+	return "server:" + serverName
+}
+
+// ConditionalReadInput holds the validators of a conditional read
+type ConditionalReadInput struct {
+	IfNoneMatch     string `header:"If-None-Match" doc:"ETag of a previous response; the response is only sent if it has changed since" required:"false"`
+	IfModifiedSince string `header:"If-Modified-Since" doc:"Last-Modified of a previous response; the response is only sent if it has changed since. Ignored when If-None-Match is given." required:"false"`
+}
+
+// cacheControl returns the Cache-Control of a server read. Reads made with a token can include
+// drafts, so they are only cached by the client, and revalidated every time.
+func cacheControl(cfg *config.Config, authorization string) string {
+	if authorization != "" {
+		return "private, no-cache"
+	}
+	if cfg.HTTPCacheMaxAge <= 0 {
+		return "no-cache"
+	}
+	return "public, max-age=" + strconv.Itoa(int(cfg.HTTPCacheMaxAge.Seconds()))
+}
+
+// newCachedResponse builds the response of a read of servers, or a 304 response if it matches
+// the validators of the request. The ETag is a hash of the body, so it is strong. Only reads of
+// an exact version have a last modification, see versionLastModified; for other reads, pass the
+// zero time and they are only validated by their ETag.
+func newCachedResponse[T any](input ConditionalReadInput, cacheControl string, body T, lastModified time.Time, servers []*apiv0.ServerResponse, surrogateKeys ...string) (*CachedResponse[T], error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("Failed to encode response", err)
+	}
+	sum := sha256.Sum256(data)
+
+	for _, server := range servers {
+		if key := ServerSurrogateKey(server.Server.Name); !slices.Contains(surrogateKeys, key) {
+			surrogateKeys = append(surrogateKeys, key)
+		}
+	}
+
+	response := &CachedResponse[T]{
+		Status:       http.StatusOK,
+		ETag:         `"` + hex.EncodeToString(sum[:16]) + `"`,
+		CacheControl: cacheControl,
+		SurrogateKey: strings.Join(surrogateKeys, " "),
+		Body:         body,
+	}
+	lastModified = lastModified.UTC().Truncate(time.Second)
+	if !lastModified.IsZero() {
+		response.LastModified = lastModified.Format(http.TimeFormat)
+	}
+	if notModified(input, response.ETag, lastModified) {
+		response.Status = http.StatusNotModified
+	}
+	return response, nil
+}
+
+// versionLastModified returns the last modification of a read of a version of a server, which is
+// when the version was last updated if ref named the version exactly. Reads resolving latest,
+// latest-stable or a channel can return another version, possibly updated earlier, when those
+// move, so they have none. The same goes for listings, which can lose versions that leave a filter.
+func versionLastModified(server *apiv0.ServerResponse, ref string) time.Time {
+	if server.Server.Version != ref || server.Meta.Official == nil {
+		return time.Time{}
+	}
+	return server.Meta.Official.UpdatedAt
+}
+
+// notModified reports whether the validators of a conditional read match the current response,
+// giving If-None-Match precedence over If-Modified-Since as RFC 9110 requires
+func notModified(input ConditionalReadInput, etag string, lastModified time.Time) bool {
+	if input.IfNoneMatch != "" {
+		return etagMatches(input.IfNoneMatch, etag)
+	}
+	if input.IfModifiedSince == "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(input.IfModifiedSince)
+	return err == nil && !lastModified.After(since)
+}
+
+// cachedReadResponses documents the 304 response of conditional reads. Huma adds the other
+// responses of an operation to its map, so every operation needs its own.
+func cachedReadResponses() map[string]*huma.Response {
+	return map[string]*huma.Response{
+		"304": {Description: "The response has not changed since the one identified by If-None-Match or If-Modified-Since"},
+	}
+}
@@ -0,0 +1,126 @@
+package v0_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestServerEndpointsCaching(t *testing.T) {
+	ctx := context.Background()
+	cfg := newTestConfig(t)
+	cfg.EnableRegistryValidation = false
+	cfg.HTTPCacheMaxAge = time.Minute
+	registryService := service.NewRegistryService(database.NewMemory(), cfg)
+
+	publish := func(version string) {
+		t.Helper()
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/cached",
+			Description: "Cached server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+	publish("1.0.0")
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, cfg)
+
+	get := func(path string, headers map[string]string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/v0"+path, nil)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	const exactPath = "/servers/com.example%2Fcached/versions/1.0.0"
+	paths := []string{"/servers", "/servers/com.example%2Fcached/versions", "/servers/com.example%2Fcached/versions/latest", exactPath}
+
+	t.Run("responses have validators and caching headers", func(t *testing.T) {
+		for _, path := range paths {
+			w := get(path, nil)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			assert.Regexp(t, `^"[0-9a-f]{32}"$`, w.Header().Get("ETag"), path)
+			if path == exactPath {
+				assert.NotEmpty(t, w.Header().Get("Last-Modified"), path)
+			} else {
+				// Listings and resolved versions can change to older updates, so only their ETag validates them
+				assert.Empty(t, w.Header().Get("Last-Modified"), path)
+			}
+			assert.Equal(t, "public, max-age=60", w.Header().Get("Cache-Control"), path)
+			assert.Contains(t, w.Header().Get("Surrogate-Key"), v0.ServerSurrogateKey("com.example/cached"), path)
+		}
+		assert.Contains(t, get("/servers", nil).Header().Get("Surrogate-Key"), v0.ServersSurrogateKey)
+	})
+
+	t.Run("matching validators get 304", func(t *testing.T) {
+		for _, path := range paths {
+			w := get(path, nil)
+			etag := w.Header().Get("ETag")
+
+			notModified := get(path, map[string]string{"If-None-Match": etag})
+			assert.Equal(t, http.StatusNotModified, notModified.Code, path)
+			assert.Empty(t, notModified.Body.String(), path)
+			assert.Equal(t, etag, notModified.Header().Get("ETag"), path)
+
+			assert.Equal(t, http.StatusNotModified, get(path, map[string]string{"If-None-Match": `W/` + etag}).Code, path)
+			assert.Equal(t, http.StatusOK, get(path, map[string]string{"If-None-Match": `"other"`}).Code, path)
+		}
+
+		lastModified := get(exactPath, nil).Header().Get("Last-Modified")
+		assert.Equal(t, http.StatusNotModified, get(exactPath, map[string]string{"If-Modified-Since": lastModified}).Code)
+		assert.Equal(t, http.StatusOK, get(exactPath, map[string]string{"If-Modified-Since": "Mon, 01 Jan 2024 00:00:00 GMT"}).Code)
+		// If-None-Match takes precedence over If-Modified-Since
+		assert.Equal(t, http.StatusOK, get(exactPath, map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": lastModified}).Code)
+	})
+
+	t.Run("resolved versions ignore If-Modified-Since", func(t *testing.T) {
+		future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+		assert.Equal(t, http.StatusOK, get("/servers/com.example%2Fcached/versions/latest", map[string]string{"If-Modified-Since": future}).Code)
+		assert.Equal(t, http.StatusOK, get("/servers", map[string]string{"If-Modified-Since": future}).Code)
+	})
+
+	t.Run("publishes change the validators", func(t *testing.T) {
+		etag := get("/servers/com.example%2Fcached/versions", nil).Header().Get("ETag")
+		publish("2.0.0")
+
+		w := get("/servers/com.example%2Fcached/versions", map[string]string{"If-None-Match": etag})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotEqual(t, etag, w.Header().Get("ETag"))
+	})
+
+	t.Run("reads with a token are private", func(t *testing.T) {
+		token, err := generateTestJWTToken(cfg, auth.JWTClaims{
+			AuthMethod:        auth.MethodNone,
+			AuthMethodSubject: "publisher",
+			Permissions:       []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"}},
+		})
+		require.NoError(t, err)
+
+		w := get("/servers/com.example%2Fcached/versions", map[string]string{"Authorization": "Bearer " + token})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "private, no-cache", w.Header().Get("Cache-Control"))
+		assert.Equal(t, "Authorization", w.Header().Get("Vary"))
+	})
+}
@@ -0,0 +1,58 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// ListServerChangesInput represents the input for reading the change feed
+type ListServerChangesInput struct {
+	Since int64 `query:"since" doc:"Only changes with a sequence number after this one. Start from 0, then pass metadata.nextSince of the previous response." default:"0" minimum:"0" example:"1234"`
+	Limit int   `query:"limit" doc:"Number of changes per page" default:"100" minimum:"1" maximum:"1000" example:"500"`
+}
+
+// RegisterChangesEndpoint registers the change feed endpoint with a custom path prefix
+func RegisterChangesEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-server-changes" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/changes",
+		Summary:     "List changes to MCP servers",
+		Description: "Read the feed of server version creates, updates and status changes in sequence order. " +
+			"Mirrors that resume from the last sequence number they processed never miss or see a change twice.",
+		Tags: []string{"servers"},
+	}, func(ctx context.Context, input *ListServerChangesInput) (*Response[apiv0.ServerChangeListResponse], error) {
+		changes, err := registry.ListServerChanges(ctx, input.Since, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get changes", err)
+		}
+
+		body := apiv0.ServerChangeListResponse{
+			Changes: make([]apiv0.ServerChange, len(changes)),
+			Metadata: apiv0.ChangeListMetadata{
+				NextSince: input.Since,
+				Count:     len(changes),
+			},
+		}
+		for i, change := range changes {
+			body.Changes[i] = apiv0.ServerChange{
+				Seq:        change.Seq,
+				Type:       change.ChangeType,
+				ServerName: change.ServerName,
+				Version:    change.Version,
+				Status:     model.Status(change.Status),
+				OccurredAt: change.OccurredAt,
+				Server:     *change.Server,
+			}
+			body.Metadata.NextSince = change.Seq
+		}
+
+		return &Response[apiv0.ServerChangeListResponse]{Body: body}, nil
+	})
+}
@@ -0,0 +1,90 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListServerChangesEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+
+	server := &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/feed",
+		Description: "Feed test server",
+		Version:     "1.0.0",
+	}
+	_, err := registryService.CreateServer(ctx, server)
+	require.NoError(t, err)
+	status := string(model.StatusDeleted)
+	_, err = registryService.UpdateServer(ctx, server.Name, "1.0.0", server, &status, nil)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterChangesEndpoint(api, "/v0", registryService)
+
+	list := func(query string) apiv0.ServerChangeListResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/v0/changes"+query, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp apiv0.ServerChangeListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp
+	}
+
+	// Follow the feed a change at a time
+	var seen []apiv0.ServerChange
+	since := int64(0)
+	for {
+		resp := list(fmt.Sprintf("?since=%d&limit=1", since))
+		if resp.Metadata.Count == 0 {
+			assert.Equal(t, since, resp.Metadata.NextSince)
+			break
+		}
+		seen = append(seen, resp.Changes...)
+		since = resp.Metadata.NextSince
+	}
+
+	// The edit changed the server.json and then the status, which took away the latest and
+	// latest stable flags
+	require.Len(t, seen, 5)
+	assert.Equal(t, "created", seen[0].Type)
+	assert.Equal(t, "updated", seen[1].Type)
+	assert.Equal(t, "status_changed", seen[2].Type)
+	assert.Equal(t, model.StatusDeleted, seen[2].Status)
+	assert.Equal(t, "updated", seen[3].Type)
+	assert.Equal(t, "updated", seen[4].Type)
+	assert.False(t, seen[4].Server.Meta.Official.IsLatest)
+	assert.False(t, seen[4].Server.Meta.Official.IsLatestStable)
+	assert.Equal(t, model.StatusDeleted, seen[0].Server.Meta.Official.Status)
+
+	// A fresh mirror starts from the beginning
+	resp := list("")
+	assert.Equal(t, 5, resp.Metadata.Count)
+	assert.Equal(t, seen[4].Seq, resp.Metadata.NextSince)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/changes?since=-1", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
@@ -0,0 +1,203 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// ServerChannel is a named pointer to a version of a server
+type ServerChannel struct {
+	Channel   string    `json:"channel" example:"next"`
+	Version   string    `json:"version" example:"2.0.0-beta.1"`
+	UpdatedAt time.Time `json:"updatedAt" format:"date-time" doc:"When the channel was last pointed at a version"`
+}
+
+// ServerChannelListResponse is the list of channels of a server
+type ServerChannelListResponse struct {
+	Channels []ServerChannel `json:"channels"`
+}
+
+// ListServerChannelsInput represents the input for listing the channels of a server
+type ListServerChannelsInput struct {
+	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+}
+
+// SetServerChannelInput represents the input for pointing a channel at a version
+type SetServerChannelInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permission for the server" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Channel       string `path:"channel" doc:"Channel name: a lowercase tag that is not a version, 'latest' or 'latest-stable'" example:"next"`
+	Body          struct {
+		Version string `json:"version" doc:"Version to point the channel at. It must be published and not deleted." minLength:"1" maxLength:"255" example:"2.0.0-beta.1"`
+	}
+}
+
+// DeleteServerChannelInput represents the input for deleting a channel
+type DeleteServerChannelInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permission for the server" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Channel       string `path:"channel" doc:"Channel name" example:"next"`
+}
+
+// RegisterChannelEndpoints registers the server channel endpoints with a custom path prefix
+func RegisterChannelEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	// authorizePublisher checks that the token can publish the server, scoping namespace ownership to it
+	authorizePublisher := func(ctx context.Context, serverName string, claims *auth.JWTClaims) (context.Context, error) {
+		if !jwtManager.HasPermission(serverName, auth.PermissionActionPublish, claims.Permissions) &&
+			!jwtManager.HasPermission(serverName, auth.PermissionActionEdit, claims.Permissions) {
+			return nil, huma.Error403Forbidden(buildPermissionErrorMessage(serverName, claims.Permissions))
+		}
+		if owners := jwtManager.PermissionOwners(serverName, auth.PermissionActionPublish, claims.Permissions); len(owners) > 0 {
+			ctx = service.WithNamespaceOwners(ctx, string(claims.AuthMethod), toNamespaceOwners(owners))
+		}
+		return ctx, nil
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-server-channels" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/channels",
+		Summary:     "List MCP server channels",
+		Description: "List the named channels of a server, such as 'next' or 'beta', and the versions they point at. " +
+			"Channel names can be used in place of a version to get the version they point at.",
+		Tags: []string{"servers"},
+	}, func(ctx context.Context, input *ListServerChannelsInput) (*Response[ServerChannelListResponse], error) {
+		serverName, err := url.PathUnescape(input.ServerName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid server name encoding", err)
+		}
+
+		channels, err := registry.ListServerChannels(ctx, serverName)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server channels", err)
+		}
+
+		body := ServerChannelListResponse{Channels: make([]ServerChannel, len(channels))}
+		for i, channel := range channels {
+			body.Channels[i] = toServerChannel(channel)
+		}
+
+		return &Response[ServerChannelListResponse]{Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-server-channel" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/servers/{serverName}/channels/{channel}",
+		Summary:     "Point MCP server channel at a version",
+		Description: "Create a named channel of a server, or move it to another version, with the same permission as publishing the server.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *SetServerChannelInput) (*Response[ServerChannel], error) {
+		ctx, claims, err := authenticate(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		serverName, channelName, err := decodeServerChannelPath(input.ServerName, input.Channel)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx, err = authorizePublisher(ctx, serverName, claims)
+		if err != nil {
+			return nil, err
+		}
+
+		channel, err := registry.SetServerChannel(ctx, serverName, channelName, input.Body.Version)
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				return nil, huma.Error404NotFound("Server version not found")
+			case errors.Is(err, database.ErrInvalidInput):
+				return nil, huma.Error400BadRequest("Invalid channel", err)
+			case errors.Is(err, service.ErrNamespaceOwnedByOther):
+				return nil, huma.Error403Forbidden("You do not own this server's namespace", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to set server channel", err)
+		}
+
+		return &Response[ServerChannel]{Body: toServerChannel(channel)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-server-channel" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodDelete,
+		Path:        pathPrefix + "/servers/{serverName}/channels/{channel}",
+		Summary:     "Delete MCP server channel",
+		Description: "Delete a named channel of a server, with the same permission as publishing the server.",
+		Tags:        []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *DeleteServerChannelInput) (*struct{}, error) {
+		ctx, claims, err := authenticate(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		serverName, channelName, err := decodeServerChannelPath(input.ServerName, input.Channel)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx, err = authorizePublisher(ctx, serverName, claims)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := registry.DeleteServerChannel(ctx, serverName, channelName); err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				return nil, huma.Error404NotFound("Channel not found")
+			case errors.Is(err, service.ErrNamespaceOwnedByOther):
+				return nil, huma.Error403Forbidden("You do not own this server's namespace", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to delete server channel", err)
+		}
+
+		return nil, nil
+	})
+}
+
+// decodeServerChannelPath URL-decodes the server name and channel of a channel path
+func decodeServerChannelPath(encodedServerName, encodedChannel string) (string, string, error) {
+	serverName, err := url.PathUnescape(encodedServerName)
+	if err != nil {
+		return "", "", huma.Error400BadRequest("Invalid server name encoding", err)
+	}
+
+	channel, err := url.PathUnescape(encodedChannel)
+	if err != nil {
+		return "", "", huma.Error400BadRequest("Invalid channel encoding", err)
+	}
+
+	return serverName, channel, nil
+}
+
+// toServerChannel converts a stored channel to its API representation
+func toServerChannel(channel *database.ServerChannel) ServerChannel {
+	return ServerChannel{
+		Channel:   channel.Channel,
+		Version:   channel.Version,
+		UpdatedAt: channel.UpdatedAt,
+	}
+}
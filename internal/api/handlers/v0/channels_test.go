@@ -0,0 +1,109 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestServerChannelEndpoints(t *testing.T) {
+	ctx := context.Background()
+	cfg := newTestConfig(t)
+	cfg.EnableRegistryValidation = false
+	registryService := service.NewRegistryService(database.NewMemory(), cfg)
+
+	for _, version := range []string{"1.0.0", "2.0.0-beta.1"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/channel-server",
+			Description: "Channel test server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, cfg)
+	v0.RegisterChannelEndpoints(api, "/v0", registryService, cfg)
+
+	publisher := testAuthHeader(t, cfg, "publisher", auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"})
+	do := func(method, path, authHeader string, body any) *httptest.ResponseRecorder {
+		return serveTestRequest(t, mux, method, "/v0/servers/com.example%2Fchannel-server"+path, authHeader, body)
+	}
+	version := func(path string) string {
+		t.Helper()
+		w := do(http.MethodGet, path, "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var server apiv0.ServerResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &server))
+		return server.Server.Version
+	}
+
+	t.Run("latest-stable skips prereleases", func(t *testing.T) {
+		assert.Equal(t, "2.0.0-beta.1", version("/versions/latest"))
+		assert.Equal(t, "1.0.0", version("/versions/latest-stable"))
+
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers?version=latest-stable", nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list apiv0.ServerListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Servers, 1)
+		assert.Equal(t, "1.0.0", list.Servers[0].Server.Version)
+		assert.True(t, list.Servers[0].Meta.Official.IsLatestStable)
+	})
+
+	t.Run("publisher can point a channel at a version", func(t *testing.T) {
+		w := do(http.MethodPut, "/channels/next", publisher, map[string]string{"version": "2.0.0-beta.1"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "2.0.0-beta.1", version("/versions/next"))
+
+		w = do(http.MethodGet, "/channels", "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list v0.ServerChannelListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Channels, 1)
+		assert.Equal(t, "next", list.Channels[0].Channel)
+		assert.Equal(t, "2.0.0-beta.1", list.Channels[0].Version)
+	})
+
+	t.Run("requires publish permission for the server", func(t *testing.T) {
+		outsider := testAuthHeader(t, cfg, "publisher", auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "org.example/*"})
+		w := do(http.MethodPut, "/channels/beta", outsider, map[string]string{"version": "1.0.0"})
+		assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+		w = do(http.MethodPut, "/channels/beta", "", map[string]string{"version": "1.0.0"})
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	})
+
+	t.Run("rejects reserved channels and missing versions", func(t *testing.T) {
+		w := do(http.MethodPut, "/channels/latest", publisher, map[string]string{"version": "1.0.0"})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		w = do(http.MethodPut, "/channels/beta", publisher, map[string]string{"version": "9.9.9"})
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+
+	t.Run("publisher can delete a channel", func(t *testing.T) {
+		w := do(http.MethodDelete, "/channels/next", publisher, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+		w = do(http.MethodGet, "/versions/next", "", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+		w = do(http.MethodDelete, "/channels/next", publisher, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+}
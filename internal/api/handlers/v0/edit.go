@@ -18,11 +18,16 @@ import (
 
 // EditServerInput represents the input for editing a server
 type EditServerInput struct {
-	Authorization string           `header:"Authorization" doc:"Registry JWT token with edit permissions" required:"true"`
-	ServerName    string           `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
-	Version       string           `path:"version" doc:"URL-encoded version to edit" example:"1.0.0"`
-	Status        string           `query:"status" doc:"New status for the server (active, deprecated, deleted)" required:"false" enum:"active,deprecated,deleted"`
-	Body          apiv0.ServerJSON `body:""`
+	Authorization string `header:"Authorization" doc:"Registry JWT token with edit permissions" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version       string `path:"version" doc:"URL-encoded version to edit" example:"1.0.0"`
+	Status        string `query:"status" doc:"New status for the server (active, deprecated, deleted)" required:"false" enum:"active,deprecated,deleted"`
+	Reason        string `query:"reason" doc:"Why the change is being made, recorded in the audit log" required:"false" maxLength:"1000" example:"Takedown: malware reported in #123"`
+
+	DeprecationMessage string           `query:"deprecationMessage" doc:"Explanation shown to consumers of a deprecated or deleted version; requires status" required:"false" maxLength:"1000" example:"Superseded by com.example/my-server-v2"`
+	ReplacedBy         string           `query:"replacedBy" doc:"Name of the server that consumers of a deprecated or deleted version should move to; requires status" required:"false" maxLength:"200" example:"com.example/my-server-v2"`
+	ReplacedByVersion  string           `query:"replacedByVersion" doc:"Version of the replacement server; requires replacedBy" required:"false" maxLength:"255" example:"2.0.0"`
+	Body               apiv0.ServerJSON `body:""`
 }
 
 // RegisterEditEndpoints registers the edit endpoint with a custom path prefix
@@ -41,18 +46,10 @@ func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.Reg
 			{"bearer": {}},
 		},
 	}, func(ctx context.Context, input *EditServerInput) (*Response[apiv0.ServerResponse], error) {
-		// Extract bearer token
-		const bearerPrefix = "Bearer "
-		authHeader := input.Authorization
-		if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
-			return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
-		}
-		token := authHeader[len(bearerPrefix):]
-
-		// Validate Registry JWT token
-		claims, err := jwtManager.ValidateToken(ctx, token)
+		// Validate the Registry JWT token, recording its holder as the actor of this change
+		ctx, claims, err := authenticate(ctx, jwtManager, input.Authorization)
 		if err != nil {
-			return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+			return nil, err
 		}
 
 		// URL-decode the server name
@@ -102,9 +99,24 @@ func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.Reg
 				return nil, huma.Error400BadRequest("Cannot change status of deleted server. Deleted servers cannot be undeleted.")
 			}
 
-			// For now, only allow status changes for admins
-			// Future: Implement logic to allow server authors to change active <-> deprecated
-			// but only admins can set to deleted
+			// Authors switch between active and deprecated through the status endpoint, so
+			// status changes here, including deletion, are for holders of edit permission
+		}
+
+		if input.Reason != "" {
+			ctx = service.WithAuditReason(ctx, input.Reason)
+		}
+
+		// Deprecation details replace the current ones along with the status
+		var statusDetails *database.StatusDetails
+		if input.DeprecationMessage != "" || input.ReplacedBy != "" || input.ReplacedByVersion != "" {
+			if input.ReplacedBy == "" && input.ReplacedByVersion != "" {
+				return nil, huma.Error400BadRequest("replacedByVersion requires replacedBy")
+			}
+			statusDetails = &database.StatusDetails{Message: input.DeprecationMessage}
+			if input.ReplacedBy != "" {
+				statusDetails.ReplacedBy = &apiv0.ReplacedBy{Name: input.ReplacedBy, Version: input.ReplacedByVersion}
+			}
 		}
 
 		// Update the server using the service
@@ -112,12 +124,12 @@ func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.Reg
 		if input.Status != "" {
 			statusPtr = &input.Status
 		}
-		updatedServer, err := registry.UpdateServer(ctx, serverName, version, &input.Body, statusPtr)
+		updatedServer, err := registry.UpdateServer(ctx, serverName, version, &input.Body, statusPtr, statusDetails)
 		if err != nil {
 			if errors.Is(err, database.ErrNotFound) {
 				return nil, huma.Error404NotFound("Server not found")
 			}
-			return nil, huma.Error400BadRequest("Failed to edit server", err)
+			return nil, huma.Error400BadRequest("Failed to edit server", problemDetails(err)...)
 		}
 
 		return &Response[apiv0.ServerResponse]{
@@ -87,7 +87,7 @@ func TestEditServerEndpoint(t *testing.T) {
 	require.NoError(t, err)
 
 	// Set the server to deleted status
-	_, err = registryService.UpdateServer(context.Background(), deletedServer.Name, deletedServer.Version, deletedServer, stringPtr(string(model.StatusDeleted)))
+	_, err = registryService.UpdateServer(context.Background(), deletedServer.Name, deletedServer.Version, deletedServer, stringPtr(string(model.StatusDeleted)), nil)
 	require.NoError(t, err)
 
 	// Create a server with build metadata for URL encoding test
@@ -461,7 +461,7 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 				Name:        server.name,
 				Description: "Test server for editing",
 				Version:     server.version,
-			}, stringPtr(string(server.status)))
+			}, stringPtr(string(server.status)), nil)
 			require.NoError(t, err)
 		}
 	}
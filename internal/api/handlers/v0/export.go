@@ -0,0 +1,121 @@
+package v0
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// Headers describing the snapshot an export was taken from
+const (
+	// ChangeSeqHeader is the change feed position of the snapshot: pass it as since to the change
+	// feed to continue from the export
+	ChangeSeqHeader = "X-Registry-Change-Seq"
+	// SnapshotTimeHeader is when the snapshot was taken, for use as updated_since
+	SnapshotTimeHeader = "X-Registry-Snapshot-Time"
+)
+
+// ExportServersInput represents the input for exporting the registry
+type ExportServersInput struct {
+	IfNoneMatch string `header:"If-None-Match" doc:"ETag of a previous export; the export is only sent if the registry has changed since" required:"false"`
+}
+
+// RegisterExportEndpoint registers the full registry export endpoint with a custom path prefix
+func RegisterExportEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "export-servers" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/export",
+		Summary:     "Export all MCP servers",
+		Description: "Stream every server version with its official metadata as gzip-compressed newline-delimited JSON, " +
+			"read from a single consistent snapshot. The " + ChangeSeqHeader + " header gives the change feed position " +
+			"of the snapshot, so consumers can continue from /changes without missing or repeating a change.",
+		Tags: []string{"servers"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "One server version per line, in name and release order",
+				Content: map[string]*huma.MediaType{
+					"application/x-ndjson": {},
+				},
+				Headers: map[string]*huma.Param{
+					"ETag":             {Description: "Identifies the registry state the export was taken from", Schema: &huma.Schema{Type: huma.TypeString}},
+					ChangeSeqHeader:    {Description: "Change feed sequence number of the last change included in the export", Schema: &huma.Schema{Type: huma.TypeInteger}},
+					SnapshotTimeHeader: {Description: "When the snapshot was taken (RFC3339 datetime)", Schema: &huma.Schema{Type: huma.TypeString}},
+				},
+			},
+			"304": {Description: "The registry has not changed since the export identified by If-None-Match"},
+		},
+	}, func(_ context.Context, input *ExportServersInput) (*huma.StreamResponse, error) {
+		return &huma.StreamResponse{
+			Body: func(ctx huma.Context) {
+				started := false
+				err := registry.ExportServers(ctx.Context(), func(export *database.ServerExport) error {
+					started = true
+					etag := exportETag(export.Seq)
+					ctx.SetHeader("ETag", etag)
+					ctx.SetHeader(ChangeSeqHeader, strconv.FormatInt(export.Seq, 10))
+					ctx.SetHeader(SnapshotTimeHeader, export.SnapshotAt.UTC().Format(time.RFC3339Nano))
+					if etagMatches(input.IfNoneMatch, etag) {
+						ctx.SetStatus(http.StatusNotModified)
+						return nil
+					}
+
+					ctx.SetHeader("Content-Type", "application/x-ndjson")
+					ctx.SetHeader("Content-Encoding", "gzip")
+					ctx.SetStatus(http.StatusOK)
+
+					gz := gzip.NewWriter(ctx.BodyWriter())
+					encoder := json.NewEncoder(gz)
+					for server, err := range export.Servers {
+						if err != nil {
+							return err
+						}
+						if err := encoder.Encode(server); err != nil {
+							return err
+						}
+					}
+					// The gzip trailer is only written once every server has been, so a failed
+					// export is seen by consumers as a truncated stream rather than a short one
+					return gz.Close()
+				})
+				if err == nil {
+					return
+				}
+				if !started {
+					_ = huma.WriteErr(api, ctx, http.StatusInternalServerError, "Failed to export servers", err)
+					return
+				}
+				log.Printf("Failed to export servers: %v", err)
+			},
+		}, nil
+	})
+}
+
+// exportETag returns the ETag of an export taken at a change feed position. Every change to a
+// server version is recorded in the change feed, so the position identifies the registry state.
+func exportETag(seq int64) string {
+	return `W/"` + strconv.FormatInt(seq, 10) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header matches an ETag, using the weak
+// comparison that RFC 9110 requires for If-None-Match
+func etagMatches(ifNoneMatch, etag string) bool {
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
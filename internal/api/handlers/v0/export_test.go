@@ -0,0 +1,116 @@
+package v0_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportServersEndpoint(t *testing.T) {
+	ctx := context.Background()
+	registryService := service.NewRegistryService(database.NewMemory(), &config.Config{EnableRegistryValidation: false})
+
+	publish := func(name, version string) {
+		t.Helper()
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Export test server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+	publish("com.example/export-b", "1.0.0")
+	publish("com.example/export-a", "1.0.0")
+	publish("com.example/export-a", "1.1.0")
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterExportEndpoint(api, "/v0.1", registryService)
+	v0.RegisterChangesEndpoint(api, "/v0.1", registryService)
+
+	export := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v0.1/export", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	w := export("")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	etag := w.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+	_, err := time.Parse(time.RFC3339Nano, w.Header().Get(v0.SnapshotTimeHeader))
+	require.NoError(t, err)
+	seq, err := strconv.ParseInt(w.Header().Get(v0.ChangeSeqHeader), 10, 64)
+	require.NoError(t, err)
+
+	t.Run("streams every version with official metadata", func(t *testing.T) {
+		gz, err := gzip.NewReader(w.Body)
+		require.NoError(t, err)
+
+		var exported []string
+		scanner := bufio.NewScanner(gz)
+		for scanner.Scan() {
+			var server apiv0.ServerResponse
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &server))
+			require.NotNil(t, server.Meta.Official)
+			assert.Equal(t, model.StatusActive, server.Meta.Official.Status)
+			exported = append(exported, server.Server.Name+"@"+server.Server.Version)
+		}
+		require.NoError(t, scanner.Err())
+		assert.Equal(t, []string{
+			"com.example/export-a@1.0.0",
+			"com.example/export-a@1.1.0",
+			"com.example/export-b@1.0.0",
+		}, exported)
+	})
+
+	t.Run("unchanged registry is not modified", func(t *testing.T) {
+		w := export(etag)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.Equal(t, etag, w.Header().Get("ETag"))
+	})
+
+	t.Run("change feed continues from the export", func(t *testing.T) {
+		publish("com.example/export-c", "1.0.0")
+
+		req := httptest.NewRequest(http.MethodGet, "/v0.1/changes?since="+strconv.FormatInt(seq, 10), nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var resp apiv0.ServerChangeListResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		require.Len(t, resp.Changes, 1)
+		assert.Equal(t, "com.example/export-c", resp.Changes[0].ServerName)
+
+		// The registry changed, so the old ETag no longer matches
+		w := export(etag)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotEqual(t, etag, w.Header().Get("ETag"))
+	})
+}
@@ -0,0 +1,88 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// RecomputeLatestInput represents the input for recomputing latest versions
+type RecomputeLatestInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	Body          struct {
+		ServerName string `json:"serverName,omitempty" doc:"Only recompute the latest version of this server" required:"false" example:"com.example/my-server"`
+		Namespace  string `json:"namespace,omitempty" doc:"Only recompute the latest versions of the servers in this namespace" required:"false" example:"com.example"`
+	}
+}
+
+// LatestChange is a server whose latest or latest stable version was moved
+type LatestChange struct {
+	ServerName           string `json:"serverName" example:"com.example/my-server"`
+	PreviousLatest       string `json:"previousLatest,omitempty" doc:"Version that was latest, absent if none was" example:"2.0.0"`
+	Latest               string `json:"latest,omitempty" doc:"Version that is now latest, absent if every version is deleted" example:"1.5.0"`
+	PreviousLatestStable string `json:"previousLatestStable,omitempty" doc:"Version that was latest stable, absent if none was" example:"2.0.0"`
+	LatestStable         string `json:"latestStable,omitempty" doc:"Version that is now latest stable, absent if no release is active or deprecated" example:"1.5.0"`
+}
+
+// RecomputeLatestResponse reports the outcome of recomputing latest versions
+type RecomputeLatestResponse struct {
+	Checked int            `json:"checked" doc:"Number of servers checked"`
+	Changes []LatestChange `json:"changes" doc:"Servers whose latest or latest stable version moved"`
+}
+
+// RegisterLatestEndpoints registers the admin endpoint that repairs latest versions with a custom path prefix
+func RegisterLatestEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "recompute-latest" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/admin/latest/recompute",
+		Summary:     "Recompute latest versions",
+		Description: "Mark the highest active version of each server as latest, and its highest active release as latest stable, " +
+			"clearing them for servers without active versions and repairing servers whose latest version was deprecated or deleted (admin only). Applies to one server, the servers of a namespace, or every server when neither is given.",
+		Tags: []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *RecomputeLatestInput) (*Response[RecomputeLatestResponse], error) {
+		ctx, _, err := authenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		changes, checked, err := registry.RecomputeLatest(ctx, input.Body.ServerName, input.Body.Namespace)
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				return nil, huma.Error404NotFound("Server not found")
+			case errors.Is(err, database.ErrInvalidInput):
+				return nil, huma.Error400BadRequest("Invalid recompute request", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to recompute latest versions", err)
+		}
+
+		body := RecomputeLatestResponse{
+			Checked: checked,
+			Changes: make([]LatestChange, len(changes)),
+		}
+		for i, change := range changes {
+			body.Changes[i] = LatestChange{
+				ServerName:           change.ServerName,
+				PreviousLatest:       change.Previous,
+				Latest:               change.Latest,
+				PreviousLatestStable: change.PreviousStable,
+				LatestStable:         change.LatestStable,
+			}
+		}
+
+		return &Response[RecomputeLatestResponse]{Body: body}, nil
+	})
+}
@@ -0,0 +1,87 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestRecomputeLatestEndpoint(t *testing.T) {
+	ctx := context.Background()
+	cfg := newTestConfig(t)
+	cfg.EnableRegistryValidation = false
+	db := database.NewMemory()
+	registryService := service.NewRegistryService(db, cfg)
+
+	for _, version := range []string{"1.0.0", "2.0.0"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/recompute",
+			Description: "Recompute test server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+	// Deleted without recomputing latest, as before status changes did
+	_, err := db.SetServerStatus(ctx, nil, "com.example/recompute", "2.0.0", string(model.StatusDeleted), nil)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterLatestEndpoints(api, "/v0", registryService, cfg)
+
+	admin := testAuthHeader(t, cfg, "admin", auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"})
+	recompute := func(authHeader string, body map[string]string) *httptest.ResponseRecorder {
+		return serveTestRequest(t, mux, http.MethodPost, "/v0/admin/latest/recompute", authHeader, body)
+	}
+
+	t.Run("requires admin permission", func(t *testing.T) {
+		publisher := testAuthHeader(t, cfg, "publisher", auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "*"})
+		w := recompute(publisher, map[string]string{})
+		assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	})
+
+	t.Run("repairs the latest version", func(t *testing.T) {
+		w := recompute(admin, map[string]string{"serverName": "com.example/recompute"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp v0.RecomputeLatestResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, 1, resp.Checked)
+		assert.Equal(t, []v0.LatestChange{{
+			ServerName:           "com.example/recompute",
+			PreviousLatest:       "2.0.0",
+			Latest:               "1.0.0",
+			PreviousLatestStable: "2.0.0",
+			LatestStable:         "1.0.0",
+		}}, resp.Changes)
+
+		latest, err := registryService.GetServerByName(ctx, "com.example/recompute")
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", latest.Server.Version)
+	})
+
+	t.Run("unknown server", func(t *testing.T) {
+		w := recompute(admin, map[string]string{"serverName": "com.example/missing"})
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+
+	t.Run("server name and namespace are exclusive", func(t *testing.T) {
+		w := recompute(admin, map[string]string{"serverName": "com.example/recompute", "namespace": "com.example"})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+}
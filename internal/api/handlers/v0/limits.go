@@ -0,0 +1,185 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+)
+
+// ListPublishLimitsInput represents the input for listing publish limits
+type ListPublishLimitsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+}
+
+// SetPublishLimitsInput represents the input for setting the publish limits of a namespace
+type SetPublishLimitsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	Namespace     string `path:"namespace" doc:"Namespace, the part of server names before the '/'" example:"io.github.octocat"`
+	Body          PublishLimitsBody
+}
+
+// DeletePublishLimitsInput represents the input for resetting the publish limits of a namespace
+type DeletePublishLimitsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	Namespace     string `path:"namespace" doc:"Namespace, the part of server names before the '/'" example:"io.github.octocat"`
+}
+
+// PublishLimitsBody holds the publish limits of a namespace, where 0 means no limit
+type PublishLimitsBody struct {
+	PublishesPerHour int `json:"publishesPerHour" doc:"Publishes per hour to the namespace (0 for no limit)" minimum:"0" example:"100"`
+	NewServersPerDay int `json:"newServersPerDay" doc:"Publishes of new servers per day to the namespace (0 for no limit)" minimum:"0" example:"20"`
+}
+
+// NamespacePublishLimits are the publish limits set for a namespace
+type NamespacePublishLimits struct {
+	Namespace string `json:"namespace" example:"io.github.octocat"`
+	PublishLimitsBody
+	UpdatedAt time.Time `json:"updatedAt" doc:"When the limits were last set"`
+}
+
+// PublishLimitsResponse lists the default publish limits and the namespaces with other limits
+type PublishLimitsResponse struct {
+	Defaults   PublishLimitsBody        `json:"defaults" doc:"Limits of namespaces without limits of their own"`
+	Namespaces []NamespacePublishLimits `json:"namespaces" doc:"Namespaces with limits of their own, ordered by namespace"`
+}
+
+// RegisterPublishLimitsEndpoints registers the publish limit admin endpoints with a custom path prefix
+func RegisterPublishLimitsEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	// List publish limits endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "list-publish-limits" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/publish-limits",
+		Summary:     "List publish limits",
+		Description: "List the default publish limits, and the namespaces with limits of their own (admin only).",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListPublishLimitsInput) (*Response[PublishLimitsResponse], error) {
+		ctx, _, err := authenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		defaults, namespaces, err := registry.ListPublishLimits(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get publish limits", err)
+		}
+
+		body := PublishLimitsResponse{
+			Defaults:   toPublishLimitsBody(defaults),
+			Namespaces: make([]NamespacePublishLimits, len(namespaces)),
+		}
+		for i, limits := range namespaces {
+			body.Namespaces[i] = toNamespacePublishLimits(limits)
+		}
+
+		return &Response[PublishLimitsResponse]{Body: body}, nil
+	})
+
+	// Set publish limits endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "set-publish-limits" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/admin/publish-limits/{namespace}",
+		Summary:     "Set namespace publish limits",
+		Description: "Set the publish limits of a namespace, replacing the defaults (admin only). " +
+			"Publishers are also limited by the configured per-publisher limits, which namespace limits do not change.",
+		Tags: []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *SetPublishLimitsInput) (*Response[NamespacePublishLimits], error) {
+		ctx, _, err := authenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		namespace, err := decodeNamespacePath(input.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		limits, err := registry.SetPublishLimits(ctx, namespace, input.Body.PublishesPerHour, input.Body.NewServersPerDay)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Invalid publish limits", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to set publish limits", err)
+		}
+
+		return &Response[NamespacePublishLimits]{Body: toNamespacePublishLimits(limits)}, nil
+	})
+
+	// Delete publish limits endpoint
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-publish-limits" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/admin/publish-limits/{namespace}",
+		Summary:       "Reset namespace publish limits",
+		Description:   "Delete the publish limits of a namespace, making it subject to the defaults again (admin only).",
+		Tags:          []string{"admin"},
+		DefaultStatus: http.StatusNoContent,
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *DeletePublishLimitsInput) (*struct{}, error) {
+		ctx, _, err := authenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		namespace, err := decodeNamespacePath(input.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := registry.DeletePublishLimits(ctx, namespace); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Namespace has no publish limits of its own")
+			}
+			return nil, huma.Error500InternalServerError("Failed to delete publish limits", err)
+		}
+
+		return nil, nil
+	})
+}
+
+// rateLimited converts a publish limit error to a 429 response telling the client when to retry
+func rateLimited(err *service.RateLimitError) error {
+	retryAfter := strconv.Itoa(int(math.Ceil(err.RetryAfter.Seconds())))
+	return huma.ErrorWithHeaders(
+		huma.Error429TooManyRequests("Publish limit exceeded: "+err.Limit, err),
+		http.Header{"Retry-After": []string{retryAfter}},
+	)
+}
+
+// toPublishLimitsBody converts stored publish limits to their API representation
+func toPublishLimitsBody(limits *database.PublishLimits) PublishLimitsBody {
+	return PublishLimitsBody{
+		PublishesPerHour: limits.PublishesPerHour,
+		NewServersPerDay: limits.NewServersPerDay,
+	}
+}
+
+// toNamespacePublishLimits converts the stored publish limits of a namespace to their API representation
+func toNamespacePublishLimits(limits *database.PublishLimits) NamespacePublishLimits {
+	return NamespacePublishLimits{
+		Namespace:         limits.Namespace,
+		PublishLimitsBody: toPublishLimitsBody(limits),
+		UpdatedAt:         limits.UpdatedAt,
+	}
+}
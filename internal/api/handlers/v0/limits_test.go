@@ -0,0 +1,127 @@
+package v0_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestPublishLimitsEndpoints(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.EnableRegistryValidation = false
+	cfg.PublishesPerHour = 2
+	cfg.NewServersPerDay = 1
+	registryService := service.NewRegistryService(database.NewMemory(), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, cfg)
+	v0.RegisterPublishLimitsEndpoints(api, "/v0", registryService, cfg)
+
+	admin := testAuthHeader(t, cfg, "moderator", auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"})
+	publisher := testAuthHeader(t, cfg, "publisher", auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "*"})
+	serve := func(method, target, authHeader string, body any) *httptest.ResponseRecorder {
+		return serveTestRequest(t, mux, method, target, authHeader, body)
+	}
+	publish := func(name, version string) *httptest.ResponseRecorder {
+		return serve(http.MethodPost, "/v0/publish", publisher, apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Limited server",
+			Version:     version,
+		})
+	}
+
+	t.Run("requires admin permission", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, serve(http.MethodGet, "/v0/admin/publish-limits", publisher, nil).Code)
+		w := serve(http.MethodPut, "/v0/admin/publish-limits/com.example", publisher, v0.PublishLimitsBody{PublishesPerHour: 10})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("new servers beyond the daily quota are rejected", func(t *testing.T) {
+		w := publish("com.example/first", "1.0.0")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = publish("com.example/second", "1.0.0")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code, w.Body.String())
+		retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+		require.NoError(t, err)
+		assert.Greater(t, retryAfter, 23*60*60)
+		assert.Contains(t, w.Body.String(), "new servers per day")
+	})
+
+	t.Run("publishes beyond the hourly rate are rejected", func(t *testing.T) {
+		w := publish("com.example/first", "1.0.1")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		w = publish("com.example/first", "1.0.2")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code, w.Body.String())
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+		assert.Contains(t, w.Body.String(), "publishes per hour")
+	})
+
+	t.Run("admins can raise the limits of a namespace", func(t *testing.T) {
+		w := serve(http.MethodPut, "/v0/admin/publish-limits/com.example", admin, v0.PublishLimitsBody{PublishesPerHour: 10, NewServersPerDay: 0})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var limits v0.NamespacePublishLimits
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &limits))
+		assert.Equal(t, "com.example", limits.Namespace)
+		assert.Equal(t, 10, limits.PublishesPerHour)
+		assert.False(t, limits.UpdatedAt.IsZero())
+
+		w = serve(http.MethodGet, "/v0/admin/publish-limits", admin, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list v0.PublishLimitsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		assert.Equal(t, v0.PublishLimitsBody{PublishesPerHour: 2, NewServersPerDay: 1}, list.Defaults)
+		require.Len(t, list.Namespaces, 1)
+		assert.Equal(t, "com.example", list.Namespaces[0].Namespace)
+
+		assert.Equal(t, http.StatusOK, publish("com.example/first", "1.0.2").Code)
+		assert.Equal(t, http.StatusOK, publish("com.example/second", "1.0.0").Code, "0 means no limit")
+	})
+
+	t.Run("limits are rejected when negative", func(t *testing.T) {
+		w := serve(http.MethodPut, "/v0/admin/publish-limits/com.example", admin, map[string]any{"publishesPerHour": -1, "newServersPerDay": 0})
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	})
+
+	t.Run("deleting limits restores the defaults", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, serve(http.MethodDelete, "/v0/admin/publish-limits/com.example", admin, nil).Code)
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodDelete, "/v0/admin/publish-limits/com.example", admin, nil).Code)
+		assert.Equal(t, http.StatusTooManyRequests, publish("com.example/third", "1.0.0").Code)
+	})
+
+	t.Run("admins are not limited", func(t *testing.T) {
+		adminPublisher, err := generateTestJWTToken(cfg, auth.JWTClaims{
+			AuthMethod:        auth.MethodNone,
+			AuthMethodSubject: "moderator",
+			Permissions: []auth.Permission{
+				{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+				{Action: auth.PermissionActionEdit, ResourcePattern: "*"},
+			},
+		})
+		require.NoError(t, err)
+		w := serve(http.MethodPost, "/v0/publish", "Bearer "+adminPublisher, apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/third",
+			Description: "Limited server",
+			Version:     "1.0.0",
+		})
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+}
@@ -0,0 +1,201 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListNamespacesInput represents the input for listing namespace owners
+type ListNamespacesInput struct {
+	Cursor    string `query:"cursor" doc:"Pagination cursor" required:"false" example:"com.example"`
+	Limit     int    `query:"limit" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100" example:"50"`
+	OwnerType string `query:"owner_type" doc:"Only namespaces with this owner type" required:"false" enum:"github,domain"`
+	OwnerID   string `query:"owner_id" doc:"Only namespaces owned by this ID (a GitHub account ID, or a domain)" required:"false" example:"123456"`
+}
+
+// NamespaceInput represents the input for getting the owner of a namespace
+type NamespaceInput struct {
+	Namespace string `path:"namespace" doc:"Namespace, the part of server names before the '/'" example:"io.github.octocat"`
+}
+
+// TransferNamespaceInput represents the input for transferring a namespace
+type TransferNamespaceInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	Namespace     string `path:"namespace" doc:"Namespace, the part of server names before the '/'" example:"io.github.octocat"`
+	Body          NamespaceOwner
+}
+
+// NamespaceOwner identifies the owner of a namespace
+type NamespaceOwner struct {
+	Type string `json:"type" doc:"Kind of identity" enum:"github,domain" example:"github"`
+	ID   string `json:"id" doc:"GitHub account ID (not the login, which can change), or the domain name" minLength:"1" example:"123456"`
+	Name string `json:"name,omitempty" doc:"GitHub login, for display" required:"false" example:"octocat"`
+}
+
+// Namespace is the ownership record of a namespace
+type Namespace struct {
+	Namespace          string         `json:"namespace" example:"io.github.octocat"`
+	Owner              NamespaceOwner `json:"owner"`
+	VerificationMethod string         `json:"verificationMethod" doc:"How ownership was proven: the auth method of the claiming publish, or 'admin' for transfers" example:"github-at"`
+	VerifiedAt         time.Time      `json:"verifiedAt" doc:"When ownership was proven"`
+	CreatedAt          time.Time      `json:"createdAt" doc:"When the namespace was first claimed"`
+	UpdatedAt          time.Time      `json:"updatedAt" doc:"When the owner record last changed"`
+}
+
+// NamespaceListResponse is a page of namespace ownership records
+type NamespaceListResponse struct {
+	Namespaces []Namespace    `json:"namespaces"`
+	Metadata   apiv0.Metadata `json:"metadata"`
+}
+
+// RegisterNamespaceEndpoints registers the namespace ownership endpoints with a custom path prefix
+func RegisterNamespaceEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	// List namespaces endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "list-namespaces" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/namespaces",
+		Summary:     "List namespace owners",
+		Description: "List the recorded owners of namespaces, ordered by namespace. A namespace is claimed by the first publish to it.",
+		Tags:        []string{"namespaces"},
+	}, func(ctx context.Context, input *ListNamespacesInput) (*Response[NamespaceListResponse], error) {
+		filter := &database.NamespaceFilter{}
+		if input.OwnerType != "" {
+			filter.OwnerType = &input.OwnerType
+		}
+		if input.OwnerID != "" {
+			filter.OwnerID = &input.OwnerID
+		}
+
+		namespaces, nextCursor, err := registry.ListNamespaces(ctx, filter, input.Cursor, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get namespaces", err)
+		}
+
+		body := NamespaceListResponse{
+			Namespaces: make([]Namespace, len(namespaces)),
+			Metadata: apiv0.Metadata{
+				NextCursor: nextCursor,
+				Count:      len(namespaces),
+			},
+		}
+		for i, namespace := range namespaces {
+			body.Namespaces[i] = toNamespace(namespace)
+		}
+
+		return &Response[NamespaceListResponse]{Body: body}, nil
+	})
+
+	// Get namespace endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "get-namespace" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/namespaces/{namespace}",
+		Summary:     "Get namespace owner",
+		Description: "Get the recorded owner of a namespace.",
+		Tags:        []string{"namespaces"},
+	}, func(ctx context.Context, input *NamespaceInput) (*Response[Namespace], error) {
+		namespace, err := decodeNamespacePath(input.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := registry.GetNamespace(ctx, namespace)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Namespace has no recorded owner")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get namespace", err)
+		}
+
+		return &Response[Namespace]{Body: toNamespace(record)}, nil
+	})
+
+	// Transfer namespace endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "transfer-namespace" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/admin/namespaces/{namespace}/transfer",
+		Summary:     "Transfer namespace ownership",
+		Description: "Record a new owner for a namespace, whether or not it had one (admin only). " +
+			"Publishers must still be granted publish permission for the namespace by their auth method.",
+		Tags: []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *TransferNamespaceInput) (*Response[Namespace], error) {
+		ctx, _, err := authenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		namespace, err := decodeNamespacePath(input.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := registry.TransferNamespace(ctx, namespace, service.NamespaceOwner{
+			Type: input.Body.Type,
+			ID:   input.Body.ID,
+			Name: input.Body.Name,
+		})
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Invalid namespace owner", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to transfer namespace", err)
+		}
+
+		return &Response[Namespace]{Body: toNamespace(record)}, nil
+	})
+}
+
+// decodeNamespacePath decodes a URL-encoded namespace path parameter
+func decodeNamespacePath(encoded string) (string, error) {
+	namespace, err := url.PathUnescape(encoded)
+	if err != nil {
+		return "", huma.Error400BadRequest("Invalid namespace encoding", err)
+	}
+	if namespace == "" || strings.Contains(namespace, "/") {
+		return "", huma.Error400BadRequest("Invalid namespace: expected the part of a server name before the '/'")
+	}
+	return namespace, nil
+}
+
+// toNamespace converts a stored namespace ownership record to its API representation
+func toNamespace(record *database.Namespace) Namespace {
+	return Namespace{
+		Namespace: record.Namespace,
+		Owner: NamespaceOwner{
+			Type: record.OwnerType,
+			ID:   record.OwnerID,
+			Name: record.OwnerName,
+		},
+		VerificationMethod: record.VerificationMethod,
+		VerifiedAt:         record.VerifiedAt,
+		CreatedAt:          record.CreatedAt,
+		UpdatedAt:          record.UpdatedAt,
+	}
+}
+
+// toNamespaceOwners converts the identities of a token's permissions to namespace owners
+func toNamespaceOwners(identities []auth.Identity) []service.NamespaceOwner {
+	owners := make([]service.NamespaceOwner, len(identities))
+	for i, identity := range identities {
+		owners[i] = service.NamespaceOwner{Type: identity.Type, ID: identity.ID, Name: identity.Name}
+	}
+	return owners
+}
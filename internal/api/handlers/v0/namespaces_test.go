@@ -0,0 +1,110 @@
+package v0_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestNamespaceEndpoints(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.EnableRegistryValidation = false
+	registryService := service.NewRegistryService(database.NewMemory(), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, cfg)
+	v0.RegisterNamespaceEndpoints(api, "/v0", registryService, cfg)
+
+	// gitHubToken mimics the token of a GitHub account, which gets its login's namespace
+	gitHubToken := func(login, id string) string {
+		return testAuthHeader(t, cfg, login, auth.Permission{
+			Action:          auth.PermissionActionPublish,
+			ResourcePattern: "io.github." + login + "/*",
+			Owner:           &auth.Identity{Type: auth.IdentityTypeGitHub, ID: id, Name: login},
+		})
+	}
+	adminToken := testAuthHeader(t, cfg, "admin", auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"})
+	serve := func(method, target, authHeader string, body any) *httptest.ResponseRecorder {
+		return serveTestRequest(t, mux, method, target, authHeader, body)
+	}
+	publish := func(authHeader, name, version string) *httptest.ResponseRecorder {
+		return serve(http.MethodPost, "/v0/publish", authHeader, apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Namespace test server",
+			Version:     version,
+		})
+	}
+
+	w := publish(gitHubToken("octocat", "583231"), "io.github.octocat/server", "1.0.0")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	t.Run("publish records the owner", func(t *testing.T) {
+		w := serve(http.MethodGet, "/v0/namespaces/io.github.octocat", "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var namespace v0.Namespace
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&namespace))
+		assert.Equal(t, v0.NamespaceOwner{Type: "github", ID: "583231", Name: "octocat"}, namespace.Owner)
+		assert.Equal(t, string(auth.MethodGitHubAT), namespace.VerificationMethod)
+
+		w = serve(http.MethodGet, "/v0/namespaces?owner_id=583231", "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list v0.NamespaceListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+		require.Len(t, list.Namespaces, 1)
+		assert.Equal(t, "io.github.octocat", list.Namespaces[0].Namespace)
+	})
+
+	t.Run("a renamed login's new holder cannot publish", func(t *testing.T) {
+		w := publish(gitHubToken("octocat", "999999"), "io.github.octocat/server", "1.0.1")
+		assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	})
+
+	t.Run("unknown namespace", func(t *testing.T) {
+		w := serve(http.MethodGet, "/v0/namespaces/io.github.nobody", "", nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("transfer requires admin", func(t *testing.T) {
+		w := serve(http.MethodPost, "/v0/admin/namespaces/io.github.octocat/transfer", gitHubToken("octocat", "583231"),
+			v0.NamespaceOwner{Type: "github", ID: "999999"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("transfer", func(t *testing.T) {
+		w := serve(http.MethodPost, "/v0/admin/namespaces/io.github.octocat/transfer", adminToken,
+			v0.NamespaceOwner{Type: "github", ID: "999999", Name: "octocat"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var namespace v0.Namespace
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&namespace))
+		assert.Equal(t, "999999", namespace.Owner.ID)
+		assert.Equal(t, service.VerificationMethodAdmin, namespace.VerificationMethod)
+
+		w = publish(gitHubToken("octocat", "999999"), "io.github.octocat/server", "1.0.1")
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		w = publish(gitHubToken("octocat", "583231"), "io.github.octocat/server", "1.0.2")
+		assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	})
+
+	t.Run("transfer rejects an unknown owner type", func(t *testing.T) {
+		w := serve(http.MethodPost, "/v0/admin/namespaces/io.github.octocat/transfer", adminToken,
+			map[string]string{"type": "gitlab", "id": "1"})
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+}
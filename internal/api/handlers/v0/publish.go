@@ -2,22 +2,33 @@ package v0
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
 
 // PublishServerInput represents the input for publishing a server
 type PublishServerInput struct {
 	Authorization string           `header:"Authorization" doc:"Registry JWT token (obtained from /v0/auth/token/github)" required:"true"`
+	Draft         bool             `query:"draft" doc:"Publish as a draft, only visible to tokens that can publish the server until promoted" required:"false"`
 	Body          apiv0.ServerJSON `body:""`
 }
 
+// PromoteServerInput represents the input for promoting a draft
+type PromoteServerInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permission for the server" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version       string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+}
+
 // RegisterPublishEndpoint registers the publish endpoint with a custom path prefix
 func RegisterPublishEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
 	// Create JWT manager for token validation
@@ -34,18 +45,10 @@ func RegisterPublishEndpoint(api huma.API, pathPrefix string, registry service.R
 			{"bearer": {}},
 		},
 	}, func(ctx context.Context, input *PublishServerInput) (*Response[apiv0.ServerResponse], error) {
-		// Extract bearer token
-		const bearerPrefix = "Bearer "
-		authHeader := input.Authorization
-		if len(authHeader) < len(bearerPrefix) || !strings.EqualFold(authHeader[:len(bearerPrefix)], bearerPrefix) {
-			return nil, huma.Error401Unauthorized("Invalid Authorization header format. Expected 'Bearer <token>'")
-		}
-		token := authHeader[len(bearerPrefix):]
-
-		// Validate Registry JWT token
-		claims, err := jwtManager.ValidateToken(ctx, token)
+		// Validate the Registry JWT token, recording its holder as the actor of this change
+		ctx, claims, err := authenticate(ctx, jwtManager, input.Authorization)
 		if err != nil {
-			return nil, huma.Error401Unauthorized("Invalid or expired Registry JWT token", err)
+			return nil, err
 		}
 
 		// Verify that the token has permission to publish the server
@@ -53,10 +56,26 @@ func RegisterPublishEndpoint(api huma.API, pathPrefix string, registry service.R
 			return nil, huma.Error403Forbidden(buildPermissionErrorMessage(input.Body.Name, claims.Permissions))
 		}
 
+		// Publishes on behalf of verified identities are also checked against the namespace's recorded owner
+		if owners := jwtManager.PermissionOwners(input.Body.Name, auth.PermissionActionPublish, claims.Permissions); len(owners) > 0 {
+			ctx = service.WithNamespaceOwners(ctx, string(claims.AuthMethod), toNamespaceOwners(owners))
+		}
+
 		// Publish the server with extensions
-		publishedServer, err := registry.CreateServer(ctx, &input.Body)
+		publish := registry.CreateServer
+		if input.Draft {
+			publish = registry.CreateDraftServer
+		}
+		publishedServer, err := publish(ctx, &input.Body)
 		if err != nil {
-			return nil, huma.Error400BadRequest("Failed to publish server", err)
+			if errors.Is(err, service.ErrNamespaceOwnedByOther) {
+				return nil, huma.Error403Forbidden("You do not own this server's namespace", err)
+			}
+			var rateLimitErr *service.RateLimitError
+			if errors.As(err, &rateLimitErr) {
+				return nil, rateLimited(rateLimitErr)
+			}
+			return nil, huma.Error400BadRequest("Failed to publish server", problemDetails(err)...)
 		}
 
 		// Return the published server response with metadata
@@ -64,6 +83,69 @@ func RegisterPublishEndpoint(api huma.API, pathPrefix string, registry service.R
 			Body: *publishedServer,
 		}, nil
 	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "promote-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/promote",
+		Summary:     "Promote draft MCP server version",
+		Description: "Make a draft version active and visible to everyone, as if it was published now. " +
+			"It becomes the latest version if it is newer than the current latest.",
+		Tags: []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *PromoteServerInput) (*Response[apiv0.ServerResponse], error) {
+		ctx, claims, err := authenticate(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		serverName, version, err := decodeServerVersionPath(input.ServerName, input.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		if !jwtManager.HasPermission(serverName, auth.PermissionActionPublish, claims.Permissions) {
+			return nil, huma.Error403Forbidden(buildPermissionErrorMessage(serverName, claims.Permissions))
+		}
+		if owners := jwtManager.PermissionOwners(serverName, auth.PermissionActionPublish, claims.Permissions); len(owners) > 0 {
+			ctx = service.WithNamespaceOwners(ctx, string(claims.AuthMethod), toNamespaceOwners(owners))
+		}
+
+		promotedServer, err := registry.PromoteServer(ctx, serverName, version)
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				return nil, huma.Error404NotFound("Server not found")
+			case errors.Is(err, service.ErrNotDraft):
+				return nil, huma.Error409Conflict("Server version is not a draft")
+			case errors.Is(err, service.ErrNamespaceOwnedByOther):
+				return nil, huma.Error403Forbidden("You do not own this server's namespace", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to promote server", err)
+		}
+
+		return &Response[apiv0.ServerResponse]{
+			Body: *promotedServer,
+		}, nil
+	})
+}
+
+// problemDetails converts the problems of a validation error to error details, each with the JSON
+// pointer of the offending value in the server.json as its location and the problem code as its
+// value, or returns err itself if it is not a validation error
+func problemDetails(err error) []error {
+	problems := validators.ProblemsOf(err)
+	if problems == nil {
+		return []error{err}
+	}
+
+	details := make([]error, len(problems))
+	for i, problem := range problems {
+		details[i] = &huma.ErrorDetail{Message: problem.Error(), Location: problem.Pointer, Value: problem.Code}
+	}
+	return details
 }
 
 // buildPermissionErrorMessage creates a detailed error message showing what permissions
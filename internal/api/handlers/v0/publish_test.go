@@ -524,3 +524,160 @@ func TestPublishEndpoint_MultipleSlashesEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestPublishDraftAndPromote(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.EnableRegistryValidation = false
+	registryService := service.NewRegistryService(database.NewMemory(), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, cfg)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, cfg)
+
+	token := func(pattern string) string {
+		token, err := generateTestJWTToken(cfg, auth.JWTClaims{
+			AuthMethod:  auth.MethodNone,
+			Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: pattern}},
+		})
+		require.NoError(t, err)
+		return "Bearer " + token
+	}
+	publisher := token("com.example/*")
+	stranger := token("org.example/*")
+
+	serve := func(method, target, authHeader string, body any) *httptest.ResponseRecorder {
+		reader := bytes.NewReader(nil)
+		if body != nil {
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+			reader = bytes.NewReader(data)
+		}
+		req := httptest.NewRequest(method, target, reader)
+		req.Header.Set("Content-Type", "application/json")
+		if authHeader != "" {
+			req.Header.Set("Authorization", authHeader)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+	publish := func(version, query string) *httptest.ResponseRecorder {
+		return serve(http.MethodPost, "/v0/publish"+query, publisher, apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/draft-server",
+			Description: "Draft server",
+			Version:     version,
+		})
+	}
+	versionPath := "/v0/servers/com.example%2Fdraft-server/versions/"
+
+	require.Equal(t, http.StatusOK, publish("1.0.0", "").Code)
+	w := publish("2.0.0", "?draft=true")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var draft apiv0.ServerResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &draft))
+	assert.Equal(t, model.StatusDraft, draft.Meta.Official.Status)
+	assert.False(t, draft.Meta.Official.IsLatest)
+
+	t.Run("draft is only visible to its publishers", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, versionPath+"2.0.0", "", nil).Code)
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, versionPath+"2.0.0", stranger, nil).Code)
+		assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, versionPath+"2.0.0", "Bearer invalid", nil).Code)
+		w := serve(http.MethodGet, versionPath+"2.0.0", publisher, nil)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var list apiv0.ServerListResponse
+		w = serve(http.MethodGet, "/v0/servers/com.example%2Fdraft-server/versions", publisher, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		assert.Len(t, list.Servers, 2)
+
+		w = serve(http.MethodGet, "/v0/servers?search=draft-server", publisher, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		assert.Len(t, list.Servers, 1, "drafts are not listed")
+	})
+
+	t.Run("promotion requires publish permission", func(t *testing.T) {
+		w := serve(http.MethodPost, versionPath+"2.0.0/promote", stranger, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	})
+
+	t.Run("promotion publishes the draft", func(t *testing.T) {
+		w := serve(http.MethodPost, versionPath+"2.0.0/promote", publisher, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var promoted apiv0.ServerResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &promoted))
+		assert.Equal(t, model.StatusActive, promoted.Meta.Official.Status)
+		assert.True(t, promoted.Meta.Official.IsLatest)
+
+		w = serve(http.MethodGet, versionPath+"latest", "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var latest apiv0.ServerResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &latest))
+		assert.Equal(t, "2.0.0", latest.Server.Version)
+
+		assert.Equal(t, http.StatusConflict, serve(http.MethodPost, versionPath+"2.0.0/promote", publisher, nil).Code)
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodPost, versionPath+"3.0.0/promote", publisher, nil).Code)
+	})
+}
+
+func TestPublishEndpoint_ValidationProblems(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.EnableRegistryValidation = false
+	registryService := service.NewRegistryService(database.NewMemory(), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0", registryService, cfg)
+
+	token, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod:  auth.MethodNone,
+		Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"}},
+	})
+	require.NoError(t, err)
+
+	body, err := json.Marshal(apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/invalid-server",
+		Description: "Server with several problems",
+		Version:     "^1.0.0",
+		WebsiteURL:  "http://example.com",
+		Packages: []model.Package{{
+			RegistryType: model.RegistryTypeNPM,
+			Identifier:   "@example/invalid-server",
+			Version:      "1.0.0",
+			Transport:    model.Transport{Type: model.TransportTypeStdio},
+			PackageArguments: []model.Argument{
+				{Type: model.ArgumentTypePositional, ValueHint: "file"},
+				{Type: model.ArgumentTypeNamed, Name: "--port"},
+				{Type: model.ArgumentTypeNamed, Name: "--port <port>"},
+			},
+		}},
+		Remotes: []model.Transport{{Type: model.TransportTypeStdio}},
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/v0/publish", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+	var resp huma.ErrorModel
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "Failed to publish server", resp.Detail)
+
+	problems := make(map[string]any)
+	for _, detail := range resp.Errors {
+		assert.NotEmpty(t, detail.Message)
+		problems[detail.Location] = detail.Value
+	}
+	assert.Equal(t, map[string]any{
+		"/version":                            "version_range",
+		"/websiteUrl":                         "invalid_website_url",
+		"/packages/0/packageArguments/2/name": "invalid_named_argument",
+		"/remotes/0/type":                     "unsupported_transport_type",
+	}, problems)
+}
@@ -21,3 +21,15 @@ type Response[T any] struct {
 //           Body: HealthBody{...},
 //       }, nil
 //   }
+
+// CachedResponse is a Response of a cacheable read, with its validators and caching headers.
+// Status is http.StatusNotModified, and the body is not sent, when the client's copy is current.
+type CachedResponse[T any] struct {
+	Status       int
+	ETag         string `header:"ETag"`
+	LastModified string `header:"Last-Modified"` // only for reads of an exact version, see versionLastModified
+	CacheControl string `header:"Cache-Control"`
+	SurrogateKey string `header:"Surrogate-Key"`
+	Vary         string `header:"Vary"`
+	Body         T
+}
@@ -0,0 +1,192 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListServerRevisionsInput represents the input for listing the revisions of a server version
+type ListServerRevisionsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version       string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+}
+
+// ServerRevisionInput represents the input for getting or restoring a single revision
+type ServerRevisionInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version       string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+	Revision      int    `path:"revision" doc:"Revision number, starting at 1 for the server.json as published" minimum:"1" example:"1"`
+}
+
+// RestoreServerRevisionInput represents the input for restoring a revision
+type RestoreServerRevisionInput struct {
+	ServerRevisionInput
+	Reason string `query:"reason" doc:"Why the revision is being restored, recorded in the audit log" required:"false" maxLength:"1000"`
+}
+
+// ServerRevision is a stored revision of a server version's server.json
+type ServerRevision struct {
+	Revision  int              `json:"revision" doc:"Revision number, starting at 1 for the server.json as published" example:"1"`
+	CreatedAt time.Time        `json:"createdAt" doc:"When this revision was stored"`
+	Server    apiv0.ServerJSON `json:"server"`
+}
+
+// ServerRevisionListResponse lists the revisions of a server version
+type ServerRevisionListResponse struct {
+	Revisions []ServerRevision `json:"revisions"`
+	Metadata  apiv0.Metadata   `json:"metadata"`
+}
+
+// RegisterRevisionEndpoints registers the admin endpoints for server version revisions with a custom path prefix.
+// Revisions are admin-only because they may hold content that an admin has since scrubbed.
+func RegisterRevisionEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	// List revisions endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "list-server-revisions" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/revisions",
+		Summary:     "List server version revisions",
+		Description: "List every stored revision of a server version's server.json, oldest first (admin only).",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListServerRevisionsInput) (*Response[ServerRevisionListResponse], error) {
+		ctx, _, err := authenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		serverName, version, err := decodeServerVersionPath(input.ServerName, input.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		revisions, err := registry.ListServerRevisions(ctx, serverName, version)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server revisions", err)
+		}
+
+		body := ServerRevisionListResponse{
+			Revisions: make([]ServerRevision, len(revisions)),
+			Metadata:  apiv0.Metadata{Count: len(revisions)},
+		}
+		for i, revision := range revisions {
+			body.Revisions[i] = toServerRevision(revision)
+		}
+
+		return &Response[ServerRevisionListResponse]{Body: body}, nil
+	})
+
+	// Get revision endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "get-server-revision" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/revisions/{revision}",
+		Summary:     "Get server version revision",
+		Description: "Get a single stored revision of a server version's server.json (admin only).",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ServerRevisionInput) (*Response[ServerRevision], error) {
+		ctx, _, err := authenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		serverName, version, err := decodeServerVersionPath(input.ServerName, input.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		revision, err := registry.GetServerRevision(ctx, serverName, version, input.Revision)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Revision not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get server revision", err)
+		}
+
+		return &Response[ServerRevision]{Body: toServerRevision(revision)}, nil
+	})
+
+	// Restore revision endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "restore-server-revision" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/revisions/{revision}/restore",
+		Summary:     "Restore server version revision",
+		Description: "Make an earlier revision the current server.json of a server version. The restored content is stored as a new revision (admin only).",
+		Tags:        []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *RestoreServerRevisionInput) (*Response[apiv0.ServerResponse], error) {
+		ctx, _, err := authenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		serverName, version, err := decodeServerVersionPath(input.ServerName, input.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		if input.Reason != "" {
+			ctx = service.WithAuditReason(ctx, input.Reason)
+		}
+
+		restoredServer, err := registry.RestoreServerRevision(ctx, serverName, version, input.Revision)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Revision not found")
+			}
+			return nil, huma.Error400BadRequest("Failed to restore revision", err)
+		}
+
+		return &Response[apiv0.ServerResponse]{Body: *restoredServer}, nil
+	})
+}
+
+// decodeServerVersionPath URL-decodes the server name and version path parameters
+func decodeServerVersionPath(encodedServerName, encodedVersion string) (string, string, error) {
+	serverName, err := url.PathUnescape(encodedServerName)
+	if err != nil {
+		return "", "", huma.Error400BadRequest("Invalid server name encoding", err)
+	}
+
+	version, err := url.PathUnescape(encodedVersion)
+	if err != nil {
+		return "", "", huma.Error400BadRequest("Invalid version encoding", err)
+	}
+
+	return serverName, version, nil
+}
+
+// toServerRevision converts a stored revision to its API representation
+func toServerRevision(revision *database.ServerRevision) ServerRevision {
+	return ServerRevision{
+		Revision:  revision.Revision,
+		CreatedAt: revision.CreatedAt,
+		Server:    revision.Server,
+	}
+}
@@ -0,0 +1,119 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestServerRevisionEndpoints(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.EnableRegistryValidation = false
+	registryService := service.NewRegistryService(database.NewMemory(), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterEditEndpoints(api, "/v0", registryService, cfg)
+	v0.RegisterRevisionEndpoints(api, "/v0", registryService, cfg)
+
+	adminToken := testAuthHeader(t, cfg, "admin", auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"})
+	editorToken := testAuthHeader(t, cfg, "editor", auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "com.example/*"})
+	serve := func(method, target, authHeader string, body any) *httptest.ResponseRecorder {
+		return serveTestRequest(t, mux, method, target, authHeader, body)
+	}
+
+	server := apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/revised",
+		Description: "Original description",
+		Version:     "1.0.0",
+	}
+	_, err := registryService.CreateServer(context.Background(), &server)
+	require.NoError(t, err)
+
+	versionPath := "/v0/servers/" + url.PathEscape(server.Name) + "/versions/1.0.0"
+	edited := server
+	edited.Description = "Scrubbed description"
+	w := serve(http.MethodPut, versionPath, adminToken, edited)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	t.Run("list revisions", func(t *testing.T) {
+		w := serve(http.MethodGet, versionPath+"/revisions", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp v0.ServerRevisionListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(t, resp.Revisions, 2)
+		assert.Equal(t, 2, resp.Metadata.Count)
+		assert.Equal(t, 1, resp.Revisions[0].Revision)
+		assert.Equal(t, "Original description", resp.Revisions[0].Server.Description)
+		assert.Equal(t, "Scrubbed description", resp.Revisions[1].Server.Description)
+	})
+
+	t.Run("get revision", func(t *testing.T) {
+		w := serve(http.MethodGet, versionPath+"/revisions/1", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp v0.ServerRevision
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, "Original description", resp.Server.Description)
+
+		w = serve(http.MethodGet, versionPath+"/revisions/9", adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("restore revision", func(t *testing.T) {
+		w := serve(http.MethodPost, versionPath+"/revisions/1/restore?reason=Undo+edit", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp apiv0.ServerResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, "Original description", resp.Server.Description)
+
+		current, err := registryService.GetServerByNameAndVersion(context.Background(), server.Name, "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, "Original description", current.Server.Description)
+
+		// The restore is itself a new revision, and is audited
+		revisions, err := registryService.ListServerRevisions(context.Background(), server.Name, "1.0.0")
+		require.NoError(t, err)
+		assert.Len(t, revisions, 3)
+
+		events, _, err := registryService.ListAuditEvents(context.Background(), nil, "", 1)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, service.AuditActionRestore, events[0].Action)
+		assert.Equal(t, "Undo edit", events[0].Reason)
+	})
+
+	t.Run("requires admin", func(t *testing.T) {
+		w := serve(http.MethodGet, versionPath+"/revisions", editorToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = serve(http.MethodPost, versionPath+"/revisions/1/restore", editorToken, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("unknown server version", func(t *testing.T) {
+		w := serve(http.MethodGet, "/v0/servers/"+url.PathEscape(server.Name)+"/versions/9.9.9/revisions", adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = serve(http.MethodPost, "/v0/servers/"+url.PathEscape(server.Name)+"/versions/9.9.9/revisions/1/restore", adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
@@ -18,11 +20,22 @@ const errRecordNotFound = "record not found"
 
 // ListServersInput represents the input for listing servers
 type ListServersInput struct {
-	Cursor       string `query:"cursor" doc:"Pagination cursor" required:"false" example:"server-cursor-123"`
+	Cursor       string `query:"cursor" doc:"Pagination cursor: the opaque metadata.nextCursor of the previous page, only valid with the same filters and sort" required:"false" example:"eyJwIjoiY29tLmV4YW1wbGUvbXktc2VydmVyOjEuMC4wIn0.c2lnbmF0dXJl"`
 	Limit        int    `query:"limit" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100" example:"50"`
 	UpdatedSince string `query:"updated_since" doc:"Filter servers updated since timestamp (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
-	Search       string `query:"search" doc:"Search servers by name (substring match)" required:"false" example:"filesystem"`
-	Version      string `query:"version" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
+	Search       string `query:"search" doc:"Search servers by name, title, description and package identifiers. Results are ordered by relevance, and names within a small typo of the search term also match." required:"false" example:"filesystem"`
+	Version      string `query:"version" doc:"Filter by version ('latest' for latest version, 'latest-stable' for the latest version that is not a prerelease, or an exact version like '1.2.3')" required:"false" example:"latest"`
+
+	RegistryType   string `query:"registry_type" doc:"Filter servers with a package from this registry type" required:"false" example:"oci"`
+	Package        string `query:"package" doc:"Filter servers with a package with this exact identifier" required:"false" example:"@modelcontextprotocol/server-filesystem"`
+	Transport      string `query:"transport" doc:"Filter servers with a remote or package using this transport type" required:"false" example:"streamable-http"`
+	Status         string `query:"status" doc:"Filter by version status" required:"false" enum:"active,deprecated,deleted" example:"deprecated"`
+	Namespace      string `query:"namespace" doc:"Filter servers in this namespace, the part of the name before the slash" required:"false" example:"io.github.modelcontextprotocol"`
+	PublishedSince string `query:"published_since" doc:"Filter versions published since timestamp (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
+
+	Sort string `query:"sort" doc:"Order of the results as field:direction. Defaults to name:asc (name, then release order), or to relevance for searches." required:"false" enum:"name:asc,name:desc,updated_at:asc,updated_at:desc,published_at:asc,published_at:desc" example:"updated_at:desc"`
+
+	ConditionalReadInput
 }
 
 // ServerDetailInput represents the input for getting server details
@@ -32,17 +45,25 @@ type ServerDetailInput struct {
 
 // ServerVersionDetailInput represents the input for getting a specific version
 type ServerVersionDetailInput struct {
-	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
-	Version    string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+	Authorization string `header:"Authorization" doc:"Optional Registry JWT token; drafts are only visible to tokens that can publish or edit the server" required:"false"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version       string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+
+	ConditionalReadInput
 }
 
 // ServerVersionsInput represents the input for listing all versions of a server
 type ServerVersionsInput struct {
-	ServerName string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Authorization string `header:"Authorization" doc:"Optional Registry JWT token; drafts are only visible to tokens that can publish or edit the server" required:"false"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+
+	ConditionalReadInput
 }
 
 // RegisterServersEndpoints registers all server-related endpoints with a custom path prefix
-func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
 	// List servers endpoint
 	huma.Register(api, huma.Operation{
 		OperationID: "list-servers" + strings.ReplaceAll(pathPrefix, "/", "-"),
@@ -51,7 +72,8 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		Summary:     "List MCP servers",
 		Description: "Get a paginated list of MCP servers from the registry",
 		Tags:        []string{"servers"},
-	}, func(ctx context.Context, input *ListServersInput) (*Response[apiv0.ServerListResponse], error) {
+		Responses:   cachedReadResponses(),
+	}, func(ctx context.Context, input *ListServersInput) (*CachedResponse[apiv0.ServerListResponse], error) {
 		// Build filter from input parameters
 		filter := &database.ServerFilter{}
 
@@ -65,18 +87,57 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 			}
 		}
 
-		// Handle search parameter
+		// Parse published_since parameter
+		if input.PublishedSince != "" {
+			publishedTime, err := time.Parse(time.RFC3339, input.PublishedSince)
+			if err != nil {
+				return nil, huma.Error400BadRequest("Invalid published_since format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04.280Z)")
+			}
+			filter.PublishedSince = &publishedTime
+		}
+
+		// Handle package, transport and namespace parameters
+		if input.RegistryType != "" {
+			filter.RegistryType = &input.RegistryType
+		}
+		if input.Package != "" {
+			filter.PackageIdentifier = &input.Package
+		}
+		if input.Transport != "" {
+			filter.TransportType = &input.Transport
+		}
+		if input.Status != "" {
+			filter.Status = &input.Status
+		}
+		if input.Namespace != "" {
+			filter.Namespace = &input.Namespace
+		}
+
+		// Handle search parameter (relevance-ranked)
 		if input.Search != "" {
-			filter.SubstringName = &input.Search
+			filter.Search = &input.Search
+		}
+
+		// Handle sort parameter
+		if input.Sort != "" {
+			sort, err := database.ParseServerSort(input.Sort)
+			if err != nil {
+				return nil, huma.Error400BadRequest("Invalid sort", err)
+			}
+			filter.Sort = &sort
 		}
 
 		// Handle version parameter
 		if input.Version != "" {
-			if input.Version == "latest" {
+			switch input.Version {
+			case service.VersionLatest:
 				// Special case: filter for latest versions
 				isLatest := true
 				filter.IsLatest = &isLatest
-			} else {
+			case service.VersionLatestStable:
+				isLatestStable := true
+				filter.IsLatestStable = &isLatestStable
+			default:
 				// Future: exact version matching
 				filter.Version = &input.Version
 			}
@@ -85,6 +146,9 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		// Get paginated results with filtering
 		servers, nextCursor, err := registry.ListServers(ctx, filter, input.Cursor, input.Limit)
 		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Invalid cursor", err)
+			}
 			return nil, huma.Error500InternalServerError("Failed to get registry list", err)
 		}
 
@@ -94,26 +158,32 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 			serverValues[i] = *server
 		}
 
-		return &Response[apiv0.ServerListResponse]{
-			Body: apiv0.ServerListResponse{
-				Servers: serverValues,
-				Metadata: apiv0.Metadata{
-					NextCursor: nextCursor,
-					Count:      len(servers),
-				},
+		return newCachedResponse(input.ConditionalReadInput, cacheControl(cfg, ""), apiv0.ServerListResponse{
+			Servers: serverValues,
+			Metadata: apiv0.Metadata{
+				NextCursor: nextCursor,
+				Count:      len(servers),
 			},
-		}, nil
+		}, time.Time{}, servers, ServersSurrogateKey)
 	})
 
-	// Get specific server version endpoint (supports "latest" as special version)
+	// Get specific server version endpoint (supports "latest", "latest-stable" and channels as special versions)
 	huma.Register(api, huma.Operation{
 		OperationID: "get-server-version" + strings.ReplaceAll(pathPrefix, "/", "-"),
 		Method:      http.MethodGet,
 		Path:        pathPrefix + "/servers/{serverName}/versions/{version}",
 		Summary:     "Get specific MCP server version",
-		Description: "Get detailed information about a specific version of an MCP server. Use the special version 'latest' to get the latest version.",
-		Tags:        []string{"servers"},
-	}, func(ctx context.Context, input *ServerVersionDetailInput) (*Response[apiv0.ServerResponse], error) {
+		Description: "Get detailed information about a specific version of an MCP server. Use the special version 'latest' to get the latest version, " +
+			"'latest-stable' to get the latest version that is not a prerelease, or the name of a channel of the server to get the version it points at. " +
+			"Drafts are only returned with a token that can publish or edit the server.",
+		Tags:      []string{"servers"},
+		Responses: cachedReadResponses(),
+	}, func(ctx context.Context, input *ServerVersionDetailInput) (*CachedResponse[apiv0.ServerResponse], error) {
+		ctx, err := authenticateOptional(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
 		// URL-decode the server name
 		serverName, err := url.PathUnescape(input.ServerName)
 		if err != nil {
@@ -126,14 +196,8 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 			return nil, huma.Error400BadRequest("Invalid version encoding", err)
 		}
 
-		var serverResponse *apiv0.ServerResponse
-		// Handle "latest" as a special version
-		if version == "latest" {
-			serverResponse, err = registry.GetServerByName(ctx, serverName)
-		} else {
-			serverResponse, err = registry.GetServerByNameAndVersion(ctx, serverName, version)
-		}
-
+		// Handle "latest", "latest-stable" and channel names as well as versions
+		serverResponse, err := registry.ResolveServerVersion(ctx, serverName, version)
 		if err != nil {
 			if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) {
 				return nil, huma.Error404NotFound("Server not found")
@@ -141,9 +205,12 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 			return nil, huma.Error500InternalServerError("Failed to get server details", err)
 		}
 
-		return &Response[apiv0.ServerResponse]{
-			Body: *serverResponse,
-		}, nil
+		response, err := newCachedResponse(input.ConditionalReadInput, cacheControl(cfg, input.Authorization), *serverResponse, versionLastModified(serverResponse, version), []*apiv0.ServerResponse{serverResponse})
+		if err != nil {
+			return nil, err
+		}
+		response.Vary = "Authorization"
+		return response, nil
 	})
 
 	// Get server versions endpoint
@@ -152,9 +219,15 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 		Method:      http.MethodGet,
 		Path:        pathPrefix + "/servers/{serverName}/versions",
 		Summary:     "Get all versions of an MCP server",
-		Description: "Get all available versions for a specific MCP server",
+		Description: "Get all available versions for a specific MCP server, including drafts when called with a token that can publish or edit the server",
 		Tags:        []string{"servers"},
-	}, func(ctx context.Context, input *ServerVersionsInput) (*Response[apiv0.ServerListResponse], error) {
+		Responses:   cachedReadResponses(),
+	}, func(ctx context.Context, input *ServerVersionsInput) (*CachedResponse[apiv0.ServerListResponse], error) {
+		ctx, err := authenticateOptional(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
 		// URL-decode the server name
 		serverName, err := url.PathUnescape(input.ServerName)
 		if err != nil {
@@ -176,13 +249,16 @@ func RegisterServersEndpoints(api huma.API, pathPrefix string, registry service.
 			serverValues[i] = *server
 		}
 
-		return &Response[apiv0.ServerListResponse]{
-			Body: apiv0.ServerListResponse{
-				Servers: serverValues,
-				Metadata: apiv0.Metadata{
-					Count: len(servers),
-				},
+		response, err := newCachedResponse(input.ConditionalReadInput, cacheControl(cfg, input.Authorization), apiv0.ServerListResponse{
+			Servers: serverValues,
+			Metadata: apiv0.Metadata{
+				Count: len(servers),
 			},
-		}, nil
+		}, time.Time{}, servers)
+		if err != nil {
+			return nil, err
+		}
+		response.Vary = "Authorization"
+		return response, nil
 	})
 }
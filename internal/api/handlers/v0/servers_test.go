@@ -1,7 +1,11 @@
 package v0_test
 
 import (
+	"bytes"
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -11,6 +15,7 @@ import (
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
 	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
@@ -20,9 +25,54 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// newTestConfig returns the default configuration with a random JWT signing key
+func newTestConfig(t *testing.T) *config.Config {
+	t.Helper()
+	seed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(seed)
+	require.NoError(t, err)
+	cfg := config.NewConfig()
+	cfg.JWTPrivateKey = hex.EncodeToString(seed)
+	return cfg
+}
+
+// testAuthHeader returns an Authorization header carrying a registry token signed with the key
+// of cfg, for a GitHub user with the given permissions
+func testAuthHeader(t *testing.T, cfg *config.Config, subject string, permissions ...auth.Permission) string {
+	t.Helper()
+	token, err := generateTestJWTToken(cfg, auth.JWTClaims{
+		AuthMethod:        auth.MethodGitHubAT,
+		AuthMethodSubject: subject,
+		Permissions:       permissions,
+	})
+	require.NoError(t, err)
+	return "Bearer " + token
+}
+
+// serveTestRequest serves a request to handler and records the response. A non-nil body is sent
+// as JSON, and the Authorization header is only set when authHeader is not empty.
+func serveTestRequest(t *testing.T, handler http.Handler, method, target, authHeader string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+	req := httptest.NewRequest(method, target, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	if authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
 func TestListServersEndpoint(t *testing.T) {
 	ctx := context.Background()
-	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+	cfg := newTestConfig(t)
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
 
 	// Setup test data
 	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
@@ -44,7 +94,7 @@ func TestListServersEndpoint(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, cfg)
 
 	tests := []struct {
 		name           string
@@ -71,18 +121,78 @@ func TestListServersEndpoint(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedCount:  1,
 		},
+		{
+			name:           "search matches description",
+			queryParams:    "?search=test",
+			expectedStatus: http.StatusOK,
+			expectedCount:  2,
+		},
 		{
 			name:           "filter latest only",
 			queryParams:    "?version=latest",
 			expectedStatus: http.StatusOK,
 			expectedCount:  2,
 		},
+		{
+			name:           "filter by namespace",
+			queryParams:    "?namespace=com.example",
+			expectedStatus: http.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name:           "filter by status",
+			queryParams:    "?status=deprecated",
+			expectedStatus: http.StatusOK,
+			expectedCount:  0,
+		},
+		{
+			name:           "filter by registry type",
+			queryParams:    "?registry_type=oci",
+			expectedStatus: http.StatusOK,
+			expectedCount:  0,
+		},
+		{
+			name:           "filter by published since",
+			queryParams:    "?published_since=2020-01-01T00:00:00Z",
+			expectedStatus: http.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name:           "invalid published since",
+			queryParams:    "?published_since=yesterday",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid published_since format",
+		},
+		{
+			name:           "invalid status",
+			queryParams:    "?status=archived",
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "validation failed",
+		},
 		{
 			name:           "invalid limit",
 			queryParams:    "?limit=abc",
 			expectedStatus: http.StatusUnprocessableEntity,
 			expectedError:  "validation failed",
 		},
+		{
+			name:           "sort by update time",
+			queryParams:    "?sort=updated_at:desc",
+			expectedStatus: http.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name:           "invalid sort",
+			queryParams:    "?sort=downloads:desc",
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "validation failed",
+		},
+		{
+			name:           "unsigned cursor",
+			queryParams:    "?cursor=com.example/server-alpha:1.0.0",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid cursor",
+		},
 	}
 
 	for _, tt := range tests {
@@ -114,9 +224,66 @@ func TestListServersEndpoint(t *testing.T) {
 	}
 }
 
+func TestListServersEndpointPagination(t *testing.T) {
+	ctx := context.Background()
+	cfg := newTestConfig(t)
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
+
+	for _, name := range []string{"com.example/page-alpha", "com.example/page-beta", "com.example/page-gamma"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Pagination test server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterServersEndpoints(api, "/v0", registryService, cfg)
+
+	list := func(query string) (*httptest.ResponseRecorder, apiv0.ServerListResponse) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/v0/servers"+query, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		var resp apiv0.ServerListResponse
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		}
+		return w, resp
+	}
+
+	// Newest first, a server at a time
+	var names []string
+	cursor := ""
+	for range 5 {
+		w, resp := list("?sort=published_at:desc&limit=1&cursor=" + url.QueryEscape(cursor))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		for _, server := range resp.Servers {
+			names = append(names, server.Server.Name)
+		}
+		if resp.Metadata.NextCursor == "" {
+			break
+		}
+		cursor = resp.Metadata.NextCursor
+	}
+	assert.Equal(t, []string{"com.example/page-gamma", "com.example/page-beta", "com.example/page-alpha"}, names)
+
+	// A cursor is only valid for the listing it came from
+	_, resp := list("?sort=published_at:desc&limit=1")
+	w, _ := list("?sort=name:asc&limit=1&cursor=" + url.QueryEscape(resp.Metadata.NextCursor))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w, _ = list("?sort=published_at:desc&limit=1&search=page&cursor=" + url.QueryEscape(resp.Metadata.NextCursor))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestGetLatestServerVersionEndpoint(t *testing.T) {
 	ctx := context.Background()
-	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+	cfg := newTestConfig(t)
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
 
 	// Setup test data
 	_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
@@ -130,7 +297,7 @@ func TestGetLatestServerVersionEndpoint(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, cfg)
 
 	tests := []struct {
 		name           string
@@ -177,7 +344,8 @@ func TestGetLatestServerVersionEndpoint(t *testing.T) {
 
 func TestGetServerVersionEndpoint(t *testing.T) {
 	ctx := context.Background()
-	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+	cfg := newTestConfig(t)
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
 
 	serverName := "com.example/version-server"
 
@@ -210,7 +378,7 @@ func TestGetServerVersionEndpoint(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, cfg)
 
 	tests := []struct {
 		name           string
@@ -302,7 +470,8 @@ func TestGetServerVersionEndpoint(t *testing.T) {
 
 func TestGetAllVersionsEndpoint(t *testing.T) {
 	ctx := context.Background()
-	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+	cfg := newTestConfig(t)
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
 
 	serverName := "com.example/multi-version-server"
 
@@ -321,7 +490,7 @@ func TestGetAllVersionsEndpoint(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, cfg)
 
 	tests := []struct {
 		name           string
@@ -394,7 +563,8 @@ func TestGetAllVersionsEndpoint(t *testing.T) {
 
 func TestServersEndpointEdgeCases(t *testing.T) {
 	ctx := context.Background()
-	registryService := service.NewRegistryService(database.NewTestDB(t), config.NewConfig())
+	cfg := newTestConfig(t)
+	registryService := service.NewRegistryService(database.NewTestDB(t), cfg)
 
 	// Setup test data with edge case names that comply with constraints
 	specialServers := []struct {
@@ -420,7 +590,7 @@ func TestServersEndpointEdgeCases(t *testing.T) {
 	// Create API
 	mux := http.NewServeMux()
 	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
-	v0.RegisterServersEndpoints(api, "/v0", registryService)
+	v0.RegisterServersEndpoints(api, "/v0", registryService, cfg)
 
 	t.Run("URL encoding edge cases", func(t *testing.T) {
 		tests := []struct {
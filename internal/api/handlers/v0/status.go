@@ -0,0 +1,84 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// SetServerStatusInput represents the input for changing the status of a server version
+type SetServerStatusInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with publish permission for the server" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version       string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+	Body          struct {
+		Status     string            `json:"status" doc:"New status of the version" enum:"active,deprecated" example:"deprecated"`
+		Message    string            `json:"message,omitempty" doc:"Why the status is changing, e.g. what to use instead. Shown to consumers as the deprecation message of deprecated versions." required:"false" maxLength:"1000" example:"Superseded by 2.0.0"`
+		ReplacedBy *apiv0.ReplacedBy `json:"replacedBy,omitempty" doc:"Server that consumers of a deprecated version should move to. It must exist and not be deleted." required:"false"`
+	}
+}
+
+// RegisterStatusEndpoint registers the self-service status endpoint with a custom path prefix
+func RegisterStatusEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-server-status" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/status",
+		Summary:     "Deprecate or undeprecate MCP server version",
+		Description: "Move a published server version between active and deprecated, with the same permission as publishing it. " +
+			"Deleting versions is reserved to admins, through the edit endpoint.",
+		Tags: []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *SetServerStatusInput) (*Response[apiv0.ServerResponse], error) {
+		ctx, claims, err := authenticate(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		serverName, version, err := decodeServerVersionPath(input.ServerName, input.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		if !jwtManager.HasPermission(serverName, auth.PermissionActionPublish, claims.Permissions) &&
+			!jwtManager.HasPermission(serverName, auth.PermissionActionEdit, claims.Permissions) {
+			return nil, huma.Error403Forbidden(buildPermissionErrorMessage(serverName, claims.Permissions))
+		}
+		if owners := jwtManager.PermissionOwners(serverName, auth.PermissionActionPublish, claims.Permissions); len(owners) > 0 {
+			ctx = service.WithNamespaceOwners(ctx, string(claims.AuthMethod), toNamespaceOwners(owners))
+		}
+
+		updatedServer, err := registry.SetServerStatus(ctx, serverName, version, model.Status(input.Body.Status), database.StatusDetails{
+			Message:    input.Body.Message,
+			ReplacedBy: input.Body.ReplacedBy,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				return nil, huma.Error404NotFound("Server not found")
+			case errors.Is(err, database.ErrInvalidInput):
+				return nil, huma.Error400BadRequest("Invalid status change", err)
+			case errors.Is(err, service.ErrNamespaceOwnedByOther):
+				return nil, huma.Error403Forbidden("You do not own this server's namespace", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to change server status", err)
+		}
+
+		return &Response[apiv0.ServerResponse]{
+			Body: *updatedServer,
+		}, nil
+	})
+}
@@ -0,0 +1,123 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestSetServerStatusEndpoint(t *testing.T) {
+	ctx := context.Background()
+	cfg := newTestConfig(t)
+	cfg.EnableRegistryValidation = false
+	registryService := service.NewRegistryService(database.NewMemory(), cfg)
+
+	for _, version := range []string{"1.0.0", "2.0.0"} {
+		_, err := registryService.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/status-server",
+			Description: "Status test server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+	deleted := string(model.StatusDeleted)
+	_, err := registryService.UpdateServer(ctx, "com.example/status-server", "2.0.0", &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/status-server",
+		Description: "Status test server",
+		Version:     "2.0.0",
+	}, &deleted, nil)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterStatusEndpoint(api, "/v0", registryService, cfg)
+
+	publisher := testAuthHeader(t, cfg, "publisher", auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"})
+	setStatus := func(authHeader, version string, body map[string]any) *httptest.ResponseRecorder {
+		return serveTestRequest(t, mux, http.MethodPut, "/v0/servers/com.example%2Fstatus-server/versions/"+version+"/status", authHeader, body)
+	}
+	status := func(w *httptest.ResponseRecorder) model.Status {
+		t.Helper()
+		var server apiv0.ServerResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &server))
+		return server.Meta.Official.Status
+	}
+
+	t.Run("publisher can deprecate with a message", func(t *testing.T) {
+		w := setStatus(publisher, "1.0.0", map[string]any{"status": "deprecated", "message": "Use 2.x"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, model.StatusDeprecated, status(w))
+
+		serverName := "com.example/status-server"
+		events, _, err := registryService.ListAuditEvents(ctx, &database.AuditEventFilter{ServerName: &serverName}, "", 1)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, service.AuditActionStatus, events[0].Action)
+		assert.Equal(t, "Use 2.x", events[0].Reason)
+		assert.Equal(t, "publisher", events[0].ActorSubject)
+	})
+
+	t.Run("publisher can undeprecate", func(t *testing.T) {
+		w := setStatus(publisher, "1.0.0", map[string]any{"status": "active"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, model.StatusActive, status(w))
+	})
+
+	t.Run("publisher can name a replacement", func(t *testing.T) {
+		w := setStatus(publisher, "1.0.0", map[string]any{
+			"status":     "deprecated",
+			"message":    "Use the latest version",
+			"replacedBy": map[string]string{"name": "com.example/status-server"},
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var server apiv0.ServerResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &server))
+		assert.Equal(t, "Use the latest version", server.Meta.Official.DeprecationMessage)
+		assert.Equal(t, &apiv0.ReplacedBy{Name: "com.example/status-server"}, server.Meta.Official.ReplacedBy)
+	})
+
+	t.Run("replacement must not be deleted", func(t *testing.T) {
+		w := setStatus(publisher, "1.0.0", map[string]any{
+			"status":     "deprecated",
+			"replacedBy": map[string]string{"name": "com.example/status-server", "version": "2.0.0"},
+		})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("deleting is not possible", func(t *testing.T) {
+		w := setStatus(publisher, "1.0.0", map[string]any{"status": "deleted"})
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	})
+
+	t.Run("deleted versions cannot be undeleted", func(t *testing.T) {
+		w := setStatus(publisher, "2.0.0", map[string]any{"status": "active"})
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("requires publish permission for the server", func(t *testing.T) {
+		outsider := testAuthHeader(t, cfg, "publisher", auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "org.example/*"})
+		w := setStatus(outsider, "1.0.0", map[string]any{"status": "deprecated"})
+		assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		w := setStatus(publisher, "9.9.9", map[string]any{"status": "deprecated"})
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+}
@@ -0,0 +1,116 @@
+package v0
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Results of a publish dry run
+const (
+	ValidationStatusAccepted = "accepted"
+	ValidationStatusRejected = "rejected"
+)
+
+// ValidateServerInput represents the input for validating a server without publishing it
+type ValidateServerInput struct {
+	Authorization string           `header:"Authorization" doc:"Registry JWT token (obtained from /v0/auth/token/github)" required:"true"`
+	Draft         bool             `query:"draft" doc:"Validate as a draft publish, which never becomes the latest version" required:"false"`
+	Body          apiv0.ServerJSON `body:""`
+}
+
+// ValidationResponse is what publishing a server version would do
+type ValidationResponse struct {
+	Status         string                   `json:"status" doc:"Whether the publish would be accepted" enum:"accepted,rejected" example:"accepted"`
+	Problems       []ValidationProblem      `json:"problems,omitempty" doc:"Why the publish would be rejected"`
+	IsLatest       bool                     `json:"isLatest" doc:"Whether the version would become the latest version if accepted"`
+	IsLatestStable bool                     `json:"isLatestStable" doc:"Whether the version would become the latest stable version if accepted"`
+	Packages       []PackageValidationCheck `json:"packages" doc:"Registry validation of each package, in the order of the server.json's packages"`
+}
+
+// ValidationProblem is a problem that would reject a publish
+type ValidationProblem struct {
+	Code    string `json:"code" doc:"Stable identifier of the kind of problem" example:"invalid_named_argument"`
+	Pointer string `json:"pointer" doc:"JSON pointer to the offending value in the server.json" example:"/packages/0/packageArguments/2/name"`
+	Message string `json:"message" doc:"What is wrong"`
+}
+
+// PackageValidationCheck is the result of the registry validation of a package in a dry run
+type PackageValidationCheck struct {
+	PackageIndex int               `json:"packageIndex" doc:"Position of the package in the server.json's packages" example:"0"`
+	RegistryType string            `json:"registryType" example:"npm"`
+	Identifier   string            `json:"identifier" example:"@example/my-server"`
+	Check        string            `json:"check,omitempty" doc:"Which check ran, or 'disabled' if registry validation is turned off" example:"npm-mcp-name"`
+	Outcome      string            `json:"outcome" doc:"Whether the check passed, was skipped, or failed" enum:"passed,skipped,failed" example:"passed"`
+	Facts        map[string]string `json:"facts,omitempty" doc:"Facts reported by the upstream registry, e.g. the resolved version, digest or checksum"`
+	Error        string            `json:"error,omitempty" doc:"Why the package failed validation"`
+}
+
+// RegisterValidateEndpoint registers the publish dry run endpoint with a custom path prefix
+func RegisterValidateEndpoint(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "validate-server" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/validate",
+		Summary:     "Validate MCP server",
+		Description: "Check whether a server.json would be published, without publishing it. " +
+			"Runs the same validation as publish, including the registry validation of each package, " +
+			"and reports every problem found and whether the version would become latest. Publish limits are not checked.",
+		Tags: []string{"publish"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ValidateServerInput) (*Response[ValidationResponse], error) {
+		// Dry runs need the same permissions as the publish they stand in for
+		ctx, claims, err := authenticate(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+		if !jwtManager.HasPermission(input.Body.Name, auth.PermissionActionPublish, claims.Permissions) {
+			return nil, huma.Error403Forbidden(buildPermissionErrorMessage(input.Body.Name, claims.Permissions))
+		}
+		if owners := jwtManager.PermissionOwners(input.Body.Name, auth.PermissionActionPublish, claims.Permissions); len(owners) > 0 {
+			ctx = service.WithNamespaceOwners(ctx, string(claims.AuthMethod), toNamespaceOwners(owners))
+		}
+
+		result, err := registry.DryRunPublish(ctx, &input.Body, input.Draft)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to validate server", err)
+		}
+
+		body := ValidationResponse{
+			Status:         ValidationStatusAccepted,
+			Problems:       make([]ValidationProblem, len(result.Problems)),
+			IsLatest:       result.IsLatest,
+			IsLatestStable: result.IsLatestStable,
+			Packages:       make([]PackageValidationCheck, len(result.Packages)),
+		}
+		if !result.Accepted() {
+			body.Status = ValidationStatusRejected
+		}
+		for i, problem := range result.Problems {
+			body.Problems[i] = ValidationProblem{Code: problem.Code, Pointer: problem.Pointer, Message: problem.Error()}
+		}
+		for i, outcome := range result.Packages {
+			body.Packages[i] = PackageValidationCheck{
+				PackageIndex: i,
+				RegistryType: outcome.RegistryType,
+				Identifier:   outcome.Identifier,
+				Check:        outcome.Check,
+				Outcome:      outcome.Outcome,
+				Facts:        outcome.Facts,
+				Error:        outcome.Error,
+			}
+		}
+
+		return &Response[ValidationResponse]{Body: body}, nil
+	})
+}
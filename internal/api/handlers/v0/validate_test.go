@@ -0,0 +1,88 @@
+package v0_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestValidateEndpoint(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.EnableRegistryValidation = false
+	registryService := service.NewRegistryService(database.NewMemory(), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPublishEndpoint(api, "/v0.1", registryService, cfg)
+	v0.RegisterValidateEndpoint(api, "/v0.1", registryService, cfg)
+
+	publisher := testAuthHeader(t, cfg, "publisher", auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "com.example/*"})
+	post := func(target, authHeader string, server apiv0.ServerJSON) *httptest.ResponseRecorder {
+		return serveTestRequest(t, mux, http.MethodPost, target, authHeader, server)
+	}
+	validate := func(server apiv0.ServerJSON) v0.ValidationResponse {
+		w := post("/v0.1/validate", publisher, server)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp v0.ValidationResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp
+	}
+	server := func(version string) apiv0.ServerJSON {
+		return apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/validated",
+			Description: "Validated server",
+			Version:     version,
+			Packages: []model.Package{{
+				RegistryType: model.RegistryTypeNPM,
+				Identifier:   "@example/validated",
+				Version:      version,
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+			}},
+		}
+	}
+
+	t.Run("requires publish permission", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, post("/v0.1/validate", "Bearer invalid", server("1.0.0")).Code)
+		outsider := testAuthHeader(t, cfg, "publisher", auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "org.example/*"})
+		assert.Equal(t, http.StatusForbidden, post("/v0.1/validate", outsider, server("1.0.0")).Code)
+	})
+
+	t.Run("new versions are accepted without being published", func(t *testing.T) {
+		resp := validate(server("1.0.0"))
+		assert.Equal(t, v0.ValidationStatusAccepted, resp.Status)
+		assert.Empty(t, resp.Problems)
+		assert.True(t, resp.IsLatest)
+		require.Len(t, resp.Packages, 1)
+		assert.Equal(t, "skipped", resp.Packages[0].Outcome)
+		assert.Equal(t, "@example/validated", resp.Packages[0].Identifier)
+
+		_, err := registryService.GetServerByName(t.Context(), "com.example/validated")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("published versions are rejected", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, post("/v0.1/publish", publisher, server("1.0.0")).Code)
+
+		resp := validate(server("1.0.0"))
+		assert.Equal(t, v0.ValidationStatusRejected, resp.Status)
+		assert.Equal(t, []v0.ValidationProblem{{Code: service.CodeVersionExists, Pointer: "/version", Message: database.ErrInvalidVersion.Error()}}, resp.Problems)
+
+		resp = validate(server("0.9.0"))
+		assert.Equal(t, v0.ValidationStatusAccepted, resp.Status)
+		assert.False(t, resp.IsLatest)
+	})
+}
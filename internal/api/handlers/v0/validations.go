@@ -0,0 +1,94 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListPackageValidationsInput represents the input for listing the package validations of a server version
+type ListPackageValidationsInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	ServerName    string `path:"serverName" doc:"URL-encoded server name" example:"com.example%2Fmy-server"`
+	Version       string `path:"version" doc:"URL-encoded server version" example:"1.0.0"`
+}
+
+// PackageValidation is the stored evidence of the registry validation of a package
+type PackageValidation struct {
+	PackageIndex int               `json:"packageIndex" doc:"Position of the package in the server.json's packages" example:"0"`
+	RegistryType string            `json:"registryType" example:"npm"`
+	Identifier   string            `json:"identifier" example:"@example/my-server"`
+	Check        string            `json:"check" doc:"Which check ran, or 'disabled' if registry validation was turned off" example:"npm-mcp-name"`
+	Outcome      string            `json:"outcome" doc:"Whether the check passed, or was skipped" enum:"passed,skipped" example:"passed"`
+	Facts        map[string]string `json:"facts,omitempty" doc:"Facts reported by the upstream registry, e.g. the resolved version, digest or checksum"`
+	ValidatedAt  time.Time         `json:"validatedAt" doc:"When the check ran"`
+}
+
+// PackageValidationListResponse lists the package validations of a server version
+type PackageValidationListResponse struct {
+	Validations []PackageValidation `json:"validations"`
+	Metadata    apiv0.Metadata      `json:"metadata"`
+}
+
+// RegisterValidationEndpoints registers the admin endpoint for package validation evidence with a custom path prefix
+func RegisterValidationEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-package-validations" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/servers/{serverName}/versions/{version}/validations",
+		Summary:     "List package validations",
+		Description: "List the stored evidence of why each package of a server version passed registry validation, " +
+			"oldest first. Edits that revalidate packages add new records (admin only).",
+		Tags: []string{"admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *ListPackageValidationsInput) (*Response[PackageValidationListResponse], error) {
+		ctx, _, err := authenticateAdmin(ctx, jwtManager, input.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		serverName, version, err := decodeServerVersionPath(input.ServerName, input.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		validations, err := registry.ListPackageValidations(ctx, serverName, version)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Server not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get package validations", err)
+		}
+
+		body := PackageValidationListResponse{
+			Validations: make([]PackageValidation, len(validations)),
+			Metadata:    apiv0.Metadata{Count: len(validations)},
+		}
+		for i, validation := range validations {
+			body.Validations[i] = PackageValidation{
+				PackageIndex: validation.PackageIndex,
+				RegistryType: validation.RegistryType,
+				Identifier:   validation.Identifier,
+				Check:        validation.Check,
+				Outcome:      validation.Outcome,
+				Facts:        validation.Facts,
+				ValidatedAt:  validation.ValidatedAt,
+			}
+		}
+
+		return &Response[PackageValidationListResponse]{Body: body}, nil
+	})
+}
@@ -0,0 +1,88 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestPackageValidationEndpoints(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.EnableRegistryValidation = false
+	registryService := service.NewRegistryService(database.NewMemory(), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterValidationEndpoints(api, "/v0", registryService, cfg)
+
+	adminToken := testAuthHeader(t, cfg, "admin", auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"})
+	editorToken := testAuthHeader(t, cfg, "editor", auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "com.example/*"})
+	serve := func(target, authHeader string) *httptest.ResponseRecorder {
+		return serveTestRequest(t, mux, http.MethodGet, target, authHeader, nil)
+	}
+
+	server := apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/validated",
+		Description: "Server with packages",
+		Version:     "1.0.0",
+		Packages: []model.Package{
+			{
+				RegistryType: model.RegistryTypeNPM,
+				Identifier:   "@example/validated",
+				Version:      "1.0.0",
+				Transport:    model.Transport{Type: model.TransportTypeStdio},
+			},
+		},
+	}
+	_, err := registryService.CreateServer(context.Background(), &server)
+	require.NoError(t, err)
+
+	validationsPath := "/v0/servers/" + url.PathEscape(server.Name) + "/versions/1.0.0/validations"
+
+	t.Run("lists the evidence recorded at publish", func(t *testing.T) {
+		w := serve(validationsPath, adminToken)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp v0.PackageValidationListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(t, resp.Validations, 1)
+		validation := resp.Validations[0]
+		assert.Equal(t, 0, validation.PackageIndex)
+		assert.Equal(t, model.RegistryTypeNPM, validation.RegistryType)
+		assert.Equal(t, "@example/validated", validation.Identifier)
+		// Registry validation is disabled, so the package was accepted without being checked
+		assert.Equal(t, validators.CheckDisabled, validation.Check)
+		assert.Equal(t, "skipped", validation.Outcome)
+		assert.False(t, validation.ValidatedAt.IsZero())
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		w := serve("/v0/servers/"+url.PathEscape(server.Name)+"/versions/2.0.0/validations", adminToken)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("requires admin", func(t *testing.T) {
+		w := serve(validationsPath, editorToken)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = serve(validationsPath, "Bearer invalid")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
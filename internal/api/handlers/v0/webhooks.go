@@ -0,0 +1,347 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// AdminInput represents the input of admin endpoints without parameters
+type AdminInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+}
+
+// WebhookInput represents the input for operations on a webhook
+type WebhookInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	ID            int64  `path:"id" doc:"Webhook ID" example:"1"`
+}
+
+// CreateWebhookInput represents the input for creating a webhook
+type CreateWebhookInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	Body          struct {
+		URL    string   `json:"url" doc:"Endpoint that deliveries are POSTed to" format:"uri" example:"https://example.com/hooks/registry"`
+		Events []string `json:"events" doc:"Event types to deliver" minItems:"1" uniqueItems:"true" enum:"server.published,server.updated,server.status_changed"`
+		Secret string   `json:"secret,omitempty" doc:"Key deliveries are signed with; generated if omitted" required:"false"`
+	}
+}
+
+// UpdateWebhookInput represents the input for updating a webhook
+type UpdateWebhookInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	ID            int64  `path:"id" doc:"Webhook ID" example:"1"`
+	Body          struct {
+		URL    string   `json:"url" doc:"Endpoint that deliveries are POSTed to" format:"uri" example:"https://example.com/hooks/registry"`
+		Events []string `json:"events" doc:"Event types to deliver" minItems:"1" uniqueItems:"true" enum:"server.published,server.updated,server.status_changed"`
+		Active bool     `json:"active" doc:"Whether deliveries are sent; deliveries to inactive webhooks fail"`
+	}
+}
+
+// ListWebhookDeliveriesInput represents the input for listing the deliveries of a webhook
+type ListWebhookDeliveriesInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	ID            int64  `path:"id" doc:"Webhook ID" example:"1"`
+	Cursor        string `query:"cursor" doc:"Pagination cursor" required:"false" example:"42"`
+	Limit         int    `query:"limit" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100" example:"50"`
+}
+
+// RedeliverWebhookInput represents the input for redelivering a webhook delivery
+type RedeliverWebhookInput struct {
+	Authorization string `header:"Authorization" doc:"Registry JWT token with admin permissions" required:"true"`
+	ID            int64  `path:"id" doc:"Webhook ID" example:"1"`
+	DeliveryID    int64  `path:"deliveryId" doc:"Delivery ID" example:"42"`
+}
+
+// Webhook is a subscription of a URL to registry events
+type Webhook struct {
+	ID        int64     `json:"id" example:"1"`
+	URL       string    `json:"url" example:"https://example.com/hooks/registry"`
+	Events    []string  `json:"events" example:"[\"server.published\"]"`
+	Active    bool      `json:"active"`
+	Secret    string    `json:"secret,omitempty" doc:"Signing key, only returned when the webhook is created" required:"false"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// WebhookListResponse is the list of webhooks
+type WebhookListResponse struct {
+	Webhooks []Webhook      `json:"webhooks"`
+	Metadata apiv0.Metadata `json:"metadata"`
+}
+
+// WebhookDelivery is the record of an event sent, or to be sent, to a webhook
+type WebhookDelivery struct {
+	ID             int64      `json:"id" example:"42"`
+	WebhookID      int64      `json:"webhookId" example:"1"`
+	Event          string     `json:"event" example:"server.published"`
+	ServerName     string     `json:"serverName" example:"io.github.octocat/weather"`
+	Version        string     `json:"version" example:"1.0.0"`
+	Status         string     `json:"status" enum:"pending,succeeded,failed"`
+	Attempts       int        `json:"attempts" doc:"Attempts made since the delivery was created or last redelivered"`
+	NextAttemptAt  *time.Time `json:"nextAttemptAt,omitempty" doc:"When the next attempt is due, for pending deliveries" required:"false"`
+	LastAttemptAt  *time.Time `json:"lastAttemptAt,omitempty" required:"false"`
+	ResponseStatus int        `json:"responseStatus,omitempty" doc:"HTTP status of the last attempt's response" required:"false"`
+	LastError      string     `json:"lastError,omitempty" doc:"Why the last attempt failed" required:"false"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+// WebhookDeliveryListResponse is a page of webhook deliveries
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	Metadata   apiv0.Metadata    `json:"metadata"`
+}
+
+// RegisterWebhookEndpoints registers the webhook management endpoints with a custom path prefix
+func RegisterWebhookEndpoints(api huma.API, pathPrefix string, registry service.RegistryService, cfg *config.Config) {
+	jwtManager := auth.NewJWTManager(cfg)
+	security := []map[string][]string{
+		{"bearer": {}},
+	}
+
+	// Create webhook endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "create-webhook" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/admin/webhooks",
+		Summary:     "Create webhook",
+		Description: "Subscribe a URL to registry events (admin only). Each delivery is a POST of the event as JSON, " +
+			"signed with HMAC-SHA256 of \"<" + service.WebhookTimestampHeader + ">.<body>\" in the " +
+			service.WebhookSignatureHeader + " header. The secret is only returned in this response.",
+		Tags:          []string{"admin"},
+		Security:      security,
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *CreateWebhookInput) (*Response[Webhook], error) {
+		if _, _, err := authenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		webhook, err := registry.CreateWebhook(ctx, input.Body.URL, input.Body.Events, input.Body.Secret)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Invalid webhook", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to create webhook", err)
+		}
+
+		body := toWebhook(webhook)
+		body.Secret = webhook.Secret
+		return &Response[Webhook]{Body: body}, nil
+	})
+
+	// List webhooks endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "list-webhooks" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/webhooks",
+		Summary:     "List webhooks",
+		Description: "List all webhooks (admin only).",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *AdminInput) (*Response[WebhookListResponse], error) {
+		if _, _, err := authenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		webhooks, err := registry.ListWebhooks(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to get webhooks", err)
+		}
+
+		body := WebhookListResponse{
+			Webhooks: make([]Webhook, len(webhooks)),
+			Metadata: apiv0.Metadata{Count: len(webhooks)},
+		}
+		for i, webhook := range webhooks {
+			body.Webhooks[i] = toWebhook(webhook)
+		}
+
+		return &Response[WebhookListResponse]{Body: body}, nil
+	})
+
+	// Get webhook endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "get-webhook" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/webhooks/{id}",
+		Summary:     "Get webhook",
+		Description: "Get a webhook (admin only).",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *WebhookInput) (*Response[Webhook], error) {
+		if _, _, err := authenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		webhook, err := registry.GetWebhook(ctx, input.ID)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Webhook not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to get webhook", err)
+		}
+
+		return &Response[Webhook]{Body: toWebhook(webhook)}, nil
+	})
+
+	// Update webhook endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "update-webhook" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/admin/webhooks/{id}",
+		Summary:     "Update webhook",
+		Description: "Change the URL, events and active flag of a webhook (admin only). The secret cannot be changed: " +
+			"create a new webhook to rotate it.",
+		Tags:     []string{"admin"},
+		Security: security,
+	}, func(ctx context.Context, input *UpdateWebhookInput) (*Response[Webhook], error) {
+		if _, _, err := authenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		webhook, err := registry.UpdateWebhook(ctx, input.ID, input.Body.URL, input.Body.Events, input.Body.Active)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Webhook not found")
+			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Invalid webhook", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to update webhook", err)
+		}
+
+		return &Response[Webhook]{Body: toWebhook(webhook)}, nil
+	})
+
+	// Delete webhook endpoint
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-webhook" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:        http.MethodDelete,
+		Path:          pathPrefix + "/admin/webhooks/{id}",
+		Summary:       "Delete webhook",
+		Description:   "Delete a webhook and its delivery log (admin only). Pending deliveries are not sent.",
+		Tags:          []string{"admin"},
+		Security:      security,
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *WebhookInput) (*struct{}, error) {
+		if _, _, err := authenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		if err := registry.DeleteWebhook(ctx, input.ID); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Webhook not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to delete webhook", err)
+		}
+
+		return nil, nil
+	})
+
+	// List webhook deliveries endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "list-webhook-deliveries" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/admin/webhooks/{id}/deliveries",
+		Summary:     "List webhook deliveries",
+		Description: "List the deliveries of a webhook newest first, with the outcome of their last attempt (admin only).",
+		Tags:        []string{"admin"},
+		Security:    security,
+	}, func(ctx context.Context, input *ListWebhookDeliveriesInput) (*Response[WebhookDeliveryListResponse], error) {
+		if _, _, err := authenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		deliveries, nextCursor, err := registry.ListWebhookDeliveries(ctx, input.ID, input.Cursor, input.Limit)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Webhook not found")
+			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest("Invalid cursor", err)
+			}
+			return nil, huma.Error500InternalServerError("Failed to get webhook deliveries", err)
+		}
+
+		body := WebhookDeliveryListResponse{
+			Deliveries: make([]WebhookDelivery, len(deliveries)),
+			Metadata: apiv0.Metadata{
+				NextCursor: nextCursor,
+				Count:      len(deliveries),
+			},
+		}
+		for i, delivery := range deliveries {
+			body.Deliveries[i] = toWebhookDelivery(delivery)
+		}
+
+		return &Response[WebhookDeliveryListResponse]{Body: body}, nil
+	})
+
+	// Redeliver webhook delivery endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "redeliver-webhook-delivery" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/admin/webhooks/{id}/deliveries/{deliveryId}/redeliver",
+		Summary:     "Redeliver webhook delivery",
+		Description: "Send a delivery again right away, with a fresh set of retries, whatever its outcome so far (admin only). " +
+			"The payload and delivery ID are unchanged.",
+		Tags:     []string{"admin"},
+		Security: security,
+	}, func(ctx context.Context, input *RedeliverWebhookInput) (*Response[WebhookDelivery], error) {
+		if _, _, err := authenticateAdmin(ctx, jwtManager, input.Authorization); err != nil {
+			return nil, err
+		}
+
+		delivery, err := registry.RedeliverWebhookDelivery(ctx, input.ID, input.DeliveryID)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Webhook delivery not found")
+			}
+			return nil, huma.Error500InternalServerError("Failed to redeliver webhook delivery", err)
+		}
+
+		return &Response[WebhookDelivery]{Body: toWebhookDelivery(delivery)}, nil
+	})
+}
+
+// toWebhook converts a stored webhook to its API representation, without its secret
+func toWebhook(webhook *database.Webhook) Webhook {
+	return Webhook{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    webhook.Events,
+		Active:    webhook.Active,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
+
+// toWebhookDelivery converts a stored webhook delivery to its API representation
+func toWebhookDelivery(delivery *database.WebhookDelivery) WebhookDelivery {
+	result := WebhookDelivery{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		Event:          delivery.EventType,
+		ServerName:     delivery.ServerName,
+		Version:        delivery.Version,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+	}
+	if delivery.Status == database.WebhookDeliveryPending {
+		result.NextAttemptAt = &delivery.NextAttemptAt
+	}
+	return result
+}
@@ -0,0 +1,127 @@
+package v0_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/modelcontextprotocol/registry/internal/api/handlers/v0"
+	"github.com/modelcontextprotocol/registry/internal/auth"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func TestWebhookEndpoints(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.EnableRegistryValidation = false
+	registryService := service.NewRegistryService(database.NewMemory(), cfg)
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterWebhookEndpoints(api, "/v0", registryService, cfg)
+
+	adminToken := testAuthHeader(t, cfg, "admin", auth.Permission{Action: auth.PermissionActionEdit, ResourcePattern: "*"})
+	publisherToken := testAuthHeader(t, cfg, "publisher", auth.Permission{Action: auth.PermissionActionPublish, ResourcePattern: "*"})
+	serve := func(method, target, authHeader string, body any) *httptest.ResponseRecorder {
+		return serveTestRequest(t, mux, method, target, authHeader, body)
+	}
+	webhookBody := map[string]any{
+		"url":    "https://example.com/hooks/registry",
+		"events": []string{service.WebhookEventPublished},
+	}
+
+	t.Run("requires admin permissions", func(t *testing.T) {
+		w := serve(http.MethodPost, "/v0/admin/webhooks", publisherToken, webhookBody)
+		assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+		w = serve(http.MethodGet, "/v0/admin/webhooks", "Bearer invalid", nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code, w.Body.String())
+	})
+
+	t.Run("rejects unknown events", func(t *testing.T) {
+		w := serve(http.MethodPost, "/v0/admin/webhooks", adminToken, map[string]any{
+			"url":    "https://example.com/hooks/registry",
+			"events": []string{"server.deleted"},
+		})
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	})
+
+	w := serve(http.MethodPost, "/v0/admin/webhooks", adminToken, webhookBody)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created v0.Webhook
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.NotEmpty(t, created.Secret, "a secret should be generated and returned once")
+	assert.True(t, created.Active)
+	webhookPath := "/v0/admin/webhooks/" + strconv.FormatInt(created.ID, 10)
+
+	t.Run("secret is not returned afterwards", func(t *testing.T) {
+		w := serve(http.MethodGet, webhookPath, adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var webhook v0.Webhook
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &webhook))
+		assert.Empty(t, webhook.Secret)
+
+		w = serve(http.MethodGet, "/v0/admin/webhooks", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list v0.WebhookListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Webhooks, 1)
+		assert.Empty(t, list.Webhooks[0].Secret)
+	})
+
+	t.Run("publishes are logged as deliveries and can be redelivered", func(t *testing.T) {
+		_, err := registryService.CreateServer(t.Context(), &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/webhook-endpoint-test",
+			Description: "Webhook endpoint test server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+
+		w := serve(http.MethodGet, webhookPath+"/deliveries", adminToken, nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var list v0.WebhookDeliveryListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Len(t, list.Deliveries, 1)
+		delivery := list.Deliveries[0]
+		assert.Equal(t, service.WebhookEventPublished, delivery.Event)
+		assert.Equal(t, "com.example/webhook-endpoint-test", delivery.ServerName)
+		assert.Equal(t, database.WebhookDeliveryPending, delivery.Status)
+		assert.NotNil(t, delivery.NextAttemptAt)
+
+		w = serve(http.MethodPost, webhookPath+"/deliveries/"+strconv.FormatInt(delivery.ID, 10)+"/redeliver", adminToken, nil)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		w = serve(http.MethodPost, webhookPath+"/deliveries/999/redeliver", adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+
+	t.Run("update and delete", func(t *testing.T) {
+		w := serve(http.MethodPut, webhookPath, adminToken, map[string]any{
+			"url":    "https://example.com/hooks/other",
+			"events": []string{service.WebhookEventStatusChanged},
+			"active": false,
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var updated v0.Webhook
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+		assert.Equal(t, "https://example.com/hooks/other", updated.URL)
+		assert.Equal(t, []string{service.WebhookEventStatusChanged}, updated.Events)
+		assert.False(t, updated.Active)
+		assert.Empty(t, updated.Secret)
+
+		w = serve(http.MethodDelete, webhookPath, adminToken, nil)
+		assert.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+		w = serve(http.MethodGet, webhookPath, adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+		w = serve(http.MethodGet, webhookPath+"/deliveries", adminToken, nil)
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+}
@@ -17,10 +17,21 @@ func RegisterV0Routes(
 	v0.RegisterHealthEndpoint(api, "/v0", cfg, metrics)
 	v0.RegisterPingEndpoint(api, "/v0")
 	v0.RegisterVersionEndpoint(api, "/v0", versionInfo)
-	v0.RegisterServersEndpoints(api, "/v0", registry)
+	v0.RegisterServersEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterChangesEndpoint(api, "/v0", registry)
 	v0.RegisterEditEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterRevisionEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterValidationEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterNamespaceEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterAuditEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterWebhookEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterLatestEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterBulkStatusEndpoints(api, "/v0", registry, cfg)
+	v0.RegisterPublishLimitsEndpoints(api, "/v0", registry, cfg)
 	v0auth.RegisterAuthEndpoints(api, "/v0", cfg)
 	v0.RegisterPublishEndpoint(api, "/v0", registry, cfg)
+	v0.RegisterStatusEndpoint(api, "/v0", registry, cfg)
+	v0.RegisterChannelEndpoints(api, "/v0", registry, cfg)
 }
 
 func RegisterV0_1Routes(
@@ -29,8 +40,21 @@ func RegisterV0_1Routes(
 	v0.RegisterHealthEndpoint(api, "/v0.1", cfg, metrics)
 	v0.RegisterPingEndpoint(api, "/v0.1")
 	v0.RegisterVersionEndpoint(api, "/v0.1", versionInfo)
-	v0.RegisterServersEndpoints(api, "/v0.1", registry)
+	v0.RegisterServersEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterChangesEndpoint(api, "/v0.1", registry)
+	v0.RegisterExportEndpoint(api, "/v0.1", registry)
 	v0.RegisterEditEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterRevisionEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterValidationEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterNamespaceEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterAuditEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterWebhookEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterLatestEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterBulkStatusEndpoints(api, "/v0.1", registry, cfg)
+	v0.RegisterPublishLimitsEndpoints(api, "/v0.1", registry, cfg)
 	v0auth.RegisterAuthEndpoints(api, "/v0.1", cfg)
 	v0.RegisterPublishEndpoint(api, "/v0.1", registry, cfg)
+	v0.RegisterValidateEndpoint(api, "/v0.1", registry, cfg)
+	v0.RegisterStatusEndpoint(api, "/v0.1", registry, cfg)
+	v0.RegisterChannelEndpoints(api, "/v0.1", registry, cfg)
 }
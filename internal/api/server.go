@@ -2,8 +2,12 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"net/netip"
+	"slices"
 	"strings"
 	"time"
 
@@ -35,6 +39,77 @@ func TrailingSlashMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// ParseTrustedProxies parses a comma-separated list of IP addresses and CIDR ranges
+func ParseTrustedProxies(list string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if strings.Contains(entry, "/") {
+			prefix, err := netip.ParsePrefix(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", entry, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", entry, err)
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+	}
+	return prefixes, nil
+}
+
+// ClientIPMiddleware records the client IP of each request for the audit log. This is the
+// connection's remote address, unless that is one of the trusted proxies: then it is the
+// right-most address in X-Forwarded-For that is not a trusted proxy, since every address left
+// of the last trusted hop could have been written by the client.
+func ClientIPMiddleware(trustedProxies []netip.Prefix) func(http.Handler) http.Handler {
+	trusted := func(ip string) bool {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			return false
+		}
+		addr = addr.Unmap()
+		return slices.ContainsFunc(trustedProxies, func(prefix netip.Prefix) bool {
+			return prefix.Contains(addr)
+		})
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := r.RemoteAddr
+			if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+				ip = host
+			}
+			if trusted(ip) {
+				var hops []string
+				for _, header := range r.Header.Values("X-Forwarded-For") {
+					for _, hop := range strings.Split(header, ",") {
+						hops = append(hops, strings.TrimSpace(hop))
+					}
+				}
+				for i := len(hops) - 1; i >= 0; i-- {
+					if _, err := netip.ParseAddr(hops[i]); err != nil {
+						// Garbage before a trusted hop is the client's doing, so the last trusted hop is the best we know
+						break
+					}
+					ip = hops[i]
+					if !trusted(ip) {
+						break
+					}
+				}
+			}
+
+			next.ServeHTTP(w, r.WithContext(service.WithSourceIP(r.Context(), ip)))
+		})
+	}
+}
+
 // Server represents the HTTP server
 type Server struct {
 	config   *config.Config
@@ -66,9 +141,15 @@ func NewServer(cfg *config.Config, registryService service.RegistryService, metr
 		MaxAge:           86400, // 24 hours
 	})
 
+	// X-Forwarded-For is only read from trusted proxies, so an invalid list trusts none
+	trustedProxies, err := ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		log.Printf("Ignoring MCP_REGISTRY_TRUSTED_PROXIES: %v", err)
+	}
+
 	// Wrap the mux with middleware stack
-	// Order: TrailingSlash -> CORS -> Mux
-	handler := TrailingSlashMiddleware(corsHandler.Handler(mux))
+	// Order: TrailingSlash -> ClientIP -> CORS -> Mux
+	handler := TrailingSlashMiddleware(ClientIPMiddleware(trustedProxies)(corsHandler.Handler(mux)))
 
 	server := &Server{
 		config:   cfg,
@@ -1,11 +1,21 @@
 package api_test
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/modelcontextprotocol/registry/internal/api"
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/service"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
 func TestTrailingSlashMiddleware(t *testing.T) {
@@ -93,3 +103,67 @@ func TestTrailingSlashMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestClientIPMiddleware(t *testing.T) {
+	trustedProxies, err := api.ParseTrustedProxies("10.0.0.0/8, 192.0.2.1")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		expectedIP   string
+	}{
+		{"remote address", "198.51.100.4:51234", "", "198.51.100.4"},
+		{"forwarded address from untrusted peer is ignored", "198.51.100.4:51234", "203.0.113.7", "198.51.100.4"},
+		{"forwarded address from trusted proxy", "10.0.0.1:443", "203.0.113.7", "203.0.113.7"},
+		{"right-most untrusted address", "10.0.0.1:443", "203.0.113.66, 203.0.113.7, 10.0.0.2, 192.0.2.1", "203.0.113.7"},
+		{"all forwarded addresses trusted", "10.0.0.1:443", "10.0.0.3, 10.0.0.2", "10.0.0.3"},
+		{"invalid forwarded address", "10.0.0.1:443", "203.0.113.66, not-an-ip, 10.0.0.2", "10.0.0.2"},
+		{"trusted proxy without forwarded address", "10.0.0.1:443", "", "10.0.0.1"},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registryService := service.NewRegistryService(database.NewMemory(), &config.Config{})
+
+			// Publish from within a request so that the audit event records the request's IP
+			handler := api.ClientIPMiddleware(trustedProxies)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, err := registryService.CreateServer(r.Context(), &apiv0.ServerJSON{
+					Schema:      model.CurrentSchemaURL,
+					Name:        fmt.Sprintf("com.example/client-ip-%d", i),
+					Description: "Client IP test server",
+					Version:     "1.0.0",
+				})
+				require.NoError(t, err)
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/v0/publish", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			events, _, err := registryService.ListAuditEvents(context.Background(), nil, "", 10)
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			assert.Equal(t, tt.expectedIP, events[0].SourceIP)
+			assert.Equal(t, service.ActorMethodSystem, events[0].ActorMethod)
+		})
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	prefixes, err := api.ParseTrustedProxies("")
+	require.NoError(t, err)
+	assert.Empty(t, prefixes)
+
+	prefixes, err = api.ParseTrustedProxies("10.1.2.3/8, 2001:db8::1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.0/8", "2001:db8::1/128"}, []string{prefixes[0].String(), prefixes[1].String()})
+
+	_, err = api.ParseTrustedProxies("10.0.0.0/8, proxy.internal")
+	assert.ErrorContains(t, err, "proxy.internal")
+}
@@ -24,6 +24,23 @@ const (
 type Permission struct {
 	Action          PermissionAction `json:"action"`   // The action type (publish or edit)
 	ResourcePattern string           `json:"resource"` // e.g., "io.github.username/*"
+	// The verified identity the permission was granted for, if any. Permissions granted by
+	// configuration (e.g. admin tokens) have no owner.
+	Owner *Identity `json:"owner,omitempty"`
+}
+
+// Identity types of namespace owners
+const (
+	IdentityTypeGitHub = "github" // a GitHub user or organization, by numeric account ID
+	IdentityTypeDomain = "domain" // a domain verified over DNS or HTTP, by domain name
+)
+
+// Identity is a stable identity that can own namespaces. Unlike GitHub logins, which can be
+// renamed and then claimed by someone else, the ID never refers to a different owner.
+type Identity struct {
+	Type string `json:"type"`           // one of the IdentityType constants
+	ID   string `json:"id"`             // GitHub account ID, or the domain name
+	Name string `json:"name,omitempty"` // GitHub login when the token was issued, for display
 }
 
 // JWTClaims represents the claims for the Registry JWT token
@@ -155,6 +172,17 @@ func (j *JWTManager) HasPermission(resource string, action PermissionAction, per
 	return false
 }
 
+// PermissionOwners returns the identities that grant the given action on a resource
+func (j *JWTManager) PermissionOwners(resource string, action PermissionAction, permissions []Permission) []Identity {
+	var owners []Identity
+	for _, perm := range permissions {
+		if perm.Action == action && perm.Owner != nil && isResourceMatch(resource, perm.ResourcePattern) {
+			owners = append(owners, *perm.Owner)
+		}
+	}
+	return owners
+}
+
 func isResourceMatch(resource, pattern string) bool {
 	if pattern == "*" {
 		return true
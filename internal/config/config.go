@@ -1,21 +1,36 @@
 package config
 
 import (
+	"time"
+
 	env "github.com/caarlos0/env/v11"
 )
 
 // Config holds the application configuration
 // See .env.example for more documentation
 type Config struct {
-	ServerAddress            string `env:"SERVER_ADDRESS" envDefault:":8080"`
-	DatabaseURL              string `env:"DATABASE_URL" envDefault:"postgres://localhost:5432/mcp-registry?sslmode=disable"`
-	SeedFrom                 string `env:"SEED_FROM" envDefault:""`
-	Version                  string `env:"VERSION" envDefault:"dev"`
-	GithubClientID           string `env:"GITHUB_CLIENT_ID" envDefault:""`
-	GithubClientSecret       string `env:"GITHUB_CLIENT_SECRET" envDefault:""`
-	JWTPrivateKey            string `env:"JWT_PRIVATE_KEY" envDefault:""`
-	EnableAnonymousAuth      bool   `env:"ENABLE_ANONYMOUS_AUTH" envDefault:"false"`
-	EnableRegistryValidation bool   `env:"ENABLE_REGISTRY_VALIDATION" envDefault:"true"`
+	ServerAddress             string        `env:"SERVER_ADDRESS" envDefault:":8080"`
+	DatabaseURL               string        `env:"DATABASE_URL" envDefault:"postgres://localhost:5432/mcp-registry?sslmode=disable"`
+	DatabaseReadURL           string        `env:"DATABASE_READ_URL" envDefault:""`
+	DatabaseReadMaxLag        time.Duration `env:"DATABASE_READ_MAX_LAG" envDefault:"10s"`
+	AutoMigrate               bool          `env:"AUTO_MIGRATE" envDefault:"true"`
+	SeedFrom                  string        `env:"SEED_FROM" envDefault:""`
+	Version                   string        `env:"VERSION" envDefault:"dev"`
+	GithubClientID            string        `env:"GITHUB_CLIENT_ID" envDefault:""`
+	GithubClientSecret        string        `env:"GITHUB_CLIENT_SECRET" envDefault:""`
+	JWTPrivateKey             string        `env:"JWT_PRIVATE_KEY" envDefault:""`
+	CursorSigningKey          string        `env:"CURSOR_SIGNING_KEY" envDefault:""`
+	EnableAnonymousAuth       bool          `env:"ENABLE_ANONYMOUS_AUTH" envDefault:"false"`
+	EnableRegistryValidation  bool          `env:"ENABLE_REGISTRY_VALIDATION" envDefault:"true"`
+	WebhookPollInterval       time.Duration `env:"WEBHOOK_POLL_INTERVAL" envDefault:"5s"`
+	WebhookMaxAttempts        int           `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"8"`
+	CacheTTL                  time.Duration `env:"CACHE_TTL" envDefault:"30s"`
+	HTTPCacheMaxAge           time.Duration `env:"HTTP_CACHE_MAX_AGE" envDefault:"60s"`
+	PublishesPerHour          int           `env:"PUBLISHES_PER_HOUR" envDefault:"100"`
+	NewServersPerDay          int           `env:"NEW_SERVERS_PER_DAY" envDefault:"20"`
+	PublisherPublishesPerHour int           `env:"PUBLISHER_PUBLISHES_PER_HOUR" envDefault:"100"`
+	PublisherNewServersPerDay int           `env:"PUBLISHER_NEW_SERVERS_PER_DAY" envDefault:"20"`
+	TrustedProxies            string        `env:"TRUSTED_PROXIES" envDefault:""`
 
 	// OIDC Configuration
 	OIDCEnabled      bool   `env:"OIDC_ENABLED" envDefault:"false"`
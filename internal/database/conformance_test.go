@@ -0,0 +1,1530 @@
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The conformance suite describes the behaviour every Database implementation must share.
+// Each backend runs it against a fresh database per subtest.
+
+func TestMemory_Conformance(t *testing.T) {
+	runConformanceSuite(t, func(_ *testing.T) database.Database {
+		return database.NewMemory()
+	})
+}
+
+func TestPostgreSQL_Conformance(t *testing.T) {
+	runConformanceSuite(t, database.NewTestDB)
+}
+
+func runConformanceSuite(t *testing.T, newDB func(t *testing.T) database.Database) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, db database.Database)
+	}{
+		{"create and get", testConformanceCreateAndGet},
+		{"duplicate version", testConformanceDuplicateVersion},
+		{"single latest per server", testConformanceSingleLatest},
+		{"list filters", testConformanceListFilters},
+		{"cursor pagination", testConformanceCursorPagination},
+		{"ranked search", testConformanceSearch},
+		{"release order", testConformanceReleaseOrder},
+		{"audit events", testConformanceAuditEvents},
+		{"server revisions", testConformanceServerRevisions},
+		{"package validations", testConformancePackageValidations},
+		{"namespaces", testConformanceNamespaces},
+		{"webhooks", testConformanceWebhooks},
+		{"drafts", testConformanceDrafts},
+		{"change feed", testConformanceChangeFeed},
+		{"export", testConformanceExport},
+		{"sorted listings", testConformanceSortedListings},
+		{"update and status", testConformanceUpdateAndStatus},
+		{"status details", testConformanceStatusDetails},
+		{"latest flag", testConformanceLatestFlag},
+		{"latest stable flag", testConformanceLatestStableFlag},
+		{"channels", testConformanceChannels},
+		{"publish limits", testConformancePublishLimits},
+		{"server remotes", testConformanceServerRemotes},
+		{"transaction commit and rollback", testConformanceTransactions},
+		{"publish lock", testConformancePublishLock},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newDB(t))
+		})
+	}
+}
+
+// createConformanceServer inserts a server version with sensible defaults
+func createConformanceServer(t *testing.T, db database.Database, tx database.Tx, name, version string, isLatest bool, remotes ...string) {
+	t.Helper()
+
+	serverJSON := &apiv0.ServerJSON{
+		Name:        name,
+		Description: "Conformance test server",
+		Version:     version,
+	}
+	for _, remote := range remotes {
+		serverJSON.Remotes = append(serverJSON.Remotes, model.Transport{Type: "streamable-http", URL: remote})
+	}
+
+	_, err := db.CreateServer(context.Background(), tx, serverJSON, &apiv0.RegistryExtensions{
+		Status:      model.StatusActive,
+		PublishedAt: time.Now(),
+		UpdatedAt:   time.Now(),
+		IsLatest:    isLatest,
+	})
+	require.NoError(t, err)
+}
+
+func testConformanceCreateAndGet(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	createConformanceServer(t, db, nil, "com.example/conformance", "1.0.0", false)
+	createConformanceServer(t, db, nil, "com.example/conformance", "2.0.0", true)
+
+	latest, err := db.GetServerByName(ctx, nil, "com.example/conformance")
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", latest.Server.Version)
+	assert.True(t, latest.Meta.Official.IsLatest)
+	assert.Equal(t, model.StatusActive, latest.Meta.Official.Status)
+
+	specific, err := db.GetServerByNameAndVersion(ctx, nil, "com.example/conformance", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", specific.Server.Version)
+	assert.False(t, specific.Meta.Official.IsLatest)
+
+	all, err := db.GetAllVersionsByServerName(ctx, nil, "com.example/conformance")
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	count, err := db.CountServerVersions(ctx, nil, "com.example/conformance")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	exists, err := db.CheckVersionExists(ctx, nil, "com.example/conformance", "2.0.0")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	_, err = db.GetServerByName(ctx, nil, "com.example/missing")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+	_, err = db.GetServerByNameAndVersion(ctx, nil, "com.example/conformance", "3.0.0")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+	_, err = db.GetAllVersionsByServerName(ctx, nil, "com.example/missing")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func testConformanceDuplicateVersion(t *testing.T, db database.Database) {
+	createConformanceServer(t, db, nil, "com.example/duplicate", "1.0.0", true)
+
+	_, err := db.CreateServer(context.Background(), nil, &apiv0.ServerJSON{
+		Name:        "com.example/duplicate",
+		Description: "Duplicate",
+		Version:     "1.0.0",
+	}, &apiv0.RegistryExtensions{
+		Status:      model.StatusActive,
+		PublishedAt: time.Now(),
+		UpdatedAt:   time.Now(),
+	})
+	assert.Error(t, err)
+
+	_, err = db.CreateServer(context.Background(), nil, &apiv0.ServerJSON{
+		Name:        "invalid-name-format",
+		Description: "Invalid",
+		Version:     "1.0.0",
+	}, &apiv0.RegistryExtensions{
+		Status:      model.StatusActive,
+		PublishedAt: time.Now(),
+		UpdatedAt:   time.Now(),
+	})
+	assert.Error(t, err)
+}
+
+func testConformanceSingleLatest(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	createConformanceServer(t, db, nil, "com.example/latest", "1.0.0", true)
+
+	// A second latest version violates the invariant
+	_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{
+		Name:        "com.example/latest",
+		Description: "Second latest",
+		Version:     "2.0.0",
+	}, &apiv0.RegistryExtensions{
+		Status:      model.StatusActive,
+		PublishedAt: time.Now(),
+		UpdatedAt:   time.Now(),
+		IsLatest:    true,
+	})
+	assert.Error(t, err)
+
+	// Unmarking the old latest first makes room for the new one
+	require.NoError(t, db.UnmarkAsLatest(ctx, nil, "com.example/latest"))
+	_, err = db.GetCurrentLatestVersion(ctx, nil, "com.example/latest")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	createConformanceServer(t, db, nil, "com.example/latest", "2.0.0", true)
+	latest, err := db.GetCurrentLatestVersion(ctx, nil, "com.example/latest")
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", latest.Server.Version)
+}
+
+func testConformanceListFilters(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	createConformanceServer(t, db, nil, "com.example/alpha", "1.0.0", false, "https://alpha.example.com/mcp")
+	createConformanceServer(t, db, nil, "com.example/alpha", "1.1.0", true, "https://alpha.example.com/mcp")
+	createConformanceServer(t, db, nil, "org.example/beta", "1.0.0", true, "https://beta.example.com/mcp")
+	_, err := db.SetServerStatus(ctx, nil, "org.example/beta", "1.0.0", string(model.StatusDeprecated), nil)
+	require.NoError(t, err)
+
+	// A packaged server, published before the others
+	_, err = db.CreateServer(ctx, nil, &apiv0.ServerJSON{
+		Name:        "com.example/gamma",
+		Description: "Conformance test server",
+		Version:     "1.0.0",
+		Packages: []model.Package{
+			{RegistryType: model.RegistryTypeOCI, Identifier: "ghcr.io/example/gamma:1.0.0", Transport: model.Transport{Type: "stdio"}},
+			{RegistryType: model.RegistryTypeNPM, Identifier: "@example/gamma", Transport: model.Transport{Type: "sse", URL: "http://localhost:8080/sse"}},
+		},
+	}, &apiv0.RegistryExtensions{
+		Status:      model.StatusActive,
+		PublishedAt: time.Now().Add(-48 * time.Hour),
+		UpdatedAt:   time.Now(),
+		IsLatest:    true,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		filter   *database.ServerFilter
+		expected []string
+	}{
+		{"no filter", nil, []string{"com.example/alpha:1.0.0", "com.example/alpha:1.1.0", "com.example/gamma:1.0.0", "org.example/beta:1.0.0"}},
+		{"name", &database.ServerFilter{Name: stringPtr("org.example/beta")}, []string{"org.example/beta:1.0.0"}},
+		{"remote URL", &database.ServerFilter{RemoteURL: stringPtr("https://alpha.example.com/mcp")}, []string{"com.example/alpha:1.0.0", "com.example/alpha:1.1.0"}},
+		{"substring is case insensitive", &database.ServerFilter{SubstringName: stringPtr("BET")}, []string{"org.example/beta:1.0.0"}},
+		{"version", &database.ServerFilter{Version: stringPtr("1.1.0")}, []string{"com.example/alpha:1.1.0"}},
+		{"latest", &database.ServerFilter{IsLatest: boolPtr(true)}, []string{"com.example/alpha:1.1.0", "com.example/gamma:1.0.0", "org.example/beta:1.0.0"}},
+		{"updated since", &database.ServerFilter{UpdatedSince: timePtr(time.Now().Add(time.Hour))}, nil},
+		{"registry type", &database.ServerFilter{RegistryType: stringPtr("oci")}, []string{"com.example/gamma:1.0.0"}},
+		{"package identifier", &database.ServerFilter{PackageIdentifier: stringPtr("@example/gamma")}, []string{"com.example/gamma:1.0.0"}},
+		{"unknown package identifier", &database.ServerFilter{PackageIdentifier: stringPtr("@example")}, nil},
+		{"remote transport", &database.ServerFilter{TransportType: stringPtr("streamable-http")}, []string{"com.example/alpha:1.0.0", "com.example/alpha:1.1.0", "org.example/beta:1.0.0"}},
+		{"package transport", &database.ServerFilter{TransportType: stringPtr("sse")}, []string{"com.example/gamma:1.0.0"}},
+		{"status", &database.ServerFilter{Status: stringPtr("deprecated")}, []string{"org.example/beta:1.0.0"}},
+		{"namespace", &database.ServerFilter{Namespace: stringPtr("com.example")}, []string{"com.example/alpha:1.0.0", "com.example/alpha:1.1.0", "com.example/gamma:1.0.0"}},
+		{"namespace is exact", &database.ServerFilter{Namespace: stringPtr("example")}, nil},
+		{"published since", &database.ServerFilter{PublishedSince: timePtr(time.Now().Add(-24 * time.Hour))}, []string{"com.example/alpha:1.0.0", "com.example/alpha:1.1.0", "org.example/beta:1.0.0"}},
+		{"combined", &database.ServerFilter{Namespace: stringPtr("com.example"), IsLatest: boolPtr(true), TransportType: stringPtr("streamable-http")}, []string{"com.example/alpha:1.1.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, _, err := db.ListServers(ctx, nil, tt.filter, "", 10)
+			require.NoError(t, err)
+
+			var got []string
+			for _, result := range results {
+				got = append(got, result.Server.Name+":"+result.Server.Version)
+			}
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func testConformanceCursorPagination(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		createConformanceServer(t, db, nil, fmt.Sprintf("com.example/page-%d", i), "1.0.0", false)
+		createConformanceServer(t, db, nil, fmt.Sprintf("com.example/page-%d", i), "1.0.1", true)
+	}
+
+	var seen []string
+	cursor := ""
+	for {
+		results, nextCursor, err := db.ListServers(ctx, nil, nil, cursor, 3)
+		require.NoError(t, err)
+		for _, result := range results {
+			seen = append(seen, result.Server.Name+":"+result.Server.Version)
+		}
+		if nextCursor == "" {
+			break
+		}
+		assert.Equal(t, seen[len(seen)-1], nextCursor)
+		cursor = nextCursor
+	}
+
+	assert.Len(t, seen, 10)
+	assert.Equal(t, "com.example/page-0:1.0.0", seen[0])
+	assert.Equal(t, "com.example/page-4:1.0.1", seen[9])
+}
+
+func testConformanceUpdateAndStatus(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	createConformanceServer(t, db, nil, "com.example/update", "1.0.0", true)
+
+	updated, err := db.UpdateServer(ctx, nil, "com.example/update", "1.0.0", &apiv0.ServerJSON{
+		Name:        "com.example/update",
+		Description: "Updated description",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Updated description", updated.Server.Description)
+	assert.True(t, updated.Meta.Official.IsLatest)
+
+	_, err = db.UpdateServer(ctx, nil, "com.example/update", "1.0.0", &apiv0.ServerJSON{
+		Name:    "com.example/renamed",
+		Version: "1.0.0",
+	})
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+
+	_, err = db.UpdateServer(ctx, nil, "com.example/missing", "1.0.0", &apiv0.ServerJSON{
+		Name:    "com.example/missing",
+		Version: "1.0.0",
+	})
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	deprecated, err := db.SetServerStatus(ctx, nil, "com.example/update", "1.0.0", string(model.StatusDeprecated), nil)
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusDeprecated, deprecated.Meta.Official.Status)
+	assert.Equal(t, "Updated description", deprecated.Server.Description)
+
+	_, err = db.SetServerStatus(ctx, nil, "com.example/update", "1.0.0", "invalid_status", nil)
+	assert.Error(t, err)
+
+	current, err := db.GetServerByNameAndVersion(ctx, nil, "com.example/update", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusDeprecated, current.Meta.Official.Status)
+}
+
+func testConformanceStatusDetails(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	createConformanceServer(t, db, nil, "com.example/old", "1.0.0", true)
+	createConformanceServer(t, db, nil, "com.example/new", "2.0.0", true)
+
+	details := &database.StatusDetails{
+		Message:    "Moved to com.example/new",
+		ReplacedBy: &apiv0.ReplacedBy{Name: "com.example/new", Version: "2.0.0"},
+	}
+	deprecated, err := db.SetServerStatus(ctx, nil, "com.example/old", "1.0.0", string(model.StatusDeprecated), details)
+	require.NoError(t, err)
+	assert.Equal(t, "Moved to com.example/new", deprecated.Meta.Official.DeprecationMessage)
+	assert.Equal(t, &apiv0.ReplacedBy{Name: "com.example/new", Version: "2.0.0"}, deprecated.Meta.Official.ReplacedBy)
+
+	// Every read returns the details
+	current, err := db.GetServerByNameAndVersion(ctx, nil, "com.example/old", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, deprecated.Meta.Official.DeprecationMessage, current.Meta.Official.DeprecationMessage)
+	assert.Equal(t, deprecated.Meta.Official.ReplacedBy, current.Meta.Official.ReplacedBy)
+
+	name := "com.example/old"
+	listed, _, err := db.ListServers(ctx, nil, &database.ServerFilter{Name: &name}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, deprecated.Meta.Official.ReplacedBy, listed[0].Meta.Official.ReplacedBy)
+
+	changes, err := db.ListServerChanges(ctx, nil, 0, 10)
+	require.NoError(t, err)
+	require.NotEmpty(t, changes)
+	assert.Equal(t, deprecated.Meta.Official.DeprecationMessage, changes[len(changes)-1].Server.Meta.Official.DeprecationMessage)
+
+	// Editing the server.json keeps them
+	updated, err := db.UpdateServer(ctx, nil, "com.example/old", "1.0.0", &apiv0.ServerJSON{
+		Name:        "com.example/old",
+		Description: "Edited",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, deprecated.Meta.Official.ReplacedBy, updated.Meta.Official.ReplacedBy)
+
+	// A replacement without a version means the latest version
+	latest, err := db.SetServerStatus(ctx, nil, "com.example/old", "1.0.0", string(model.StatusDeprecated),
+		&database.StatusDetails{ReplacedBy: &apiv0.ReplacedBy{Name: "com.example/new"}})
+	require.NoError(t, err)
+	assert.Empty(t, latest.Meta.Official.DeprecationMessage)
+	assert.Equal(t, &apiv0.ReplacedBy{Name: "com.example/new"}, latest.Meta.Official.ReplacedBy)
+
+	// Status changes without details clear them
+	active, err := db.SetServerStatus(ctx, nil, "com.example/old", "1.0.0", string(model.StatusActive), nil)
+	require.NoError(t, err)
+	assert.Empty(t, active.Meta.Official.DeprecationMessage)
+	assert.Nil(t, active.Meta.Official.ReplacedBy)
+}
+
+func testConformanceLatestFlag(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	createConformanceServer(t, db, nil, "com.example/latest", "1.0.0", false)
+	createConformanceServer(t, db, nil, "com.example/latest", "2.0.0", true)
+	createConformanceServer(t, db, nil, "org.example/other", "1.0.0", true)
+
+	// Another version cannot become latest while one is
+	err := db.MarkAsLatest(ctx, nil, "com.example/latest", "1.0.0")
+	assert.Error(t, err)
+	assert.ErrorIs(t, db.MarkAsLatest(ctx, nil, "com.example/latest", "9.9.9"), database.ErrNotFound)
+
+	changes, err := db.ListServerChanges(ctx, nil, 0, 100)
+	require.NoError(t, err)
+	since := changes[len(changes)-1].Seq
+	before, err := db.GetServerByNameAndVersion(ctx, nil, "com.example/latest", "1.0.0")
+	require.NoError(t, err)
+
+	require.NoError(t, db.UnmarkAsLatest(ctx, nil, "com.example/latest"))
+	require.NoError(t, db.MarkAsLatest(ctx, nil, "com.example/latest", "1.0.0"))
+
+	// Gaining the flag changes the version, so it counts as an update
+	latest, err := db.GetServerByName(ctx, nil, "com.example/latest")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", latest.Server.Version)
+	assert.True(t, latest.Meta.Official.UpdatedAt.After(before.Meta.Official.UpdatedAt))
+
+	// Both versions whose flag changed are recorded as updated
+	changes, err = db.ListServerChanges(ctx, nil, since, 100)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "2.0.0", changes[0].Version)
+	assert.Equal(t, "1.0.0", changes[1].Version)
+	for _, change := range changes {
+		assert.Equal(t, database.ChangeTypeUpdated, change.ChangeType)
+	}
+
+	names, err := db.ListServerNames(ctx, nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"com.example/latest", "org.example/other"}, names)
+	names, err = db.ListServerNames(ctx, nil, "org.example")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"org.example/other"}, names)
+	names, err = db.ListServerNames(ctx, nil, "net.example")
+	require.NoError(t, err)
+	assert.Empty(t, names)
+}
+
+func testConformanceLatestStableFlag(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	serverName := "com.example/latest-stable"
+	for _, server := range []struct {
+		version        string
+		isLatest       bool
+		isLatestStable bool
+	}{
+		{"1.0.0", false, true},
+		{"2.0.0-beta.1", true, false},
+	} {
+		_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{
+			Name:        serverName,
+			Description: "Conformance test server",
+			Version:     server.version,
+		}, &apiv0.RegistryExtensions{
+			Status:         model.StatusActive,
+			PublishedAt:    time.Now(),
+			UpdatedAt:      time.Now(),
+			IsLatest:       server.isLatest,
+			IsLatestStable: server.isLatestStable,
+		})
+		require.NoError(t, err)
+	}
+
+	isLatestStable := true
+	servers, _, err := db.ListServers(ctx, nil, &database.ServerFilter{IsLatestStable: &isLatestStable}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, servers, 1)
+	assert.Equal(t, "1.0.0", servers[0].Server.Version)
+	assert.True(t, servers[0].Meta.Official.IsLatestStable)
+	assert.False(t, servers[0].Meta.Official.IsLatest)
+
+	// Another version cannot become latest stable while one is
+	assert.Error(t, db.MarkAsLatestStable(ctx, nil, serverName, "2.0.0-beta.1"))
+	assert.ErrorIs(t, db.MarkAsLatestStable(ctx, nil, serverName, "9.9.9"), database.ErrNotFound)
+
+	changes, err := db.ListServerChanges(ctx, nil, 0, 100)
+	require.NoError(t, err)
+	since := changes[len(changes)-1].Seq
+
+	require.NoError(t, db.UnmarkAsLatestStable(ctx, nil, serverName))
+	require.NoError(t, db.MarkAsLatestStable(ctx, nil, serverName, "2.0.0-beta.1"))
+
+	server, err := db.GetServerByNameAndVersion(ctx, nil, serverName, "2.0.0-beta.1")
+	require.NoError(t, err)
+	assert.True(t, server.Meta.Official.IsLatest)
+	assert.True(t, server.Meta.Official.IsLatestStable)
+
+	// Both versions whose flag changed are recorded as updated
+	changes, err = db.ListServerChanges(ctx, nil, since, 100)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "1.0.0", changes[0].Version)
+	assert.False(t, changes[0].Server.Meta.Official.IsLatestStable)
+	assert.Equal(t, "2.0.0-beta.1", changes[1].Version)
+	for _, change := range changes {
+		assert.Equal(t, database.ChangeTypeUpdated, change.ChangeType)
+	}
+}
+
+func testConformanceChannels(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	serverName := "com.example/channels"
+	createConformanceServer(t, db, nil, serverName, "1.0.0", false)
+	createConformanceServer(t, db, nil, serverName, "2.0.0-beta.1", true)
+
+	_, err := db.GetServerChannel(ctx, nil, serverName, "next")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+	_, err = db.SetServerChannel(ctx, nil, &database.ServerChannel{ServerName: serverName, Channel: "next", Version: "9.9.9"})
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	next, err := db.SetServerChannel(ctx, nil, &database.ServerChannel{ServerName: serverName, Channel: "next", Version: "1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", next.Version)
+	assert.False(t, next.UpdatedAt.IsZero())
+
+	// Setting an existing channel moves it
+	_, err = db.SetServerChannel(ctx, nil, &database.ServerChannel{ServerName: serverName, Channel: "next", Version: "2.0.0-beta.1"})
+	require.NoError(t, err)
+	_, err = db.SetServerChannel(ctx, nil, &database.ServerChannel{ServerName: serverName, Channel: "beta", Version: "2.0.0-beta.1"})
+	require.NoError(t, err)
+
+	next, err = db.GetServerChannel(ctx, nil, serverName, "next")
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0-beta.1", next.Version)
+
+	channels, err := db.ListServerChannels(ctx, nil, serverName)
+	require.NoError(t, err)
+	require.Len(t, channels, 2)
+	assert.Equal(t, "beta", channels[0].Channel)
+	assert.Equal(t, "next", channels[1].Channel)
+
+	require.NoError(t, db.DeleteServerChannel(ctx, nil, serverName, "beta"))
+	assert.ErrorIs(t, db.DeleteServerChannel(ctx, nil, serverName, "beta"), database.ErrNotFound)
+	channels, err = db.ListServerChannels(ctx, nil, serverName)
+	require.NoError(t, err)
+	assert.Len(t, channels, 1)
+
+	channels, err = db.ListServerChannels(ctx, nil, "com.example/no-channels")
+	require.NoError(t, err)
+	assert.Empty(t, channels)
+}
+
+func testConformanceTransactions(t *testing.T, db database.Database) {
+	ctx := context.Background()
+
+	err := db.InTransaction(ctx, func(ctx context.Context, tx database.Tx) error {
+		createConformanceServer(t, db, tx, "com.example/committed", "1.0.0", true)
+
+		// Writes are visible inside the transaction
+		exists, err := db.CheckVersionExists(ctx, tx, "com.example/committed", "1.0.0")
+		require.NoError(t, err)
+		assert.True(t, exists)
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = db.InTransaction(ctx, func(ctx context.Context, tx database.Tx) error {
+		createConformanceServer(t, db, tx, "com.example/rolled-back", "1.0.0", true)
+		if _, err := db.SetServerStatus(ctx, tx, "com.example/committed", "1.0.0", string(model.StatusDeleted), nil); err != nil {
+			return err
+		}
+		return assert.AnError
+	})
+	assert.Equal(t, assert.AnError, err)
+
+	_, err = db.GetServerByName(ctx, nil, "com.example/rolled-back")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	committed, err := db.GetServerByName(ctx, nil, "com.example/committed")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusActive, committed.Meta.Official.Status)
+}
+
+func testConformancePublishLock(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	serverName := "com.example/locked"
+
+	// Each transaction reads the current latest and replaces it, which is only safe under the lock
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(version string) {
+			defer wg.Done()
+			errs <- db.InTransaction(ctx, func(ctx context.Context, tx database.Tx) error {
+				if err := db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+					return err
+				}
+				if err := db.UnmarkAsLatest(ctx, tx, serverName); err != nil {
+					return err
+				}
+				time.Sleep(10 * time.Millisecond)
+				_, err := db.CreateServer(ctx, tx, &apiv0.ServerJSON{
+					Name:        serverName,
+					Description: "Locked server",
+					Version:     version,
+				}, &apiv0.RegistryExtensions{
+					Status:      model.StatusActive,
+					PublishedAt: time.Now(),
+					UpdatedAt:   time.Now(),
+					IsLatest:    true,
+				})
+				return err
+			})
+		}(fmt.Sprintf("1.0.%d", i))
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	all, err := db.GetAllVersionsByServerName(ctx, nil, serverName)
+	require.NoError(t, err)
+	assert.Len(t, all, 5)
+
+	latestCount := 0
+	for _, server := range all {
+		if server.Meta.Official.IsLatest {
+			latestCount++
+		}
+	}
+	assert.Equal(t, 1, latestCount)
+}
+
+func testConformanceSearch(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	for _, server := range []apiv0.ServerJSON{
+		{Name: "io.github.example/weather-mcp", Description: "Conformance test server", Version: "1.0.0"},
+		{Name: "com.example/forecasts", Title: "Weather Tools", Description: "Conformance test server", Version: "1.0.0"},
+		{Name: "com.example/climate", Description: "Reports the weather for a city", Version: "1.0.0"},
+		{Name: "com.example/unrelated", Description: "Conformance test server", Version: "1.0.0"},
+	} {
+		_, err := db.CreateServer(ctx, nil, &server, &apiv0.RegistryExtensions{
+			Status:      model.StatusActive,
+			PublishedAt: time.Now(),
+			UpdatedAt:   time.Now(),
+			IsLatest:    true,
+		})
+		require.NoError(t, err)
+	}
+
+	names := func(results []*apiv0.ServerResponse) []string {
+		var names []string
+		for _, result := range results {
+			names = append(names, result.Server.Name)
+		}
+		return names
+	}
+
+	// Name matches outrank title matches, which outrank description matches
+	search := "weather"
+	results, _, err := db.ListServers(ctx, nil, &database.ServerFilter{Search: &search}, "", 10)
+	require.NoError(t, err)
+	expected := []string{"io.github.example/weather-mcp", "com.example/forecasts", "com.example/climate"}
+	assert.Equal(t, expected, names(results))
+
+	// Paging one result at a time follows the ranked order
+	var paged []*apiv0.ServerResponse
+	cursor := ""
+	for range len(expected) + 1 {
+		page, next, err := db.ListServers(ctx, nil, &database.ServerFilter{Search: &search}, cursor, 1)
+		require.NoError(t, err)
+		paged = append(paged, page...)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	assert.Equal(t, expected, names(paged))
+
+	// Misspelled terms fall back to trigram similarity on the name
+	typo := "wether"
+	results, _, err = db.ListServers(ctx, nil, &database.ServerFilter{Search: &typo}, "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"io.github.example/weather-mcp"}, names(results))
+
+	// Substring matches on the name keep working
+	partial := "unrel"
+	results, _, err = db.ListServers(ctx, nil, &database.ServerFilter{Search: &partial}, "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"com.example/unrelated"}, names(results))
+}
+
+func testConformanceReleaseOrder(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	serverName := "com.example/release-order"
+	published := time.Now().Add(-time.Hour)
+	for _, version := range []string{"1.10.0", "2.0.0-alpha", "1.9.0", "1.10.0-rc.1", "nightly"} {
+		published = published.Add(time.Minute)
+		_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{
+			Name:        serverName,
+			Description: "Conformance test server",
+			Version:     version,
+		}, &apiv0.RegistryExtensions{
+			Status:      model.StatusActive,
+			PublishedAt: published,
+			UpdatedAt:   published,
+			IsLatest:    version == "1.10.0",
+		})
+		require.NoError(t, err)
+	}
+	createConformanceServer(t, db, nil, "com.example/release-order-next", "1.0.0", true)
+
+	// Listing pages through versions in release order, then on to the next server
+	var listed []string
+	cursor := ""
+	for range 10 {
+		page, next, err := db.ListServers(ctx, nil, nil, cursor, 2)
+		require.NoError(t, err)
+		for _, server := range page {
+			listed = append(listed, server.Server.Name+"@"+server.Server.Version)
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	assert.Equal(t, []string{
+		serverName + "@nightly",
+		serverName + "@1.9.0",
+		serverName + "@1.10.0-rc.1",
+		serverName + "@1.10.0",
+		serverName + "@2.0.0-alpha",
+		"com.example/release-order-next@1.0.0",
+	}, listed)
+
+	// All versions are returned highest first
+	all, err := db.GetAllVersionsByServerName(ctx, nil, serverName)
+	require.NoError(t, err)
+	var versions []string
+	for _, server := range all {
+		versions = append(versions, server.Server.Version)
+	}
+	assert.Equal(t, []string{"2.0.0-alpha", "1.10.0", "1.10.0-rc.1", "1.9.0", "nightly"}, versions)
+}
+
+func testConformanceSortedListings(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	t0 := time.Now().Add(-time.Hour).Truncate(time.Microsecond)
+	t1, t2, t3 := t0.Add(time.Minute), t0.Add(2*time.Minute), t0.Add(3*time.Minute)
+
+	// Versions share timestamps, so listings sorted by time rely on their tie-breakers
+	for _, row := range []struct {
+		name, version      string
+		published, updated time.Time
+		latest             bool
+	}{
+		{"com.example/sort-a", "1.0.0", t0, t2, false},
+		{"com.example/sort-a", "2.0.0", t1, t1, true},
+		{"com.example/sort-b", "1.0.0", t1, t3, true},
+		{"com.example/sort-c", "1.0.0", t0, t0, true},
+	} {
+		_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{Name: row.name, Description: "Sorted", Version: row.version},
+			&apiv0.RegistryExtensions{Status: model.StatusActive, PublishedAt: row.published, UpdatedAt: row.updated, IsLatest: row.latest})
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		sort     database.ServerSort
+		expected []string
+	}{
+		{database.ServerSort{Field: database.SortByName}, []string{"a@1.0.0", "a@2.0.0", "b@1.0.0", "c@1.0.0"}},
+		{database.ServerSort{Field: database.SortByName, Descending: true}, []string{"c@1.0.0", "b@1.0.0", "a@2.0.0", "a@1.0.0"}},
+		{database.ServerSort{Field: database.SortByPublishedAt}, []string{"a@1.0.0", "c@1.0.0", "a@2.0.0", "b@1.0.0"}},
+		{database.ServerSort{Field: database.SortByPublishedAt, Descending: true}, []string{"b@1.0.0", "a@2.0.0", "c@1.0.0", "a@1.0.0"}},
+		{database.ServerSort{Field: database.SortByUpdatedAt}, []string{"c@1.0.0", "a@2.0.0", "a@1.0.0", "b@1.0.0"}},
+		{database.ServerSort{Field: database.SortByUpdatedAt, Descending: true}, []string{"b@1.0.0", "a@1.0.0", "a@2.0.0", "c@1.0.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort.String(), func(t *testing.T) {
+			filter := &database.ServerFilter{Sort: &tt.sort}
+
+			// Page through a version at a time
+			var listed []string
+			cursor := ""
+			for range 10 {
+				page, next, err := db.ListServers(ctx, nil, filter, cursor, 1)
+				require.NoError(t, err)
+				for _, server := range page {
+					listed = append(listed, strings.TrimPrefix(server.Server.Name, "com.example/sort-")+"@"+server.Server.Version)
+				}
+				if next == "" {
+					break
+				}
+				cursor = next
+			}
+			assert.Equal(t, tt.expected, listed)
+		})
+	}
+
+	// Cursors of listings sorted by time must carry the time
+	_, _, err := db.ListServers(ctx, nil, &database.ServerFilter{Sort: &database.ServerSort{Field: database.SortByUpdatedAt}}, "com.example/sort-a:1.0.0", 1)
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+
+	// Cursors must match the order of the listing
+	_, _, err = db.ListServers(ctx, nil, nil, "com.example/sort-a", 1)
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+	search := "sort"
+	_, _, err = db.ListServers(ctx, nil, &database.ServerFilter{Search: &search}, "com.example/sort-a:1.0.0", 1)
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+}
+
+func testConformanceAuditEvents(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	start := time.Now().Add(-time.Minute)
+
+	for i, actor := range []string{"alice", "bob", "alice"} {
+		event := &database.AuditEvent{
+			Action:       "publish",
+			ServerName:   fmt.Sprintf("com.example/audited-%d", i%2),
+			Version:      "1.0.0",
+			ActorMethod:  "github-at",
+			ActorSubject: actor,
+			SourceIP:     "203.0.113.7",
+			After:        []byte(`{"version": "1.0.0"}`),
+		}
+		require.NoError(t, db.CreateAuditEvent(ctx, nil, event))
+		assert.NotZero(t, event.ID)
+		assert.False(t, event.OccurredAt.IsZero())
+	}
+
+	// Events written in a rolled back transaction are discarded with it
+	err := db.InTransaction(ctx, func(ctx context.Context, tx database.Tx) error {
+		require.NoError(t, db.CreateAuditEvent(ctx, tx, &database.AuditEvent{
+			Action: "edit", ServerName: "com.example/audited-0", Version: "1.0.0", ActorMethod: "system",
+		}))
+		return fmt.Errorf("abort")
+	})
+	require.Error(t, err)
+
+	// Newest first, paginated by ID
+	first, cursor, err := db.ListAuditEvents(ctx, nil, nil, "", 2)
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+	assert.Greater(t, first[0].ID, first[1].ID)
+	assert.Equal(t, "alice", first[0].ActorSubject)
+	assert.JSONEq(t, `{"version": "1.0.0"}`, string(first[0].After))
+	assert.Empty(t, first[0].Before)
+	require.NotEmpty(t, cursor)
+
+	rest, cursor, err := db.ListAuditEvents(ctx, nil, nil, cursor, 2)
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	assert.Empty(t, cursor)
+	assert.Equal(t, "com.example/audited-0", rest[0].ServerName)
+
+	// Filters
+	actor := "alice"
+	serverName := "com.example/audited-0"
+	events, _, err := db.ListAuditEvents(ctx, nil, &database.AuditEventFilter{ActorSubject: &actor, ServerName: &serverName}, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	future := time.Now().Add(time.Hour)
+	events, _, err = db.ListAuditEvents(ctx, nil, &database.AuditEventFilter{Since: &start, Until: &future}, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, events, 3)
+	events, _, err = db.ListAuditEvents(ctx, nil, &database.AuditEventFilter{Since: &future}, "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	_, _, err = db.ListAuditEvents(ctx, nil, nil, "not-a-cursor", 10)
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+
+	// Edits keep both snapshots and the diff between them
+	require.NoError(t, db.CreateAuditEvent(ctx, nil, &database.AuditEvent{
+		Action: "edit", ServerName: "com.example/audited-1", Version: "1.0.0", ActorMethod: "system",
+		Before: []byte(`{"version": "1.0.0", "status": "active"}`),
+		After:  []byte(`{"version": "1.0.0", "status": "deleted"}`),
+		Diff:   []byte(`[{"op": "replace", "path": "/status", "value": "deleted"}]`),
+	}))
+	edits, _, err := db.ListAuditEvents(ctx, nil, nil, "", 1)
+	require.NoError(t, err)
+	require.Len(t, edits, 1)
+	assert.JSONEq(t, `{"version": "1.0.0", "status": "active"}`, string(edits[0].Before))
+	assert.JSONEq(t, `[{"op": "replace", "path": "/status", "value": "deleted"}]`, string(edits[0].Diff))
+}
+
+func testConformanceWebhooks(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	create := func(url string, events ...string) *database.Webhook {
+		t.Helper()
+		webhook := &database.Webhook{URL: url, Secret: "secret", Events: events, Active: true}
+		require.NoError(t, db.CreateWebhook(ctx, nil, webhook))
+		require.NotZero(t, webhook.ID)
+		return webhook
+	}
+	published := create("https://a.example.com/hook", "server.published")
+	all := create("https://b.example.com/hook", "server.published", "server.updated")
+	inactive := create("https://c.example.com/hook", "server.published")
+	inactive.Active = false
+	require.NoError(t, db.UpdateWebhook(ctx, nil, inactive))
+
+	webhooks, err := db.ListWebhooks(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, webhooks, 3)
+	assert.Equal(t, published.ID, webhooks[0].ID)
+	assert.Equal(t, []string{"server.published", "server.updated"}, webhooks[1].Events)
+	assert.False(t, webhooks[2].Active)
+
+	// Deliveries are only enqueued for active webhooks subscribed to the event
+	payload := json.RawMessage(`{"event":"server.published"}`)
+	require.NoError(t, db.EnqueueWebhookDeliveries(ctx, nil, "server.published", "com.example/hooked", "1.0.0", payload))
+	require.NoError(t, db.EnqueueWebhookDeliveries(ctx, nil, "server.updated", "com.example/hooked", "1.0.0", payload))
+
+	claimed, err := db.ClaimWebhookDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 3)
+	for _, delivery := range claimed {
+		assert.NotEqual(t, inactive.ID, delivery.WebhookID)
+		assert.Equal(t, database.WebhookDeliveryPending, delivery.Status)
+		assert.Equal(t, "com.example/hooked", delivery.ServerName)
+		assert.JSONEq(t, string(payload), string(delivery.Payload))
+	}
+
+	// Claimed deliveries are leased and not handed out again
+	again, err := db.ClaimWebhookDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+
+	// Recording a failed attempt that is due again makes it claimable
+	now := time.Now()
+	failed := claimed[0]
+	failed.Attempts = 1
+	failed.LastAttemptAt = &now
+	failed.ResponseStatus = 500
+	failed.LastError = "endpoint responded with status 500"
+	failed.NextAttemptAt = now.Add(-time.Second)
+	require.NoError(t, db.UpdateWebhookDelivery(ctx, nil, failed))
+
+	again, err = db.ClaimWebhookDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, again, 1)
+	assert.Equal(t, failed.ID, again[0].ID)
+	assert.Equal(t, 1, again[0].Attempts)
+	assert.Equal(t, 500, again[0].ResponseStatus)
+
+	succeeded := claimed[1]
+	succeeded.Status = database.WebhookDeliverySucceeded
+	succeeded.Attempts = 1
+	require.NoError(t, db.UpdateWebhookDelivery(ctx, nil, succeeded))
+	stored, err := db.GetWebhookDelivery(ctx, nil, succeeded.ID)
+	require.NoError(t, err)
+	assert.Equal(t, database.WebhookDeliverySucceeded, stored.Status)
+
+	_, err = db.GetWebhookDelivery(ctx, nil, 999999)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	// The delivery log is newest first and paginated
+	deliveries, cursor, err := db.ListWebhookDeliveries(ctx, nil, all.ID, "", 1)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "server.updated", deliveries[0].EventType)
+	require.NotEmpty(t, cursor)
+	deliveries, _, err = db.ListWebhookDeliveries(ctx, nil, all.ID, cursor, 1)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "server.published", deliveries[0].EventType)
+
+	// Deleting a webhook deletes its deliveries
+	require.NoError(t, db.DeleteWebhook(ctx, nil, all.ID))
+	_, err = db.GetWebhook(ctx, nil, all.ID)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+	deliveries, _, err = db.ListWebhookDeliveries(ctx, nil, all.ID, "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+	assert.ErrorIs(t, db.DeleteWebhook(ctx, nil, all.ID), database.ErrNotFound)
+}
+
+func testConformanceDrafts(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	serverName := "com.example/drafts"
+	createConformanceServer(t, db, nil, serverName, "1.0.0", true, "https://drafts.example.com/mcp")
+	draftPublishedAt := time.Now().Add(-time.Hour)
+	_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{
+		Name:        serverName,
+		Description: "Draft",
+		Version:     "2.0.0",
+		Remotes:     []model.Transport{{Type: "streamable-http", URL: "https://drafts.example.com/mcp"}},
+	}, &apiv0.RegistryExtensions{
+		Status:      model.StatusDraft,
+		PublishedAt: draftPublishedAt,
+		UpdatedAt:   draftPublishedAt,
+	})
+	require.NoError(t, err)
+
+	versions := func(filter *database.ServerFilter) []string {
+		t.Helper()
+		results, _, err := db.ListServers(ctx, nil, filter, "", 10)
+		require.NoError(t, err)
+		var versions []string
+		for _, server := range results {
+			versions = append(versions, server.Server.Version)
+		}
+		return versions
+	}
+
+	// Drafts are left out of listings, the change feed and exports unless asked for
+	assert.Equal(t, []string{"1.0.0"}, versions(nil))
+	assert.Equal(t, []string{"1.0.0"}, versions(&database.ServerFilter{Name: &serverName}))
+	assert.Equal(t, []string{"1.0.0", "2.0.0"}, versions(&database.ServerFilter{RemoteURL: stringPtr("https://drafts.example.com/mcp"), IncludeDrafts: true}))
+
+	changes, err := db.ListServerChanges(ctx, nil, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "1.0.0", changes[0].Version)
+
+	var exported []string
+	require.NoError(t, db.ExportServers(ctx, func(export *database.ServerExport) error {
+		for server, err := range export.Servers {
+			if err != nil {
+				return err
+			}
+			exported = append(exported, server.Server.Version)
+		}
+		return nil
+	}))
+	assert.Equal(t, []string{"1.0.0"}, exported)
+
+	// Drafts can still be read directly
+	draft, err := db.GetServerByNameAndVersion(ctx, nil, serverName, "2.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusDraft, draft.Meta.Official.Status)
+	assert.False(t, draft.Meta.Official.IsLatest)
+
+	// Promotion publishes the draft as of now and adds it to the change feed as created
+	_, err = db.PromoteServer(ctx, nil, serverName, "1.0.0", false, false)
+	assert.ErrorIs(t, err, database.ErrNotFound, "only drafts can be promoted")
+	err = db.InTransaction(ctx, func(ctx context.Context, tx database.Tx) error {
+		if err := db.UnmarkAsLatest(ctx, tx, serverName); err != nil {
+			return err
+		}
+		promoted, err := db.PromoteServer(ctx, tx, serverName, "2.0.0", true, false)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, model.StatusActive, promoted.Meta.Official.Status)
+		assert.True(t, promoted.Meta.Official.IsLatest)
+		assert.True(t, promoted.Meta.Official.PublishedAt.After(draftPublishedAt))
+		assert.Equal(t, "Draft", promoted.Server.Description)
+		return nil
+	})
+	require.NoError(t, err)
+
+	latest, err := db.GetServerByName(ctx, nil, serverName)
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", latest.Server.Version)
+	assert.Equal(t, []string{"1.0.0", "2.0.0"}, versions(nil))
+
+	changes, err = db.ListServerChanges(ctx, nil, changes[0].Seq, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, database.ChangeTypeUpdated, changes[0].ChangeType)
+	assert.Equal(t, "1.0.0", changes[0].Version)
+	assert.Equal(t, database.ChangeTypeCreated, changes[1].ChangeType)
+	assert.Equal(t, "2.0.0", changes[1].Version)
+}
+
+func testConformanceChangeFeed(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	serverName := "com.example/changes"
+
+	// Publish 1.0.0, then publish 2.0.0 as the new latest
+	createConformanceServer(t, db, nil, serverName, "1.0.0", true)
+	err := db.InTransaction(ctx, func(ctx context.Context, tx database.Tx) error {
+		if err := db.UnmarkAsLatest(ctx, tx, serverName); err != nil {
+			return err
+		}
+		createConformanceServer(t, db, tx, serverName, "2.0.0", true)
+		return nil
+	})
+	require.NoError(t, err)
+
+	_, err = db.UpdateServer(ctx, nil, serverName, "2.0.0", &apiv0.ServerJSON{
+		Name:        serverName,
+		Description: "Edited",
+		Version:     "2.0.0",
+	})
+	require.NoError(t, err)
+	_, err = db.SetServerStatus(ctx, nil, serverName, "1.0.0", string(model.StatusDeprecated), nil)
+	require.NoError(t, err)
+
+	// Changes in a rolled back transaction never appear
+	err = db.InTransaction(ctx, func(ctx context.Context, tx database.Tx) error {
+		createConformanceServer(t, db, tx, serverName, "3.0.0", false)
+		return fmt.Errorf("abort")
+	})
+	require.Error(t, err)
+
+	changes, err := db.ListServerChanges(ctx, nil, 0, 100)
+	require.NoError(t, err)
+
+	type entry struct{ changeType, version, status string }
+	var entries []entry
+	for i, change := range changes {
+		if i > 0 {
+			assert.Greater(t, change.Seq, changes[i-1].Seq)
+		}
+		assert.Equal(t, serverName, change.ServerName)
+		assert.False(t, change.OccurredAt.IsZero())
+		require.NotNil(t, change.Server)
+		assert.Equal(t, change.Version, change.Server.Server.Version)
+		entries = append(entries, entry{change.ChangeType, change.Version, change.Status})
+	}
+	assert.Equal(t, []entry{
+		{database.ChangeTypeCreated, "1.0.0", "active"},
+		{database.ChangeTypeUpdated, "1.0.0", "active"}, // lost its latest flag
+		{database.ChangeTypeCreated, "2.0.0", "active"},
+		{database.ChangeTypeUpdated, "2.0.0", "active"},
+		{database.ChangeTypeStatusChanged, "1.0.0", "deprecated"},
+	}, entries)
+
+	// Entries carry the current state of the version
+	assert.Equal(t, model.StatusDeprecated, changes[0].Server.Meta.Official.Status)
+	assert.False(t, changes[0].Server.Meta.Official.IsLatest)
+	assert.Equal(t, "Edited", changes[2].Server.Server.Description)
+
+	// Resuming from a sequence number returns only later changes
+	page, err := db.ListServerChanges(ctx, nil, changes[1].Seq, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, changes[2].Seq, page[0].Seq)
+	assert.Equal(t, changes[3].Seq, page[1].Seq)
+
+	page, err = db.ListServerChanges(ctx, nil, changes[len(changes)-1].Seq, 10)
+	require.NoError(t, err)
+	assert.Empty(t, page)
+
+	// Concurrent publishes each appear exactly once
+	var wg sync.WaitGroup
+	for i := range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := fmt.Sprintf("com.example/changes-concurrent-%d", i)
+			assert.NoError(t, db.InTransaction(ctx, func(ctx context.Context, tx database.Tx) error {
+				_, err := db.CreateServer(ctx, tx, &apiv0.ServerJSON{Name: name, Description: "Concurrent", Version: "1.0.0"},
+					&apiv0.RegistryExtensions{Status: model.StatusActive, PublishedAt: time.Now(), UpdatedAt: time.Now(), IsLatest: true})
+				return err
+			}))
+		}()
+	}
+	wg.Wait()
+
+	page, err = db.ListServerChanges(ctx, nil, changes[len(changes)-1].Seq, 100)
+	require.NoError(t, err)
+	assert.Len(t, page, 5)
+}
+
+func testConformanceExport(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	createConformanceServer(t, db, nil, "com.example/export-b", "1.0.0", true)
+	createConformanceServer(t, db, nil, "com.example/export-a", "2.0.0", true)
+	createConformanceServer(t, db, nil, "com.example/export-a", "1.0.0", false)
+
+	changes, err := db.ListServerChanges(ctx, nil, 0, 100)
+	require.NoError(t, err)
+	require.NotEmpty(t, changes)
+	lastSeq := changes[len(changes)-1].Seq
+
+	type entry struct{ name, version string }
+	var entries []entry
+	err = db.ExportServers(ctx, func(export *database.ServerExport) error {
+		assert.Equal(t, lastSeq, export.Seq)
+		assert.False(t, export.SnapshotAt.IsZero())
+
+		// Changes made while the export is running are not part of its snapshot
+		createConformanceServer(t, db, nil, "com.example/export-c", "1.0.0", true)
+
+		for server, err := range export.Servers {
+			require.NoError(t, err)
+			require.NotNil(t, server.Meta.Official)
+			entries = append(entries, entry{server.Server.Name, server.Server.Version})
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []entry{
+		{"com.example/export-a", "1.0.0"},
+		{"com.example/export-a", "2.0.0"},
+		{"com.example/export-b", "1.0.0"},
+	}, entries)
+
+	// The next export includes the change, and its position continues the change feed
+	err = db.ExportServers(ctx, func(export *database.ServerExport) error {
+		changes, err := db.ListServerChanges(ctx, nil, lastSeq, 100)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, changes[0].Seq, export.Seq)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Errors from fn are returned
+	err = db.ExportServers(ctx, func(_ *database.ServerExport) error {
+		return fmt.Errorf("abort")
+	})
+	assert.EqualError(t, err, "abort")
+}
+
+func testConformanceServerRevisions(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	serverName := "com.example/revisions"
+	createConformanceServer(t, db, nil, serverName, "1.0.0", true)
+
+	edit := func(description string) {
+		_, err := db.UpdateServer(ctx, nil, serverName, "1.0.0", &apiv0.ServerJSON{
+			Name:        serverName,
+			Description: description,
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+	edit("First edit")
+	edit("First edit") // unchanged content does not add a revision
+	edit("Second edit")
+
+	// Status changes do not touch the server.json
+	_, err := db.SetServerStatus(ctx, nil, serverName, "1.0.0", string(model.StatusDeprecated), nil)
+	require.NoError(t, err)
+
+	revisions, err := db.ListServerRevisions(ctx, nil, serverName, "1.0.0")
+	require.NoError(t, err)
+	require.Len(t, revisions, 3)
+	for i, revision := range revisions {
+		assert.Equal(t, i+1, revision.Revision)
+		assert.Equal(t, serverName, revision.ServerName)
+		assert.Equal(t, "1.0.0", revision.Version)
+		assert.False(t, revision.CreatedAt.IsZero())
+	}
+	assert.Equal(t, "Conformance test server", revisions[0].Server.Description)
+	assert.Equal(t, "First edit", revisions[1].Server.Description)
+	assert.Equal(t, "Second edit", revisions[2].Server.Description)
+
+	original, err := db.GetServerRevision(ctx, nil, serverName, "1.0.0", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Conformance test server", original.Server.Description)
+
+	// Revisions written in a rolled back transaction are discarded with it
+	err = db.InTransaction(ctx, func(ctx context.Context, tx database.Tx) error {
+		_, err := db.UpdateServer(ctx, tx, serverName, "1.0.0", &apiv0.ServerJSON{
+			Name:        serverName,
+			Description: "Rolled back",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+		return fmt.Errorf("abort")
+	})
+	require.Error(t, err)
+	_, err = db.GetServerRevision(ctx, nil, serverName, "1.0.0", 4)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	_, err = db.GetServerRevision(ctx, nil, serverName, "1.0.0", 0)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+	_, err = db.ListServerRevisions(ctx, nil, serverName, "2.0.0")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func testConformancePackageValidations(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	serverName := "com.example/validated"
+	createConformanceServer(t, db, nil, serverName, "1.0.0", true)
+
+	validations := []*database.PackageValidation{
+		{
+			ServerName: serverName, Version: "1.0.0", PackageIndex: 0,
+			RegistryType: "npm", Identifier: "@example/validated",
+			Check: "npm-mcp-name", Outcome: "passed",
+			Facts: map[string]string{"version": "1.0.0", "integrity": "sha512-abc"},
+		},
+		{
+			ServerName: serverName, Version: "1.0.0", PackageIndex: 1,
+			RegistryType: "oci", Identifier: "docker.io/example/validated:1.0.0",
+			Check: "oci-label", Outcome: "skipped",
+		},
+	}
+	require.NoError(t, db.CreatePackageValidations(ctx, nil, validations))
+	assert.NotZero(t, validations[0].ID)
+	assert.Greater(t, validations[1].ID, validations[0].ID)
+	assert.False(t, validations[0].ValidatedAt.IsZero())
+
+	stored, err := db.ListPackageValidations(ctx, nil, serverName, "1.0.0")
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	assert.Equal(t, validations[0].ID, stored[0].ID)
+	assert.Equal(t, "npm-mcp-name", stored[0].Check)
+	assert.Equal(t, "passed", stored[0].Outcome)
+	assert.Equal(t, map[string]string{"version": "1.0.0", "integrity": "sha512-abc"}, stored[0].Facts)
+	assert.Equal(t, 1, stored[1].PackageIndex)
+	assert.Equal(t, "skipped", stored[1].Outcome)
+	assert.Empty(t, stored[1].Facts)
+
+	// Records of an unknown server version or with an unknown outcome are rejected
+	err = db.CreatePackageValidations(ctx, nil, []*database.PackageValidation{
+		{ServerName: serverName, Version: "2.0.0", Check: "npm-mcp-name", Outcome: "passed"},
+	})
+	assert.ErrorIs(t, err, database.ErrNotFound)
+	err = db.CreatePackageValidations(ctx, nil, []*database.PackageValidation{
+		{ServerName: serverName, Version: "1.0.0", Check: "npm-mcp-name", Outcome: "failed"},
+	})
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+
+	// Records written in a rolled back transaction are discarded with it
+	err = db.InTransaction(ctx, func(ctx context.Context, tx database.Tx) error {
+		require.NoError(t, db.CreatePackageValidations(ctx, tx, []*database.PackageValidation{
+			{ServerName: serverName, Version: "1.0.0", Check: "npm-mcp-name", Outcome: "passed"},
+		}))
+		return fmt.Errorf("abort")
+	})
+	require.Error(t, err)
+	stored, err = db.ListPackageValidations(ctx, nil, serverName, "1.0.0")
+	require.NoError(t, err)
+	assert.Len(t, stored, 2)
+
+	stored, err = db.ListPackageValidations(ctx, nil, serverName, "2.0.0")
+	require.NoError(t, err)
+	assert.Empty(t, stored)
+}
+
+func testConformanceNamespaces(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	verifiedAt := time.Now().Add(-time.Minute)
+	claim := func(namespace, ownerID string) *database.Namespace {
+		t.Helper()
+		record, err := db.ClaimNamespace(ctx, nil, &database.Namespace{
+			Namespace:          namespace,
+			OwnerType:          "github",
+			OwnerID:            ownerID,
+			OwnerName:          "owner-" + ownerID,
+			VerificationMethod: "github-at",
+			VerifiedAt:         verifiedAt,
+		})
+		require.NoError(t, err)
+		return record
+	}
+
+	_, err := db.GetNamespace(ctx, nil, "io.github.first")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	first := claim("io.github.first", "1")
+	assert.Equal(t, "1", first.OwnerID)
+	assert.Equal(t, "owner-1", first.OwnerName)
+	assert.WithinDuration(t, verifiedAt, first.VerifiedAt, time.Millisecond)
+	assert.False(t, first.CreatedAt.IsZero())
+
+	// A later claim returns the earlier claimant's record
+	assert.Equal(t, "1", claim("io.github.first", "2").OwnerID)
+	stored, err := db.GetNamespace(ctx, nil, "io.github.first")
+	require.NoError(t, err)
+	assert.Equal(t, "1", stored.OwnerID)
+
+	claim("io.github.second", "2")
+	claim("io.github.third", "2")
+
+	// Transfers replace the owner but keep the creation time
+	transferred, err := db.SetNamespaceOwner(ctx, nil, &database.Namespace{
+		Namespace:          "io.github.first",
+		OwnerType:          "github",
+		OwnerID:            "2",
+		VerificationMethod: "admin",
+		VerifiedAt:         time.Now(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "2", transferred.OwnerID)
+	assert.Equal(t, "admin", transferred.VerificationMethod)
+	assert.WithinDuration(t, first.CreatedAt, transferred.CreatedAt, time.Millisecond)
+
+	_, err = db.SetNamespaceOwner(ctx, nil, &database.Namespace{
+		Namespace:          "io.github.first",
+		OwnerType:          "gitlab",
+		OwnerID:            "2",
+		VerificationMethod: "admin",
+		VerifiedAt:         time.Now(),
+	})
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+
+	// Pagination in namespace order, with filtering
+	page, cursor, err := db.ListNamespaces(ctx, nil, nil, "", 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, "io.github.first", page[0].Namespace)
+	assert.Equal(t, "io.github.second", page[1].Namespace)
+	page, _, err = db.ListNamespaces(ctx, nil, nil, cursor, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "io.github.third", page[0].Namespace)
+
+	ownerID := "1"
+	page, _, err = db.ListNamespaces(ctx, nil, &database.NamespaceFilter{OwnerID: &ownerID}, "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, page)
+}
+
+func testConformancePublishLimits(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	record := func(subject, serverName string, newServer bool) {
+		t.Helper()
+		namespace, _, _ := strings.Cut(serverName, "/")
+		event := &database.PublishEvent{ActorSubject: subject, Namespace: namespace, ServerName: serverName, NewServer: newServer}
+		require.NoError(t, db.RecordPublishEvent(ctx, nil, event))
+		assert.NotZero(t, event.ID)
+		assert.False(t, event.OccurredAt.IsZero())
+	}
+	start := time.Now().Add(-time.Minute)
+	record("alice", "com.example/one", true)
+	record("alice", "com.example/one", false)
+	record("bob", "com.example/two", true)
+	record("alice", "org.example/three", true)
+
+	count := func(filter *database.PublishEventFilter, limit int) int {
+		t.Helper()
+		times, err := db.ListPublishEventTimes(ctx, nil, filter, limit)
+		require.NoError(t, err)
+		for i := 1; i < len(times); i++ {
+			assert.False(t, times[i].After(times[i-1]), "times are newest first")
+		}
+		return len(times)
+	}
+	alice, namespace := "alice", "com.example"
+	assert.Equal(t, 4, count(nil, 10))
+	assert.Equal(t, 2, count(nil, 2))
+	assert.Equal(t, 3, count(&database.PublishEventFilter{ActorSubject: &alice}, 10))
+	assert.Equal(t, 2, count(&database.PublishEventFilter{ActorSubject: &alice, NewServer: true}, 10))
+	assert.Equal(t, 3, count(&database.PublishEventFilter{Namespace: &namespace}, 10))
+	assert.Equal(t, 1, count(&database.PublishEventFilter{Namespace: &namespace, ActorSubject: &alice, NewServer: true}, 10))
+	assert.Equal(t, 4, count(&database.PublishEventFilter{Since: start}, 10))
+	assert.Equal(t, 0, count(&database.PublishEventFilter{Since: time.Now().Add(time.Minute)}, 10))
+
+	require.NoError(t, db.DeletePublishEventsBefore(ctx, nil, start))
+	assert.Equal(t, 4, count(nil, 10))
+	require.NoError(t, db.DeletePublishEventsBefore(ctx, nil, time.Now().Add(time.Minute)))
+	assert.Equal(t, 0, count(nil, 10))
+
+	// Locks held while counting, of a namespace and an actor or of a namespace alone
+	err := db.InTransaction(ctx, func(ctx context.Context, tx database.Tx) error {
+		if err := db.AcquirePublishLimitLocks(ctx, tx, "com.example", "alice"); err != nil {
+			return err
+		}
+		return db.AcquirePublishLimitLocks(ctx, tx, "org.example", "")
+	})
+	require.NoError(t, err)
+	assert.ErrorIs(t, db.AcquirePublishLimitLocks(ctx, nil, "", "alice"), database.ErrInvalidInput)
+
+	// Limits set for namespaces
+	_, err = db.GetPublishLimits(ctx, nil, "com.example")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+	_, err = db.SetPublishLimits(ctx, nil, &database.PublishLimits{Namespace: "com.example", PublishesPerHour: -1})
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+
+	set, err := db.SetPublishLimits(ctx, nil, &database.PublishLimits{Namespace: "org.example", PublishesPerHour: 10, NewServersPerDay: 2})
+	require.NoError(t, err)
+	assert.False(t, set.UpdatedAt.IsZero())
+	_, err = db.SetPublishLimits(ctx, nil, &database.PublishLimits{Namespace: "com.example", PublishesPerHour: 500, NewServersPerDay: 50})
+	require.NoError(t, err)
+	replaced, err := db.SetPublishLimits(ctx, nil, &database.PublishLimits{Namespace: "com.example", PublishesPerHour: 1000, NewServersPerDay: 0})
+	require.NoError(t, err)
+	assert.Equal(t, 1000, replaced.PublishesPerHour)
+	assert.Equal(t, 0, replaced.NewServersPerDay)
+
+	stored, err := db.GetPublishLimits(ctx, nil, "com.example")
+	require.NoError(t, err)
+	assert.Equal(t, 1000, stored.PublishesPerHour)
+
+	all, err := db.ListPublishLimits(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "com.example", all[0].Namespace)
+	assert.Equal(t, "org.example", all[1].Namespace)
+
+	require.NoError(t, db.DeletePublishLimits(ctx, nil, "com.example"))
+	assert.ErrorIs(t, db.DeletePublishLimits(ctx, nil, "com.example"), database.ErrNotFound)
+	_, err = db.GetPublishLimits(ctx, nil, "com.example")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+}
+
+func testConformanceServerRemotes(t *testing.T, db database.Database) {
+	ctx := context.Background()
+	claim := func(serverName string, urls ...string) []*database.RemoteURLOwner {
+		t.Helper()
+		conflicts, err := db.ClaimRemoteURLs(ctx, nil, serverName, urls)
+		require.NoError(t, err)
+		return conflicts
+	}
+
+	assert.Empty(t, claim("com.example/alpha", "https://alpha.example.com/mcp", "https://shared.example.com/sse"))
+	assert.Empty(t, claim("com.example/alpha", "https://alpha.example.com/mcp"), "claiming again is a no-op")
+
+	// URLs differing only in the case of the host or trailing slashes are the same URL
+	conflicts := claim("com.example/beta", "https://beta.example.com/mcp", "HTTPS://Alpha.Example.com/mcp/")
+	assert.Equal(t, []*database.RemoteURLOwner{{URL: "HTTPS://Alpha.Example.com/mcp/", ServerName: "com.example/alpha"}}, conflicts)
+	assert.Empty(t, claim("com.example/beta", "https://alpha.example.com/MCP"), "paths are case sensitive")
+
+	owners, err := db.ListRemoteURLOwners(ctx, nil, []string{"https://unknown.example.com", "https://shared.example.com/sse/", "https://beta.example.com/mcp"})
+	require.NoError(t, err)
+	assert.Equal(t, []*database.RemoteURLOwner{
+		{URL: "https://shared.example.com/sse/", ServerName: "com.example/alpha"},
+		{URL: "https://beta.example.com/mcp", ServerName: "com.example/beta"},
+	}, owners)
+
+	_, err = db.ClaimRemoteURLs(ctx, nil, "", []string{"https://empty.example.com"})
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+
+	// Released URLs can be claimed by other servers
+	require.NoError(t, db.ReleaseRemoteURLs(ctx, nil, "com.example/alpha", []string{"https://ALPHA.example.com/mcp"}))
+	assert.Empty(t, claim("com.example/beta", "https://shared.example.com/sse"))
+	assert.Len(t, claim("com.example/beta", "https://alpha.example.com/mcp"), 1)
+	require.NoError(t, db.ReleaseRemoteURLs(ctx, nil, "com.example/alpha", nil))
+	assert.Empty(t, claim("com.example/beta", "https://alpha.example.com/mcp"))
+
+	// Claims made in a rolled back transaction are discarded
+	err = db.InTransaction(ctx, func(ctx context.Context, tx database.Tx) error {
+		conflicts, err := db.ClaimRemoteURLs(ctx, tx, "com.example/gamma", []string{"https://gamma.example.com/mcp"})
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+		return assert.AnError
+	})
+	assert.Equal(t, assert.AnError, err)
+	assert.Empty(t, claim("com.example/delta", "https://gamma.example.com/mcp"))
+
+	// Of concurrent claims of the same URL by different servers, exactly one wins
+	var wg sync.WaitGroup
+	won := make(chan string, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(serverName string) {
+			defer wg.Done()
+			err := db.InTransaction(ctx, func(ctx context.Context, tx database.Tx) error {
+				conflicts, err := db.ClaimRemoteURLs(ctx, tx, serverName, []string{"https://contested.example.com/mcp"})
+				if err != nil {
+					return err
+				}
+				if len(conflicts) == 0 {
+					won <- serverName
+				}
+				return nil
+			})
+			assert.NoError(t, err)
+		}(fmt.Sprintf("com.example/racer-%d", i))
+	}
+	wg.Wait()
+	close(won)
+
+	var winners []string
+	for serverName := range won {
+		winners = append(winners, serverName)
+	}
+	require.Len(t, winners, 1)
+	owners, err = db.ListRemoteURLOwners(ctx, nil, []string{"https://contested.example.com/mcp"})
+	require.NoError(t, err)
+	require.Len(t, owners, 1)
+	assert.Equal(t, winners[0], owners[0].ServerName)
+}
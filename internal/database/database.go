@@ -2,10 +2,11 @@ package database
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"iter"
 	"time"
 
-	"github.com/jackc/pgx/v5"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
 
@@ -19,45 +20,342 @@ var (
 	ErrMaxServersReached = errors.New("maximum number of versions for this server reached (10000): please reach out at https://github.com/modelcontextprotocol/registry to explain your use case")
 )
 
+// Tx is a handle to an in-flight transaction, as passed to the function given to InTransaction.
+// Each backend supplies its own implementation (pgx.Tx for PostgreSQL). Passing a nil Tx to a
+// Database method runs that operation outside of any transaction.
+type Tx interface {
+	Commit(ctx context.Context) error
+	Rollback(ctx context.Context) error
+}
+
 // ServerFilter defines filtering options for server queries
 type ServerFilter struct {
-	Name          *string    // for finding versions of same server
-	RemoteURL     *string    // for duplicate URL detection
-	UpdatedSince  *time.Time // for incremental sync filtering
-	SubstringName *string    // for substring search on name
-	Version       *string    // for exact version matching
-	IsLatest      *bool      // for filtering latest versions only
+	Name           *string    // for finding versions of same server
+	RemoteURL      *string    // for servers with a remote at exactly this URL
+	UpdatedSince   *time.Time // for incremental sync filtering
+	SubstringName  *string    // for substring search on name
+	Search         *string    // for relevance-ranked full-text search; results are ordered by rank
+	Version        *string    // for exact version matching
+	IsLatest       *bool      // for filtering latest versions only
+	IsLatestStable *bool      // for filtering latest stable versions only
+
+	RegistryType      *string    // for servers with a package from this registry type, e.g. "oci"
+	PackageIdentifier *string    // for servers with a package with this identifier
+	TransportType     *string    // for servers with a remote or package using this transport, e.g. "streamable-http"
+	Status            *string    // for versions with this status
+	Namespace         *string    // for servers whose name is in this namespace, e.g. "io.github.acme"
+	PublishedSince    *time.Time // for versions published after a time
+
+	Sort *ServerSort // order of the results rather than a condition; nil orders by name, or by relevance for searches
+
+	IncludeDrafts bool // also return draft versions, which listings leave out by default
+}
+
+// StatusDetails explains the status of a deprecated or deleted server version to its consumers
+type StatusDetails struct {
+	Message    string            // why the version has its status and what to do instead
+	ReplacedBy *apiv0.ReplacedBy // server to move to, if any
+}
+
+// AuditEvent is an entry of the append-only audit log, recording a single registry mutation
+type AuditEvent struct {
+	ID           int64
+	OccurredAt   time.Time
+	Action       string
+	ServerName   string
+	Version      string
+	ActorMethod  string          // auth method of the token used, or "system" for internal changes
+	ActorSubject string          // subject of the auth method, e.g. a GitHub username
+	SourceIP     string          // client IP of the request, if the change came from one
+	Reason       string          // optional free-text justification given by the actor
+	Before       json.RawMessage // full snapshot of the server version before the change, nil when it was created
+	After        json.RawMessage // full snapshot of the server version after the change
+	Diff         json.RawMessage // RFC 6902 JSON Patch from Before to After, nil when the version was created
+}
+
+// AuditEventFilter defines filtering options for audit log queries
+type AuditEventFilter struct {
+	ServerName   *string    // for events on a single server
+	ActorSubject *string    // for events by a single actor
+	Since        *time.Time // for events at or after a time
+	Until        *time.Time // for events before a time
+}
+
+// Webhook is an admin-managed subscription to server version events
+type Webhook struct {
+	ID        int64
+	URL       string
+	Secret    string   // key of the HMAC-SHA256 signature of deliveries
+	Events    []string // event types the webhook is subscribed to
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Statuses of webhook deliveries
+const (
+	WebhookDeliveryPending   = "pending"   // waiting for its next attempt
+	WebhookDeliverySucceeded = "succeeded" // the endpoint answered with a 2xx status
+	WebhookDeliveryFailed    = "failed"    // every attempt failed
+)
+
+// WebhookDelivery is an event to send to a webhook, and the outcome of sending it
+type WebhookDelivery struct {
+	ID             int64
+	WebhookID      int64
+	EventType      string
+	ServerName     string
+	Version        string
+	Payload        json.RawMessage
+	Status         string // one of the WebhookDelivery status constants
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	ResponseStatus int // HTTP status of the last attempt, 0 if there was no response
+	LastError      string
+	CreatedAt      time.Time
+}
+
+// Namespace records the owner of a namespace, the part of server names before the "/"
+type Namespace struct {
+	Namespace          string
+	OwnerType          string // "github" or "domain"
+	OwnerID            string // GitHub account ID, or the domain name
+	OwnerName          string // GitHub login when ownership was last verified, for display
+	VerificationMethod string // auth method that proved ownership, or "admin" for transfers
+	VerifiedAt         time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// NamespaceFilter defines filtering options for listing namespaces
+type NamespaceFilter struct {
+	OwnerType *string // only namespaces with this owner type
+	OwnerID   *string // only namespaces owned by this ID
+}
+
+// ServerRevision is a stored revision of a server version's server.json. Revision 1 is the
+// server.json as published, and each update that changes it adds the next revision.
+type ServerRevision struct {
+	ServerName string
+	Version    string
+	Revision   int
+	CreatedAt  time.Time
+	Server     apiv0.ServerJSON
+}
+
+// Kinds of change recorded in the change feed
+const (
+	ChangeTypeCreated       = "created"        // a version was published
+	ChangeTypeUpdated       = "updated"        // a version's server.json, latest flag or latest stable flag changed
+	ChangeTypeStatusChanged = "status_changed" // a version's status changed
+)
+
+// ServerChange is an entry of the change feed, which CreateServer, UpdateServer, SetServerStatus,
+// PromoteServer and the methods marking latest versions append to, except for changes to drafts.
+// Sequence numbers increase with every change, and a change only becomes visible once every change
+// with a lower sequence number is.
+type ServerChange struct {
+	Seq        int64
+	ChangeType string
+	ServerName string
+	Version    string
+	Status     string // status of the version as a result of the change
+	OccurredAt time.Time
+	Server     *apiv0.ServerResponse // current state of the version
+}
+
+// ServerChannel is a named pointer to a version of a server, such as "next" or "beta", which
+// publishers move between versions like npm dist-tags
+type ServerChannel struct {
+	ServerName string
+	Channel    string
+	Version    string
+	UpdatedAt  time.Time
+}
+
+// PublishEvent is a recent publish, recorded so that publish rate limits can be counted
+type PublishEvent struct {
+	ID           int64
+	OccurredAt   time.Time
+	ActorSubject string // subject of the token used, e.g. a GitHub username
+	Namespace    string // namespace of the published server
+	ServerName   string
+	NewServer    bool // whether the publish was the first version of the server
+}
+
+// PublishEventFilter defines filtering options for counting recent publishes
+type PublishEventFilter struct {
+	ActorSubject *string   // for publishes by a single actor
+	Namespace    *string   // for publishes to a single namespace
+	NewServer    bool      // for publishes of new servers only
+	Since        time.Time // for publishes at or after a time
+}
+
+// PublishLimits are the publish rate limits and quotas an admin set for a namespace, overriding
+// the configured defaults
+type PublishLimits struct {
+	Namespace        string
+	PublishesPerHour int
+	NewServersPerDay int
+	UpdatedAt        time.Time
+}
+
+// RemoteURLOwner is the server that owns a remote URL. A remote URL belongs to the first server
+// to publish it, and no other server can use it, or any URL with the same NormalizeRemoteURL,
+// while the owner's versions list it.
+type RemoteURLOwner struct {
+	URL        string // the remote URL as given
+	ServerName string
+}
+
+// PackageValidation is the stored evidence of the registry validation of one package of a server
+// version, recording why the package was accepted
+type PackageValidation struct {
+	ID           int64
+	ServerName   string
+	Version      string
+	PackageIndex int // position of the package in the server.json's packages
+	RegistryType string
+	Identifier   string
+	Check        string            // which check ran, e.g. "npm-mcp-name"
+	Outcome      string            // "passed", or "skipped" when the package was accepted without being checked
+	Facts        map[string]string // facts reported by the upstream registry, e.g. the resolved OCI digest
+	ValidatedAt  time.Time
+}
+
+// ServerExport is a consistent snapshot of every server version but drafts, as read by ExportServers
+type ServerExport struct {
+	Seq        int64     // change feed position: the snapshot includes exactly the changes up to this sequence number
+	SnapshotAt time.Time // when the snapshot was taken
+	// Servers yields every server version in name and release order. It can only be ranged over
+	// once, within the function given to ExportServers.
+	Servers iter.Seq2[*apiv0.ServerResponse, error]
 }
 
 // Database defines the interface for database operations
 type Database interface {
 	// CreateServer inserts a new server version with official metadata
-	CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *apiv0.ServerJSON, officialMeta *apiv0.RegistryExtensions) (*apiv0.ServerResponse, error)
-	// UpdateServer updates an existing server record
-	UpdateServer(ctx context.Context, tx pgx.Tx, serverName, version string, serverJSON *apiv0.ServerJSON) (*apiv0.ServerResponse, error)
-	// SetServerStatus updates the status of a specific server version
-	SetServerStatus(ctx context.Context, tx pgx.Tx, serverName, version string, status string) (*apiv0.ServerResponse, error)
+	CreateServer(ctx context.Context, tx Tx, serverJSON *apiv0.ServerJSON, officialMeta *apiv0.RegistryExtensions) (*apiv0.ServerResponse, error)
+	// UpdateServer updates an existing server record, keeping the previous server.json as a revision
+	UpdateServer(ctx context.Context, tx Tx, serverName, version string, serverJSON *apiv0.ServerJSON) (*apiv0.ServerResponse, error)
+	// SetServerStatus updates the status of a specific server version, replacing its status
+	// details; nil details clears them
+	SetServerStatus(ctx context.Context, tx Tx, serverName, version string, status string, details *StatusDetails) (*apiv0.ServerResponse, error)
 	// ListServers retrieve server entries with optional filtering
-	ListServers(ctx context.Context, tx pgx.Tx, filter *ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error)
+	ListServers(ctx context.Context, tx Tx, filter *ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error)
 	// GetServerByName retrieve a single server by its name
-	GetServerByName(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerResponse, error)
+	GetServerByName(ctx context.Context, tx Tx, serverName string) (*apiv0.ServerResponse, error)
 	// GetServerByNameAndVersion retrieve specific version of a server by server name and version
-	GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx, serverName string, version string) (*apiv0.ServerResponse, error)
+	GetServerByNameAndVersion(ctx context.Context, tx Tx, serverName string, version string) (*apiv0.ServerResponse, error)
 	// GetAllVersionsByServerName retrieve all versions of a server by server name
-	GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx, serverName string) ([]*apiv0.ServerResponse, error)
+	GetAllVersionsByServerName(ctx context.Context, tx Tx, serverName string) ([]*apiv0.ServerResponse, error)
 	// GetCurrentLatestVersion retrieve the current latest version of a server by server name
-	GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerResponse, error)
+	GetCurrentLatestVersion(ctx context.Context, tx Tx, serverName string) (*apiv0.ServerResponse, error)
 	// CountServerVersions count the number of versions for a server
-	CountServerVersions(ctx context.Context, tx pgx.Tx, serverName string) (int, error)
+	CountServerVersions(ctx context.Context, tx Tx, serverName string) (int, error)
 	// CheckVersionExists check if a specific version exists for a server
-	CheckVersionExists(ctx context.Context, tx pgx.Tx, serverName, version string) (bool, error)
+	CheckVersionExists(ctx context.Context, tx Tx, serverName, version string) (bool, error)
+	// PromoteServer makes a draft version active, publishing it as of now, and records it in the
+	// change feed as created. It returns ErrNotFound if the version is not a draft.
+	PromoteServer(ctx context.Context, tx Tx, serverName, version string, isLatest, isLatestStable bool) (*apiv0.ServerResponse, error)
 	// UnmarkAsLatest marks the current latest version of a server as no longer latest
-	UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName string) error
+	UnmarkAsLatest(ctx context.Context, tx Tx, serverName string) error
+	// MarkAsLatest marks a version of a server as its latest version, which requires that no
+	// other version is marked as latest
+	MarkAsLatest(ctx context.Context, tx Tx, serverName, version string) error
+	// UnmarkAsLatestStable marks the current latest stable version of a server as no longer latest stable
+	UnmarkAsLatestStable(ctx context.Context, tx Tx, serverName string) error
+	// MarkAsLatestStable marks a version of a server as its latest stable version, which requires
+	// that no other version is marked as latest stable
+	MarkAsLatestStable(ctx context.Context, tx Tx, serverName, version string) error
+	// ListServerNames retrieve the names of all servers in name order, only those in namespace unless it is empty
+	ListServerNames(ctx context.Context, tx Tx, namespace string) ([]string, error)
 	// AcquirePublishLock acquires an exclusive advisory lock for publishing a server
 	// This prevents race conditions when multiple versions are published concurrently
-	AcquirePublishLock(ctx context.Context, tx pgx.Tx, serverName string) error
+	AcquirePublishLock(ctx context.Context, tx Tx, serverName string) error
+	// SetServerChannel points a channel of a server at a version, creating the channel if needed. It
+	// returns ErrNotFound if the version does not exist.
+	SetServerChannel(ctx context.Context, tx Tx, channel *ServerChannel) (*ServerChannel, error)
+	// GetServerChannel retrieves a channel of a server
+	GetServerChannel(ctx context.Context, tx Tx, serverName, channel string) (*ServerChannel, error)
+	// ListServerChannels retrieve the channels of a server ordered by name
+	ListServerChannels(ctx context.Context, tx Tx, serverName string) ([]*ServerChannel, error)
+	// DeleteServerChannel deletes a channel of a server
+	DeleteServerChannel(ctx context.Context, tx Tx, serverName, channel string) error
+	// ListServerRevisions retrieve all revisions of a server version, oldest first
+	ListServerRevisions(ctx context.Context, tx Tx, serverName, version string) ([]*ServerRevision, error)
+	// GetServerRevision retrieve a single revision of a server version
+	GetServerRevision(ctx context.Context, tx Tx, serverName, version string, revision int) (*ServerRevision, error)
+	// ListServerChanges retrieve changes with a sequence number after since, oldest first
+	ListServerChanges(ctx context.Context, tx Tx, since int64, limit int) ([]*ServerChange, error)
+	// CreateWebhook stores a new webhook, setting its ID and timestamps
+	CreateWebhook(ctx context.Context, tx Tx, webhook *Webhook) error
+	// GetWebhook retrieves a webhook by ID
+	GetWebhook(ctx context.Context, tx Tx, id int64) (*Webhook, error)
+	// ListWebhooks retrieve all webhooks ordered by ID
+	ListWebhooks(ctx context.Context, tx Tx) ([]*Webhook, error)
+	// UpdateWebhook stores the URL, events and active flag of a webhook
+	UpdateWebhook(ctx context.Context, tx Tx, webhook *Webhook) error
+	// DeleteWebhook deletes a webhook and its delivery log
+	DeleteWebhook(ctx context.Context, tx Tx, id int64) error
+	// EnqueueWebhookDeliveries adds a pending delivery of an event for every active webhook subscribed to it
+	EnqueueWebhookDeliveries(ctx context.Context, tx Tx, eventType, serverName, version string, payload json.RawMessage) error
+	// ClaimWebhookDeliveries returns up to limit pending deliveries that are due, oldest first, and
+	// postpones their next attempt by lease so that concurrent dispatchers do not send them too
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*WebhookDelivery, error)
+	// UpdateWebhookDelivery stores the status and attempt details of a delivery
+	UpdateWebhookDelivery(ctx context.Context, tx Tx, delivery *WebhookDelivery) error
+	// GetWebhookDelivery retrieves a delivery by ID
+	GetWebhookDelivery(ctx context.Context, tx Tx, id int64) (*WebhookDelivery, error)
+	// ListWebhookDeliveries retrieve the deliveries of a webhook newest first
+	ListWebhookDeliveries(ctx context.Context, tx Tx, webhookID int64, cursor string, limit int) ([]*WebhookDelivery, string, error)
+	// GetNamespace retrieves the owner record of a namespace
+	GetNamespace(ctx context.Context, tx Tx, namespace string) (*Namespace, error)
+	// ListNamespaces retrieve namespace owner records ordered by namespace, with optional filtering
+	ListNamespaces(ctx context.Context, tx Tx, filter *NamespaceFilter, cursor string, limit int) ([]*Namespace, string, error)
+	// ClaimNamespace stores the owner record of a namespace that has none, and returns the
+	// namespace's owner record, which belongs to an earlier claimant if there was one
+	ClaimNamespace(ctx context.Context, tx Tx, namespace *Namespace) (*Namespace, error)
+	// SetNamespaceOwner stores the owner record of a namespace, replacing any previous owner
+	SetNamespaceOwner(ctx context.Context, tx Tx, namespace *Namespace) (*Namespace, error)
+	// ClaimRemoteURLs makes a server the owner of the remote URLs that have none, and returns the
+	// owners of the URLs owned by other servers, in the order of urls
+	ClaimRemoteURLs(ctx context.Context, tx Tx, serverName string, urls []string) ([]*RemoteURLOwner, error)
+	// ListRemoteURLOwners retrieve the owners of the remote URLs that have one, in the order of urls
+	ListRemoteURLOwners(ctx context.Context, tx Tx, urls []string) ([]*RemoteURLOwner, error)
+	// ReleaseRemoteURLs gives up a server's ownership of its remote URLs, except those in keep
+	ReleaseRemoteURLs(ctx context.Context, tx Tx, serverName string, keep []string) error
+	// CreatePackageValidations stores validation records of a server version's packages, setting their ID and ValidatedAt
+	CreatePackageValidations(ctx context.Context, tx Tx, validations []*PackageValidation) error
+	// ListPackageValidations retrieve the validation records of a server version, oldest first
+	ListPackageValidations(ctx context.Context, tx Tx, serverName, version string) ([]*PackageValidation, error)
+	// ExportServers runs fn with a consistent snapshot of every server version but drafts, streamed rather than loaded at once
+	ExportServers(ctx context.Context, fn func(export *ServerExport) error) error
+	// AcquirePublishLimitLocks acquires exclusive advisory locks on the recent publishes of a namespace
+	// and, unless actorSubject is empty, of an actor, in that order, so that concurrent publishes
+	// count each other against the publish limits
+	AcquirePublishLimitLocks(ctx context.Context, tx Tx, namespace, actorSubject string) error
+	// RecordPublishEvent appends a publish to the recent publishes, setting its ID and OccurredAt
+	RecordPublishEvent(ctx context.Context, tx Tx, event *PublishEvent) error
+	// ListPublishEventTimes retrieve the times of up to limit recent publishes newest first, with filtering
+	ListPublishEventTimes(ctx context.Context, tx Tx, filter *PublishEventFilter, limit int) ([]time.Time, error)
+	// DeletePublishEventsBefore deletes the recorded publishes that occurred before a time
+	DeletePublishEventsBefore(ctx context.Context, tx Tx, before time.Time) error
+	// GetPublishLimits retrieves the publish limits set for a namespace
+	GetPublishLimits(ctx context.Context, tx Tx, namespace string) (*PublishLimits, error)
+	// ListPublishLimits retrieve the publish limits set for namespaces ordered by namespace
+	ListPublishLimits(ctx context.Context, tx Tx) ([]*PublishLimits, error)
+	// SetPublishLimits stores the publish limits of a namespace, replacing any previous ones
+	SetPublishLimits(ctx context.Context, tx Tx, limits *PublishLimits) (*PublishLimits, error)
+	// DeletePublishLimits deletes the publish limits set for a namespace
+	DeletePublishLimits(ctx context.Context, tx Tx, namespace string) error
+	// CreateAuditEvent appends an event to the audit log, setting its ID and OccurredAt
+	CreateAuditEvent(ctx context.Context, tx Tx, event *AuditEvent) error
+	// ListAuditEvents retrieve audit events newest first with optional filtering
+	ListAuditEvents(ctx context.Context, tx Tx, filter *AuditEventFilter, cursor string, limit int) ([]*AuditEvent, string, error)
 	// InTransaction executes a function within a database transaction
-	InTransaction(ctx context.Context, fn func(ctx context.Context, tx pgx.Tx) error) error
+	InTransaction(ctx context.Context, fn func(ctx context.Context, tx Tx) error) error
 	// Close closes the database connection
 	Close() error
 }
@@ -66,11 +364,11 @@ type Database interface {
 // This exists because Go does not support generic methods on interfaces - only the Database interface
 // method InTransaction (without generics) can exist, so we provide this generic wrapper function.
 // This is a common pattern in Go for working around this language limitation.
-func InTransactionT[T any](ctx context.Context, db Database, fn func(ctx context.Context, tx pgx.Tx) (T, error)) (T, error) {
+func InTransactionT[T any](ctx context.Context, db Database, fn func(ctx context.Context, tx Tx) (T, error)) (T, error) {
 	var result T
 	var fnErr error
 
-	err := db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+	err := db.InTransaction(ctx, func(txCtx context.Context, tx Tx) error {
 		result, fnErr = fn(txCtx, tx)
 		return fnErr
 	})
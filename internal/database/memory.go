@@ -0,0 +1,828 @@
+package database
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// MemoryURL is the DATABASE_URL value that selects the in-memory backend instead of PostgreSQL
+const MemoryURL = "memory://"
+
+// errMemoryTxClosed is returned when a transaction is used after it has been committed or rolled back
+var errMemoryTxClosed = errors.New("transaction already closed")
+
+// memoryServerNameRegex mirrors the check_server_name_format constraint on the servers table
+var memoryServerNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9.-]*[a-zA-Z0-9]/[a-zA-Z0-9][a-zA-Z0-9._-]*[a-zA-Z0-9]$`)
+
+// Memory is an in-process implementation of the Database interface, intended for tests and
+// local runs without PostgreSQL.
+//
+// Transactions are serialized: InTransaction holds an exclusive lock for the whole callback and
+// restores a snapshot of the data when the callback fails. This is at least as strict as the
+// advisory locks used by the PostgreSQL backend. Operations called with a nil Tx from inside a
+// transaction callback block until that transaction finishes, so always pass the callback's tx.
+type Memory struct {
+	mu    sync.RWMutex
+	state *memoryState
+}
+
+// memoryServerKey is the primary key of a server version
+type memoryServerKey struct {
+	serverName string
+	version    string
+}
+
+// memoryServer is a single row of the servers table
+type memoryServer struct {
+	serverName     string
+	version        string
+	status         string
+	publishedAt    time.Time
+	updatedAt      time.Time
+	isLatest       bool
+	isLatestStable bool
+	value          []byte // marshalled apiv0.ServerJSON, never modified in place
+
+	versionSortKey string // see VersionSortKey
+
+	// StatusDetails, empty when not set
+	deprecationMessage string
+	replacedByName     string
+	replacedByVersion  string
+}
+
+// memoryState holds every table. Rows are stored by value so a shallow clone is a full snapshot.
+type memoryState struct {
+	servers       map[memoryServerKey]memoryServer
+	revisions     map[memoryServerKey][]memoryRevision // oldest first, append-only
+	auditEvents   []AuditEvent                         // in insertion order, append-only
+	lastAuditID   int64
+	changes       []memoryChange // in sequence order, append-only
+	lastChangeSeq int64
+
+	packageValidations    []PackageValidation // in insertion order, append-only
+	lastPackageValidation int64
+
+	namespaces map[string]Namespace
+
+	serverRemotes map[string]string // server name by normalized remote URL
+
+	channels map[memoryChannelKey]ServerChannel
+
+	publishEvents      []PublishEvent // in insertion order
+	lastPublishEventID int64
+	publishLimits      map[string]PublishLimits
+
+	webhooks          map[int64]Webhook
+	lastWebhookID     int64
+	webhookDeliveries []WebhookDelivery // in ID order
+	lastDeliveryID    int64
+}
+
+func newMemoryState() *memoryState {
+	return &memoryState{
+		servers:       make(map[memoryServerKey]memoryServer),
+		revisions:     make(map[memoryServerKey][]memoryRevision),
+		namespaces:    make(map[string]Namespace),
+		serverRemotes: make(map[string]string),
+		channels:      make(map[memoryChannelKey]ServerChannel),
+		publishLimits: make(map[string]PublishLimits),
+		webhooks:      make(map[int64]Webhook),
+	}
+}
+
+func (s *memoryState) clone() *memoryState {
+	return &memoryState{
+		servers:     maps.Clone(s.servers),
+		revisions:   maps.Clone(s.revisions),
+		auditEvents: slices.Clone(s.auditEvents),
+		lastAuditID: s.lastAuditID,
+
+		changes:       slices.Clone(s.changes),
+		lastChangeSeq: s.lastChangeSeq,
+
+		packageValidations:    slices.Clone(s.packageValidations),
+		lastPackageValidation: s.lastPackageValidation,
+
+		namespaces: maps.Clone(s.namespaces),
+
+		serverRemotes: maps.Clone(s.serverRemotes),
+
+		channels: maps.Clone(s.channels),
+
+		publishEvents:      slices.Clone(s.publishEvents),
+		lastPublishEventID: s.lastPublishEventID,
+		publishLimits:      maps.Clone(s.publishLimits),
+
+		webhooks:          maps.Clone(s.webhooks),
+		lastWebhookID:     s.lastWebhookID,
+		webhookDeliveries: slices.Clone(s.webhookDeliveries),
+		lastDeliveryID:    s.lastDeliveryID,
+	}
+}
+
+// memoryTx is the Tx implementation handed out by Memory.InTransaction
+type memoryTx struct {
+	db       *Memory
+	snapshot *memoryState
+	closed   bool
+}
+
+// Commit keeps the changes made in the transaction
+func (tx *memoryTx) Commit(_ context.Context) error {
+	if tx.closed {
+		return errMemoryTxClosed
+	}
+	tx.closed = true
+	return nil
+}
+
+// Rollback discards the changes made in the transaction
+func (tx *memoryTx) Rollback(_ context.Context) error {
+	if tx.closed {
+		return errMemoryTxClosed
+	}
+	tx.db.state = tx.snapshot
+	tx.closed = true
+	return nil
+}
+
+// NewMemory creates a new, empty in-memory database
+func NewMemory() *Memory {
+	return &Memory{
+		state: newMemoryState(),
+	}
+}
+
+// view runs fn with read access to the current state
+func (db *Memory) view(tx Tx, fn func(state *memoryState) error) error {
+	if tx == nil {
+		db.mu.RLock()
+		defer db.mu.RUnlock()
+		return fn(db.state)
+	}
+	if err := db.checkTx(tx); err != nil {
+		return err
+	}
+	return fn(db.state)
+}
+
+// update runs fn with write access to the current state.
+// Outside a transaction fn must either fully apply its change or return an error without changing anything.
+func (db *Memory) update(tx Tx, fn func(state *memoryState) error) error {
+	if tx == nil {
+		db.mu.Lock()
+		defer db.mu.Unlock()
+		return fn(db.state)
+	}
+	if err := db.checkTx(tx); err != nil {
+		return err
+	}
+	return fn(db.state)
+}
+
+// checkTx verifies that tx is an open transaction started by this database
+func (db *Memory) checkTx(tx Tx) error {
+	memTx, ok := tx.(*memoryTx)
+	if !ok || memTx.db != db {
+		return fmt.Errorf("%w: transaction was not started by this database", ErrInvalidInput)
+	}
+	if memTx.closed {
+		return errMemoryTxClosed
+	}
+	return nil
+}
+
+// toResponse builds the API representation of a stored row
+func (row memoryServer) toResponse() (*apiv0.ServerResponse, error) {
+	var serverJSON apiv0.ServerJSON
+	if err := json.Unmarshal(row.value, &serverJSON); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	}
+
+	official := &apiv0.RegistryExtensions{
+		Status:             model.Status(row.status),
+		PublishedAt:        row.publishedAt,
+		UpdatedAt:          row.updatedAt,
+		IsLatest:           row.isLatest,
+		IsLatestStable:     row.isLatestStable,
+		DeprecationMessage: row.deprecationMessage,
+	}
+	if row.replacedByName != "" {
+		official.ReplacedBy = &apiv0.ReplacedBy{Name: row.replacedByName, Version: row.replacedByVersion}
+	}
+
+	return &apiv0.ServerResponse{
+		Server: serverJSON,
+		Meta: apiv0.ResponseMeta{
+			Official: official,
+		},
+	}, nil
+}
+
+// checkConstraints mirrors the CHECK constraints of the servers table
+func (row memoryServer) checkConstraints() error {
+	switch model.Status(row.status) {
+	case model.StatusActive, model.StatusDeprecated, model.StatusDeleted, model.StatusDraft:
+	default:
+		return fmt.Errorf("%w: invalid status %q", ErrInvalidInput, row.status)
+	}
+	if !memoryServerNameRegex.MatchString(row.serverName) {
+		return fmt.Errorf("%w: invalid server name format %q", ErrInvalidInput, row.serverName)
+	}
+	if strings.TrimSpace(row.version) == "" {
+		return fmt.Errorf("%w: version must not be empty", ErrInvalidInput)
+	}
+	earliest := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	if row.publishedAt.Before(earliest) || row.publishedAt.After(time.Now().Add(24*time.Hour)) {
+		return fmt.Errorf("%w: published_at %s is out of range", ErrInvalidInput, row.publishedAt)
+	}
+	if row.replacedByVersion != "" && row.replacedByName == "" {
+		return fmt.Errorf("%w: replacement version requires a replacement name", ErrInvalidInput)
+	}
+	return nil
+}
+
+// serverRows returns the rows of a single server
+func (s *memoryState) serverRows(serverName string) []memoryServer {
+	var rows []memoryServer
+	for _, row := range s.servers {
+		if row.serverName == serverName {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// latestRow returns the row of a server that is marked as latest
+func (s *memoryState) latestRow(serverName string) (memoryServer, bool) {
+	for _, row := range s.serverRows(serverName) {
+		if row.isLatest {
+			return row, true
+		}
+	}
+	return memoryServer{}, false
+}
+
+// latestStableRow returns the row of a server that is marked as latest stable
+func (s *memoryState) latestStableRow(serverName string) (memoryServer, bool) {
+	for _, row := range s.serverRows(serverName) {
+		if row.isLatestStable {
+			return row, true
+		}
+	}
+	return memoryServer{}, false
+}
+
+// matchesFilter reports whether a row satisfies every condition of the filter
+func (row memoryServer) matchesFilter(filter *ServerFilter) (bool, error) {
+	if (filter == nil || !filter.IncludeDrafts) && row.status == string(model.StatusDraft) {
+		return false, nil
+	}
+	if filter == nil {
+		return true, nil
+	}
+	if filter.Name != nil && row.serverName != *filter.Name {
+		return false, nil
+	}
+	if filter.UpdatedSince != nil && !row.updatedAt.After(*filter.UpdatedSince) {
+		return false, nil
+	}
+	if filter.SubstringName != nil && !strings.Contains(strings.ToLower(row.serverName), strings.ToLower(*filter.SubstringName)) {
+		return false, nil
+	}
+	if filter.Version != nil && row.version != *filter.Version {
+		return false, nil
+	}
+	if filter.IsLatest != nil && row.isLatest != *filter.IsLatest {
+		return false, nil
+	}
+	if filter.IsLatestStable != nil && row.isLatestStable != *filter.IsLatestStable {
+		return false, nil
+	}
+	if filter.Status != nil && row.status != *filter.Status {
+		return false, nil
+	}
+	if filter.Namespace != nil {
+		if namespace, _, _ := strings.Cut(row.serverName, "/"); namespace != *filter.Namespace {
+			return false, nil
+		}
+	}
+	if filter.PublishedSince != nil && !row.publishedAt.After(*filter.PublishedSince) {
+		return false, nil
+	}
+
+	// The remaining conditions are on the server.json
+	if filter.RemoteURL == nil && filter.RegistryType == nil && filter.PackageIdentifier == nil && filter.TransportType == nil {
+		return true, nil
+	}
+	var serverJSON apiv0.ServerJSON
+	if err := json.Unmarshal(row.value, &serverJSON); err != nil {
+		return false, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	}
+	if filter.RemoteURL != nil && !slices.ContainsFunc(serverJSON.Remotes, func(remote model.Transport) bool {
+		return remote.URL == *filter.RemoteURL
+	}) {
+		return false, nil
+	}
+	if filter.RegistryType != nil && !slices.ContainsFunc(serverJSON.Packages, func(pkg model.Package) bool {
+		return pkg.RegistryType == *filter.RegistryType
+	}) {
+		return false, nil
+	}
+	if filter.PackageIdentifier != nil && !slices.ContainsFunc(serverJSON.Packages, func(pkg model.Package) bool {
+		return pkg.Identifier == *filter.PackageIdentifier
+	}) {
+		return false, nil
+	}
+	if filter.TransportType != nil {
+		usesTransport := slices.ContainsFunc(serverJSON.Remotes, func(remote model.Transport) bool {
+			return remote.Type == *filter.TransportType
+		}) || slices.ContainsFunc(serverJSON.Packages, func(pkg model.Package) bool {
+			return pkg.Transport.Type == *filter.TransportType
+		})
+		if !usesTransport {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ListServers returns server versions ordered by name and release order, using the same
+// "serverName:version" cursor format as the PostgreSQL implementation. Search results are
+// ordered by rank first and use "rank:serverName:version" cursors.
+func (db *Memory) ListServers(
+	ctx context.Context,
+	tx Tx,
+	filter *ServerFilter,
+	cursor string,
+	limit int,
+) ([]*apiv0.ServerResponse, string, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+
+	type rankedRow struct {
+		memoryServer
+		rank float32
+	}
+
+	search := filter != nil && filter.Search != nil
+	sort, ranked := serverSort(filter)
+	var rank float32
+	var cursorName, cursorVersion string
+	var cursorTime time.Time
+	if cursor != "" {
+		var err error
+		switch {
+		case ranked:
+			rank, cursorName, cursorVersion, err = parseSearchCursor(cursor)
+		case sort.byTime():
+			cursorTime, cursorName, cursorVersion, err = parseTimeCursor(cursor)
+		default:
+			cursorName, cursorVersion, err = parseNameCursor(cursor)
+		}
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	var matched []rankedRow
+	var cursorRow memoryServer
+	var cursorFound bool
+	err := db.view(tx, func(state *memoryState) error {
+		cursorRow, cursorFound = state.servers[memoryServerKey{cursorName, cursorVersion}]
+		for _, row := range state.servers {
+			ok, err := row.matchesFilter(filter)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			var rank float32
+			if search {
+				if rank, ok, err = row.searchRank(*filter.Search); err != nil {
+					return err
+				} else if !ok {
+					continue
+				}
+			}
+			if !ranked {
+				// Searches with another order only use the rank to select rows
+				rank = 0
+			}
+			matched = append(matched, rankedRow{row, rank})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	compareRows := func(a, b rankedRow) int {
+		if ranked {
+			if c := cmp.Compare(b.rank, a.rank); c != 0 {
+				return c
+			}
+		}
+		return sort.compare(a.memoryServer, b.memoryServer)
+	}
+	slices.SortFunc(matched, compareRows)
+
+	if cursor != "" {
+		matched = slices.DeleteFunc(matched, func(row rankedRow) bool {
+			switch {
+			case sort.byTime():
+				// The cursor carries every column the rows are ordered by
+				cursorRow := memoryServer{serverName: cursorName, version: cursorVersion, updatedAt: cursorTime, publishedAt: cursorTime}
+				return sort.compare(row.memoryServer, cursorRow) <= 0
+			case row.rank != rank || row.serverName != cursorName:
+				return compareRows(row, rankedRow{memoryServer{serverName: cursorName}, rank}) < 0
+			default:
+				// Like PostgreSQL, a cursor whose version no longer exists has no versions after it
+				return !cursorFound || compareRows(row, rankedRow{cursorRow, rank}) <= 0
+			}
+		})
+	}
+
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+
+	results := make([]*apiv0.ServerResponse, 0, len(matched))
+	for _, row := range matched {
+		serverResponse, err := row.toResponse()
+		if err != nil {
+			return nil, "", err
+		}
+		results = append(results, serverResponse)
+	}
+
+	nextCursor := ""
+	if len(results) > 0 && len(results) >= limit {
+		last := matched[len(matched)-1]
+		switch {
+		case ranked:
+			nextCursor = formatSearchCursor(last.rank, last.serverName, last.version)
+		case sort.byTime():
+			nextCursor = formatTimeCursor(sort.sortTime(last.updatedAt, last.publishedAt), last.serverName, last.version)
+		default:
+			nextCursor = formatNameCursor(last.serverName, last.version)
+		}
+	}
+
+	return results, nextCursor, nil
+}
+
+// GetServerByName retrieves the latest version of a server by server name
+func (db *Memory) GetServerByName(ctx context.Context, tx Tx, serverName string) (*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var result *apiv0.ServerResponse
+	err := db.view(tx, func(state *memoryState) error {
+		row, ok := state.latestRow(serverName)
+		if !ok {
+			return ErrNotFound
+		}
+		var err error
+		result, err = row.toResponse()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetServerByNameAndVersion retrieves a specific version of a server by server name and version
+func (db *Memory) GetServerByNameAndVersion(ctx context.Context, tx Tx, serverName string, version string) (*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var result *apiv0.ServerResponse
+	err := db.view(tx, func(state *memoryState) error {
+		row, ok := state.servers[memoryServerKey{serverName, version}]
+		if !ok {
+			return ErrNotFound
+		}
+		var err error
+		result, err = row.toResponse()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetAllVersionsByServerName retrieves all versions of a server by server name, highest version first
+func (db *Memory) GetAllVersionsByServerName(ctx context.Context, tx Tx, serverName string) ([]*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var rows []memoryServer
+	err := db.view(tx, func(state *memoryState) error {
+		rows = state.serverRows(serverName)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, ErrNotFound
+	}
+
+	slices.SortFunc(rows, func(a, b memoryServer) int {
+		if c := strings.Compare(b.versionSortKey, a.versionSortKey); c != 0 {
+			return c
+		}
+		return strings.Compare(b.version, a.version)
+	})
+
+	results := make([]*apiv0.ServerResponse, 0, len(rows))
+	for _, row := range rows {
+		serverResponse, err := row.toResponse()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, serverResponse)
+	}
+
+	return results, nil
+}
+
+// CreateServer inserts a new server version with official metadata
+func (db *Memory) CreateServer(ctx context.Context, tx Tx, serverJSON *apiv0.ServerJSON, officialMeta *apiv0.RegistryExtensions) (*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	// Validate inputs
+	if serverJSON == nil || officialMeta == nil {
+		return nil, fmt.Errorf("serverJSON and officialMeta are required")
+	}
+
+	if serverJSON.Name == "" || serverJSON.Version == "" {
+		return nil, fmt.Errorf("server name and version are required")
+	}
+
+	valueJSON, err := json.Marshal(serverJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal server JSON: %w", err)
+	}
+
+	row := memoryServer{
+		serverName:     serverJSON.Name,
+		version:        serverJSON.Version,
+		status:         string(officialMeta.Status),
+		publishedAt:    officialMeta.PublishedAt,
+		updatedAt:      officialMeta.UpdatedAt,
+		isLatest:       officialMeta.IsLatest,
+		isLatestStable: officialMeta.IsLatestStable,
+		value:          valueJSON,
+
+		versionSortKey: VersionSortKey(serverJSON.Version, officialMeta.PublishedAt),
+	}
+
+	err = db.update(tx, func(state *memoryState) error {
+		if err := row.checkConstraints(); err != nil {
+			return err
+		}
+		key := memoryServerKey{row.serverName, row.version}
+		if _, exists := state.servers[key]; exists {
+			return ErrAlreadyExists
+		}
+		if _, hasLatest := state.latestRow(row.serverName); row.isLatest && hasLatest {
+			return fmt.Errorf("%w: another version of %s is already marked as latest", ErrAlreadyExists, row.serverName)
+		}
+		if _, hasLatestStable := state.latestStableRow(row.serverName); row.isLatestStable && hasLatestStable {
+			return fmt.Errorf("%w: another version of %s is already marked as latest stable", ErrAlreadyExists, row.serverName)
+		}
+		state.servers[key] = row
+		state.appendServerRevision(key, valueJSON)
+		state.appendServerChange(row, ChangeTypeCreated)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert server: %w", err)
+	}
+
+	return &apiv0.ServerResponse{
+		Server: *serverJSON,
+		Meta: apiv0.ResponseMeta{
+			Official: officialMeta,
+		},
+	}, nil
+}
+
+// UpdateServer updates an existing server record with new server details
+func (db *Memory) UpdateServer(ctx context.Context, tx Tx, serverName, version string, serverJSON *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	// Validate inputs
+	if serverJSON == nil {
+		return nil, fmt.Errorf("serverJSON is required")
+	}
+
+	// Ensure the serverJSON matches the provided serverName and version
+	if serverJSON.Name != serverName || serverJSON.Version != version {
+		return nil, fmt.Errorf("%w: server name and version in JSON must match parameters", ErrInvalidInput)
+	}
+
+	valueJSON, err := json.Marshal(serverJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal updated server: %w", err)
+	}
+
+	var updated memoryServer
+	err = db.update(tx, func(state *memoryState) error {
+		key := memoryServerKey{serverName, version}
+		row, ok := state.servers[key]
+		if !ok {
+			return ErrNotFound
+		}
+		row.value = valueJSON
+		row.updatedAt = time.Now()
+		state.servers[key] = row
+		state.appendServerRevision(key, valueJSON)
+		state.appendServerChange(row, ChangeTypeUpdated)
+		updated = row
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated.toResponse()
+}
+
+// SetServerStatus updates the status of a specific server version, replacing its status details
+func (db *Memory) SetServerStatus(ctx context.Context, tx Tx, serverName, version string, status string, details *StatusDetails) (*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var updated memoryServer
+	err := db.update(tx, func(state *memoryState) error {
+		key := memoryServerKey{serverName, version}
+		row, ok := state.servers[key]
+		if !ok {
+			return ErrNotFound
+		}
+		row.status = status
+		row.deprecationMessage, row.replacedByName, row.replacedByVersion = "", "", ""
+		if details != nil {
+			row.deprecationMessage = details.Message
+			if details.ReplacedBy != nil {
+				row.replacedByName, row.replacedByVersion = details.ReplacedBy.Name, details.ReplacedBy.Version
+			}
+		}
+		row.updatedAt = time.Now()
+		if err := row.checkConstraints(); err != nil {
+			return err
+		}
+		state.servers[key] = row
+		state.appendServerChange(row, ChangeTypeStatusChanged)
+		updated = row
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated.toResponse()
+}
+
+// InTransaction executes a function within a transaction, restoring the previous state if it fails
+func (db *Memory) InTransaction(ctx context.Context, fn func(ctx context.Context, tx Tx) error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	tx := &memoryTx{db: db, snapshot: db.state.clone()}
+	defer func() {
+		// Roll back if fn panicked or returned an error; a no-op after commit
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := fn(ctx, tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// AcquirePublishLock acquires an exclusive lock for publishing a server.
+// Transactions on the in-memory database are already serialized, so this only validates its arguments.
+func (db *Memory) AcquirePublishLock(ctx context.Context, tx Tx, _ string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if tx == nil {
+		return nil
+	}
+
+	return db.checkTx(tx)
+}
+
+// GetCurrentLatestVersion retrieves the current latest version of a server by server name
+func (db *Memory) GetCurrentLatestVersion(ctx context.Context, tx Tx, serverName string) (*apiv0.ServerResponse, error) {
+	return db.GetServerByName(ctx, tx, serverName)
+}
+
+// CountServerVersions counts the number of versions for a server
+func (db *Memory) CountServerVersions(ctx context.Context, tx Tx, serverName string) (int, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	var count int
+	err := db.view(tx, func(state *memoryState) error {
+		count = len(state.serverRows(serverName))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CheckVersionExists checks if a specific version exists for a server
+func (db *Memory) CheckVersionExists(ctx context.Context, tx Tx, serverName, version string) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	var exists bool
+	err := db.view(tx, func(state *memoryState) error {
+		_, exists = state.servers[memoryServerKey{serverName, version}]
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// UnmarkAsLatest marks the current latest version of a server as no longer latest
+func (db *Memory) UnmarkAsLatest(ctx context.Context, tx Tx, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return db.update(tx, func(state *memoryState) error {
+		for key, row := range state.servers {
+			if row.serverName == serverName && row.isLatest {
+				row.isLatest = false
+				row.updatedAt = time.Now()
+				state.servers[key] = row
+				state.appendServerChange(row, ChangeTypeUpdated)
+			}
+		}
+		return nil
+	})
+}
+
+// Close releases the stored data
+func (db *Memory) Close() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.state = newMemoryState()
+	return nil
+}
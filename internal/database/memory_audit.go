@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// CreateAuditEvent appends an event to the audit log
+func (db *Memory) CreateAuditEvent(ctx context.Context, tx Tx, event *AuditEvent) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if event == nil || event.Action == "" || event.ServerName == "" {
+		return fmt.Errorf("%w: audit event action and server name are required", ErrInvalidInput)
+	}
+
+	return db.update(tx, func(state *memoryState) error {
+		state.lastAuditID++
+		event.ID = state.lastAuditID
+		event.OccurredAt = time.Now()
+		state.auditEvents = append(state.auditEvents, *event)
+		return nil
+	})
+}
+
+// ListAuditEvents retrieves audit events newest first, using the ID of the last event as the cursor
+func (db *Memory) ListAuditEvents(ctx context.Context, tx Tx, filter *AuditEventFilter, cursor string, limit int) ([]*AuditEvent, string, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+
+	var cursorID int64
+	if cursor != "" {
+		var err error
+		if cursorID, err = strconv.ParseInt(cursor, 10, 64); err != nil {
+			return nil, "", fmt.Errorf("%w: invalid cursor", ErrInvalidInput)
+		}
+	}
+
+	var events []*AuditEvent
+	err := db.view(tx, func(state *memoryState) error {
+		for i := len(state.auditEvents) - 1; i >= 0 && len(events) < limit; i-- {
+			event := state.auditEvents[i]
+			if cursor != "" && event.ID >= cursorID {
+				continue
+			}
+			if event.matchesFilter(filter) {
+				events = append(events, &event)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(events) > 0 && len(events) >= limit {
+		nextCursor = strconv.FormatInt(events[len(events)-1].ID, 10)
+	}
+
+	return events, nextCursor, nil
+}
+
+// matchesFilter reports whether an event satisfies every condition of the filter
+func (event AuditEvent) matchesFilter(filter *AuditEventFilter) bool {
+	if filter == nil {
+		return true
+	}
+	if filter.ServerName != nil && event.ServerName != *filter.ServerName {
+		return false
+	}
+	if filter.ActorSubject != nil && event.ActorSubject != *filter.ActorSubject {
+		return false
+	}
+	if filter.Since != nil && event.OccurredAt.Before(*filter.Since) {
+		return false
+	}
+	if filter.Until != nil && !event.OccurredAt.Before(*filter.Until) {
+		return false
+	}
+	return true
+}
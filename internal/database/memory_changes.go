@@ -0,0 +1,80 @@
+package database
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// memoryChange is a single row of the server_changes table
+type memoryChange struct {
+	seq        int64
+	changeType string
+	key        memoryServerKey
+	status     string
+	occurredAt time.Time
+}
+
+// appendServerChange records a change to a stored server version in the change feed, unless it is a draft
+func (s *memoryState) appendServerChange(row memoryServer, changeType string) {
+	if row.status == string(model.StatusDraft) {
+		return
+	}
+	s.lastChangeSeq++
+	s.changes = append(s.changes, memoryChange{
+		seq:        s.lastChangeSeq,
+		changeType: changeType,
+		key:        memoryServerKey{row.serverName, row.version},
+		status:     row.status,
+		occurredAt: time.Now(),
+	})
+}
+
+// ListServerChanges retrieves changes with a sequence number after since, oldest first
+func (db *Memory) ListServerChanges(ctx context.Context, tx Tx, since int64, limit int) ([]*ServerChange, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var results []*ServerChange
+	err := db.view(tx, func(state *memoryState) error {
+		// Changes are appended in sequence order
+		start := sort.Search(len(state.changes), func(i int) bool {
+			return state.changes[i].seq > since
+		})
+		for _, change := range state.changes[start:] {
+			if len(results) >= limit {
+				break
+			}
+			row, ok := state.servers[change.key]
+			if !ok {
+				continue
+			}
+			server, err := row.toResponse()
+			if err != nil {
+				return err
+			}
+			results = append(results, &ServerChange{
+				Seq:        change.seq,
+				ChangeType: change.changeType,
+				ServerName: change.key.serverName,
+				Version:    change.key.version,
+				Status:     change.status,
+				OccurredAt: change.occurredAt,
+				Server:     server,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,104 @@
+package database
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+)
+
+// memoryChannelKey is the primary key of a server channel
+type memoryChannelKey struct {
+	serverName string
+	channel    string
+}
+
+// SetServerChannel points a channel of a server at a version, creating the channel if needed
+func (db *Memory) SetServerChannel(ctx context.Context, tx Tx, channel *ServerChannel) (*ServerChannel, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var result ServerChannel
+	err := db.update(tx, func(state *memoryState) error {
+		if _, ok := state.servers[memoryServerKey{channel.ServerName, channel.Version}]; !ok {
+			return ErrNotFound
+		}
+		result = ServerChannel{
+			ServerName: channel.ServerName,
+			Channel:    channel.Channel,
+			Version:    channel.Version,
+			UpdatedAt:  time.Now(),
+		}
+		state.channels[memoryChannelKey{channel.ServerName, channel.Channel}] = result
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetServerChannel retrieves a channel of a server
+func (db *Memory) GetServerChannel(ctx context.Context, tx Tx, serverName, channel string) (*ServerChannel, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var result ServerChannel
+	err := db.view(tx, func(state *memoryState) error {
+		record, ok := state.channels[memoryChannelKey{serverName, channel}]
+		if !ok {
+			return ErrNotFound
+		}
+		result = record
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListServerChannels retrieves the channels of a server ordered by name
+func (db *Memory) ListServerChannels(ctx context.Context, tx Tx, serverName string) ([]*ServerChannel, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var channels []*ServerChannel
+	err := db.view(tx, func(state *memoryState) error {
+		for key, record := range state.channels {
+			if key.serverName == serverName {
+				channels = append(channels, &record)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(channels, func(a, b *ServerChannel) int {
+		return strings.Compare(a.Channel, b.Channel)
+	})
+	return channels, nil
+}
+
+// DeleteServerChannel deletes a channel of a server
+func (db *Memory) DeleteServerChannel(ctx context.Context, tx Tx, serverName, channel string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return db.update(tx, func(state *memoryState) error {
+		key := memoryChannelKey{serverName, channel}
+		if _, ok := state.channels[key]; !ok {
+			return ErrNotFound
+		}
+		delete(state.channels, key)
+		return nil
+	})
+}
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// PromoteServer makes a draft version active, publishing it as of now
+func (db *Memory) PromoteServer(ctx context.Context, tx Tx, serverName, version string, isLatest, isLatestStable bool) (*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var promoted memoryServer
+	err := db.update(tx, func(state *memoryState) error {
+		key := memoryServerKey{serverName, version}
+		row, ok := state.servers[key]
+		if !ok || row.status != string(model.StatusDraft) {
+			return ErrNotFound
+		}
+		if _, hasLatest := state.latestRow(serverName); isLatest && hasLatest {
+			return fmt.Errorf("%w: another version of %s is already marked as latest", ErrAlreadyExists, serverName)
+		}
+		if _, hasLatestStable := state.latestStableRow(serverName); isLatestStable && hasLatestStable {
+			return fmt.Errorf("%w: another version of %s is already marked as latest stable", ErrAlreadyExists, serverName)
+		}
+		row.status = string(model.StatusActive)
+		row.publishedAt = time.Now()
+		row.updatedAt = row.publishedAt
+		row.isLatest = isLatest
+		row.isLatestStable = isLatestStable
+		row.versionSortKey = VersionSortKey(version, row.publishedAt)
+		state.servers[key] = row
+		state.appendServerChange(row, ChangeTypeCreated)
+		promoted = row
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return promoted.toResponse()
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// ExportServers runs fn with every server version as of a snapshot of the current state
+func (db *Memory) ExportServers(ctx context.Context, fn func(export *ServerExport) error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	// Rows are stored by value, so a clone taken under the lock is a snapshot that later writes do not change
+	db.mu.RLock()
+	state := db.state.clone()
+	db.mu.RUnlock()
+
+	// Drafts are not published yet, so they are not exported
+	var rows []memoryServer
+	for _, row := range state.servers {
+		if row.status != string(model.StatusDraft) {
+			rows = append(rows, row)
+		}
+	}
+	slices.SortFunc(rows, func(a, b memoryServer) int {
+		if c := strings.Compare(a.serverName, b.serverName); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.versionSortKey, b.versionSortKey); c != 0 {
+			return c
+		}
+		return strings.Compare(a.version, b.version)
+	})
+
+	return fn(&ServerExport{
+		Seq:        state.lastChangeSeq,
+		SnapshotAt: time.Now(),
+		Servers: func(yield func(*apiv0.ServerResponse, error) bool) {
+			for _, row := range rows {
+				serverResponse, err := row.toResponse()
+				if !yield(serverResponse, err) || err != nil {
+					return
+				}
+			}
+		},
+	})
+}
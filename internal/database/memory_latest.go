@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MarkAsLatest marks a version of a server as its latest version
+func (db *Memory) MarkAsLatest(ctx context.Context, tx Tx, serverName, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return db.update(tx, func(state *memoryState) error {
+		key := memoryServerKey{serverName, version}
+		row, ok := state.servers[key]
+		if !ok {
+			return ErrNotFound
+		}
+		if latest, hasLatest := state.latestRow(serverName); hasLatest && latest.version != version {
+			return fmt.Errorf("%w: another version of %s is already marked as latest", ErrAlreadyExists, serverName)
+		}
+		row.isLatest = true
+		row.updatedAt = time.Now()
+		state.servers[key] = row
+		state.appendServerChange(row, ChangeTypeUpdated)
+		return nil
+	})
+}
+
+// UnmarkAsLatestStable marks the current latest stable version of a server as no longer latest stable
+func (db *Memory) UnmarkAsLatestStable(ctx context.Context, tx Tx, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return db.update(tx, func(state *memoryState) error {
+		for key, row := range state.servers {
+			if row.serverName == serverName && row.isLatestStable {
+				row.isLatestStable = false
+				row.updatedAt = time.Now()
+				state.servers[key] = row
+				state.appendServerChange(row, ChangeTypeUpdated)
+			}
+		}
+		return nil
+	})
+}
+
+// MarkAsLatestStable marks a version of a server as its latest stable version
+func (db *Memory) MarkAsLatestStable(ctx context.Context, tx Tx, serverName, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return db.update(tx, func(state *memoryState) error {
+		key := memoryServerKey{serverName, version}
+		row, ok := state.servers[key]
+		if !ok {
+			return ErrNotFound
+		}
+		if latestStable, hasLatestStable := state.latestStableRow(serverName); hasLatestStable && latestStable.version != version {
+			return fmt.Errorf("%w: another version of %s is already marked as latest stable", ErrAlreadyExists, serverName)
+		}
+		row.isLatestStable = true
+		row.updatedAt = time.Now()
+		state.servers[key] = row
+		state.appendServerChange(row, ChangeTypeUpdated)
+		return nil
+	})
+}
+
+// ListServerNames retrieves the names of all servers with at least one version, in name order,
+// only those in namespace unless it is empty
+func (db *Memory) ListServerNames(ctx context.Context, tx Tx, namespace string) ([]string, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var names []string
+	err := db.view(tx, func(state *memoryState) error {
+		for key := range state.servers {
+			if rowNamespace, _, _ := strings.Cut(key.serverName, "/"); namespace != "" && rowNamespace != namespace {
+				continue
+			}
+			if !slices.Contains(names, key.serverName) {
+				names = append(names, key.serverName)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(names)
+	return names, nil
+}
@@ -0,0 +1,180 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// AcquirePublishLimitLocks acquires the locks of the recent publishes of a namespace and of an actor.
+// Transactions on the in-memory database are already serialized, so this only validates its arguments.
+func (db *Memory) AcquirePublishLimitLocks(ctx context.Context, tx Tx, namespace, _ string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if namespace == "" {
+		return fmt.Errorf("%w: namespace is required", ErrInvalidInput)
+	}
+
+	if tx == nil {
+		return nil
+	}
+
+	return db.checkTx(tx)
+}
+
+// RecordPublishEvent appends a publish to the recent publishes
+func (db *Memory) RecordPublishEvent(ctx context.Context, tx Tx, event *PublishEvent) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if event == nil || event.Namespace == "" || event.ServerName == "" {
+		return fmt.Errorf("%w: publish event namespace and server name are required", ErrInvalidInput)
+	}
+
+	return db.update(tx, func(state *memoryState) error {
+		state.lastPublishEventID++
+		event.ID = state.lastPublishEventID
+		event.OccurredAt = time.Now()
+		state.publishEvents = append(state.publishEvents, *event)
+		return nil
+	})
+}
+
+// ListPublishEventTimes retrieves the times of up to limit recent publishes newest first
+func (db *Memory) ListPublishEventTimes(ctx context.Context, tx Tx, filter *PublishEventFilter, limit int) ([]time.Time, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var times []time.Time
+	err := db.view(tx, func(state *memoryState) error {
+		for i := len(state.publishEvents) - 1; i >= 0 && len(times) < limit; i-- {
+			event := state.publishEvents[i]
+			if filter != nil {
+				if filter.ActorSubject != nil && event.ActorSubject != *filter.ActorSubject {
+					continue
+				}
+				if filter.Namespace != nil && event.Namespace != *filter.Namespace {
+					continue
+				}
+				if filter.NewServer && !event.NewServer {
+					continue
+				}
+				if event.OccurredAt.Before(filter.Since) {
+					continue
+				}
+			}
+			times = append(times, event.OccurredAt)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return times, nil
+}
+
+// DeletePublishEventsBefore deletes the recorded publishes that occurred before a time
+func (db *Memory) DeletePublishEventsBefore(ctx context.Context, tx Tx, before time.Time) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return db.update(tx, func(state *memoryState) error {
+		state.publishEvents = slices.DeleteFunc(slices.Clone(state.publishEvents), func(event PublishEvent) bool {
+			return event.OccurredAt.Before(before)
+		})
+		return nil
+	})
+}
+
+// GetPublishLimits retrieves the publish limits set for a namespace
+func (db *Memory) GetPublishLimits(ctx context.Context, tx Tx, namespace string) (*PublishLimits, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var result PublishLimits
+	err := db.view(tx, func(state *memoryState) error {
+		limits, ok := state.publishLimits[namespace]
+		if !ok {
+			return ErrNotFound
+		}
+		result = limits
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListPublishLimits retrieves the publish limits set for namespaces ordered by namespace
+func (db *Memory) ListPublishLimits(ctx context.Context, tx Tx) ([]*PublishLimits, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var results []*PublishLimits
+	err := db.view(tx, func(state *memoryState) error {
+		for _, limits := range state.publishLimits {
+			results = append(results, &limits)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(results, func(a, b *PublishLimits) int {
+		return strings.Compare(a.Namespace, b.Namespace)
+	})
+	return results, nil
+}
+
+// SetPublishLimits stores the publish limits of a namespace, replacing any previous ones
+func (db *Memory) SetPublishLimits(ctx context.Context, tx Tx, limits *PublishLimits) (*PublishLimits, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err := limits.validate(); err != nil {
+		return nil, err
+	}
+
+	result := PublishLimits{
+		Namespace:        limits.Namespace,
+		PublishesPerHour: limits.PublishesPerHour,
+		NewServersPerDay: limits.NewServersPerDay,
+		UpdatedAt:        time.Now(),
+	}
+	err := db.update(tx, func(state *memoryState) error {
+		state.publishLimits[limits.Namespace] = result
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DeletePublishLimits deletes the publish limits set for a namespace
+func (db *Memory) DeletePublishLimits(ctx context.Context, tx Tx, namespace string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return db.update(tx, func(state *memoryState) error {
+		if _, ok := state.publishLimits[namespace]; !ok {
+			return ErrNotFound
+		}
+		delete(state.publishLimits, namespace)
+		return nil
+	})
+}
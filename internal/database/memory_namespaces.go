@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+)
+
+// GetNamespace retrieves the owner record of a namespace
+func (db *Memory) GetNamespace(ctx context.Context, tx Tx, namespace string) (*Namespace, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var result *Namespace
+	err := db.view(tx, func(state *memoryState) error {
+		record, ok := state.namespaces[namespace]
+		if !ok {
+			return ErrNotFound
+		}
+		result = &record
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListNamespaces retrieves namespace owner records ordered by namespace, using the last namespace as the cursor
+func (db *Memory) ListNamespaces(ctx context.Context, tx Tx, filter *NamespaceFilter, cursor string, limit int) ([]*Namespace, string, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+
+	var results []*Namespace
+	err := db.view(tx, func(state *memoryState) error {
+		for _, name := range slices.Sorted(maps.Keys(state.namespaces)) {
+			if len(results) >= limit {
+				break
+			}
+			record := state.namespaces[name]
+			if name <= cursor || !record.matchesFilter(filter) {
+				continue
+			}
+			results = append(results, &record)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(results) > 0 && len(results) >= limit {
+		nextCursor = results[len(results)-1].Namespace
+	}
+
+	return results, nextCursor, nil
+}
+
+// matchesFilter reports whether a namespace satisfies every condition of the filter
+func (n Namespace) matchesFilter(filter *NamespaceFilter) bool {
+	if filter == nil {
+		return true
+	}
+	if filter.OwnerType != nil && n.OwnerType != *filter.OwnerType {
+		return false
+	}
+	if filter.OwnerID != nil && n.OwnerID != *filter.OwnerID {
+		return false
+	}
+	return true
+}
+
+// ClaimNamespace stores the owner record of a namespace unless it already has one
+func (db *Memory) ClaimNamespace(ctx context.Context, tx Tx, namespace *Namespace) (*Namespace, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err := namespace.validate(); err != nil {
+		return nil, err
+	}
+
+	var result *Namespace
+	err := db.update(tx, func(state *memoryState) error {
+		record, ok := state.namespaces[namespace.Namespace]
+		if !ok {
+			now := time.Now()
+			record = *namespace
+			record.CreatedAt = now
+			record.UpdatedAt = now
+			state.namespaces[namespace.Namespace] = record
+		}
+		result = &record
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SetNamespaceOwner stores the owner record of a namespace, replacing any previous owner
+func (db *Memory) SetNamespaceOwner(ctx context.Context, tx Tx, namespace *Namespace) (*Namespace, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err := namespace.validate(); err != nil {
+		return nil, err
+	}
+
+	var result *Namespace
+	err := db.update(tx, func(state *memoryState) error {
+		now := time.Now()
+		record := *namespace
+		record.CreatedAt = now
+		if previous, ok := state.namespaces[namespace.Namespace]; ok {
+			record.CreatedAt = previous.CreatedAt
+		}
+		record.UpdatedAt = now
+		state.namespaces[namespace.Namespace] = record
+		result = &record
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
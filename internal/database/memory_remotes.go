@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"fmt"
+)
+
+// ClaimRemoteURLs makes a server the owner of the remote URLs that have none, and returns the
+// owners of the URLs owned by other servers
+func (db *Memory) ClaimRemoteURLs(ctx context.Context, tx Tx, serverName string, urls []string) ([]*RemoteURLOwner, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if serverName == "" {
+		return nil, fmt.Errorf("%w: server name is required", ErrInvalidInput)
+	}
+
+	var conflicts []*RemoteURLOwner
+	err := db.update(tx, func(state *memoryState) error {
+		for _, url := range urls {
+			key := NormalizeRemoteURL(url)
+			owner, ok := state.serverRemotes[key]
+			if !ok {
+				state.serverRemotes[key] = serverName
+				continue
+			}
+			if owner != serverName {
+				conflicts = append(conflicts, &RemoteURLOwner{URL: url, ServerName: owner})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return conflicts, nil
+}
+
+// ListRemoteURLOwners retrieves the owners of the remote URLs that have one
+func (db *Memory) ListRemoteURLOwners(ctx context.Context, tx Tx, urls []string) ([]*RemoteURLOwner, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var owners []*RemoteURLOwner
+	err := db.view(tx, func(state *memoryState) error {
+		for _, url := range urls {
+			if owner, ok := state.serverRemotes[NormalizeRemoteURL(url)]; ok {
+				owners = append(owners, &RemoteURLOwner{URL: url, ServerName: owner})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return owners, nil
+}
+
+// ReleaseRemoteURLs gives up a server's ownership of its remote URLs, except those in keep
+func (db *Memory) ReleaseRemoteURLs(ctx context.Context, tx Tx, serverName string, keep []string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	kept := make(map[string]bool, len(keep))
+	for _, key := range normalizeRemoteURLs(keep) {
+		kept[key] = true
+	}
+
+	return db.update(tx, func(state *memoryState) error {
+		for key, owner := range state.serverRemotes {
+			if owner == serverName && !kept[key] {
+				delete(state.serverRemotes, key)
+			}
+		}
+		return nil
+	})
+}
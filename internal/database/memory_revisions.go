@@ -0,0 +1,101 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// memoryRevision is a single row of the server_revisions table
+type memoryRevision struct {
+	revision  int
+	createdAt time.Time
+	value     []byte
+}
+
+// appendServerRevision stores value as the next revision of a server version, unless it is
+// identical to the latest revision
+func (s *memoryState) appendServerRevision(key memoryServerKey, value []byte) {
+	revisions := s.revisions[key]
+	if len(revisions) > 0 && bytes.Equal(revisions[len(revisions)-1].value, value) {
+		return
+	}
+	// Appending to a slice shared with a snapshot never changes what the snapshot sees, as it
+	// only covers the elements that existed when it was taken
+	s.revisions[key] = append(revisions, memoryRevision{
+		revision:  len(revisions) + 1,
+		createdAt: time.Now(),
+		value:     value,
+	})
+}
+
+// toServerRevision builds the database representation of a stored revision
+func (rev memoryRevision) toServerRevision(key memoryServerKey) (*ServerRevision, error) {
+	result := &ServerRevision{
+		ServerName: key.serverName,
+		Version:    key.version,
+		Revision:   rev.revision,
+		CreatedAt:  rev.createdAt,
+	}
+	if err := json.Unmarshal(rev.value, &result.Server); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	}
+	return result, nil
+}
+
+// ListServerRevisions retrieves all revisions of a server version, oldest first
+func (db *Memory) ListServerRevisions(ctx context.Context, tx Tx, serverName, version string) ([]*ServerRevision, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	key := memoryServerKey{serverName, version}
+	var revisions []memoryRevision
+	err := db.view(tx, func(state *memoryState) error {
+		revisions = state.revisions[key]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(revisions) == 0 {
+		return nil, ErrNotFound
+	}
+
+	results := make([]*ServerRevision, 0, len(revisions))
+	for _, rev := range revisions {
+		result, err := rev.toServerRevision(key)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// GetServerRevision retrieves a single revision of a server version
+func (db *Memory) GetServerRevision(ctx context.Context, tx Tx, serverName, version string, revision int) (*ServerRevision, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	key := memoryServerKey{serverName, version}
+	var found memoryRevision
+	err := db.view(tx, func(state *memoryState) error {
+		revisions := state.revisions[key]
+		if revision < 1 || revision > len(revisions) {
+			return ErrNotFound
+		}
+		found = revisions[revision-1]
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return found.toServerRevision(key)
+}
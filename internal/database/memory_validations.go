@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+)
+
+// CreatePackageValidations stores validation records of a server version's packages
+func (db *Memory) CreatePackageValidations(ctx context.Context, tx Tx, validations []*PackageValidation) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return db.update(tx, func(state *memoryState) error {
+		// Check every record first so that a failure outside a transaction changes nothing
+		for _, validation := range validations {
+			if err := validation.validate(); err != nil {
+				return err
+			}
+			if _, ok := state.servers[memoryServerKey{validation.ServerName, validation.Version}]; !ok {
+				return fmt.Errorf("%w: server %s version %s", ErrNotFound, validation.ServerName, validation.Version)
+			}
+		}
+
+		now := time.Now()
+		for _, validation := range validations {
+			state.lastPackageValidation++
+			validation.ID = state.lastPackageValidation
+			validation.ValidatedAt = now
+			stored := *validation
+			stored.Facts = maps.Clone(validation.Facts)
+			state.packageValidations = append(state.packageValidations, stored)
+		}
+		return nil
+	})
+}
+
+// ListPackageValidations retrieves the validation records of a server version, oldest first
+func (db *Memory) ListPackageValidations(ctx context.Context, tx Tx, serverName, version string) ([]*PackageValidation, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var results []*PackageValidation
+	err := db.view(tx, func(state *memoryState) error {
+		for _, validation := range state.packageValidations {
+			if validation.ServerName == serverName && validation.Version == version {
+				result := validation
+				result.Facts = maps.Clone(validation.Facts)
+				results = append(results, &result)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
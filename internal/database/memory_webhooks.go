@@ -0,0 +1,263 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// CreateWebhook stores a new webhook
+func (db *Memory) CreateWebhook(ctx context.Context, tx Tx, webhook *Webhook) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return db.update(tx, func(state *memoryState) error {
+		now := time.Now()
+		state.lastWebhookID++
+		webhook.ID = state.lastWebhookID
+		webhook.CreatedAt = now
+		webhook.UpdatedAt = now
+		stored := *webhook
+		stored.Events = slices.Clone(webhook.Events)
+		state.webhooks[webhook.ID] = stored
+		return nil
+	})
+}
+
+// GetWebhook retrieves a webhook by ID
+func (db *Memory) GetWebhook(ctx context.Context, tx Tx, id int64) (*Webhook, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var result *Webhook
+	err := db.view(tx, func(state *memoryState) error {
+		webhook, ok := state.webhooks[id]
+		if !ok {
+			return ErrNotFound
+		}
+		webhook.Events = slices.Clone(webhook.Events)
+		result = &webhook
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListWebhooks retrieves all webhooks ordered by ID
+func (db *Memory) ListWebhooks(ctx context.Context, tx Tx) ([]*Webhook, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var results []*Webhook
+	err := db.view(tx, func(state *memoryState) error {
+		for _, id := range slices.Sorted(maps.Keys(state.webhooks)) {
+			webhook := state.webhooks[id]
+			webhook.Events = slices.Clone(webhook.Events)
+			results = append(results, &webhook)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// UpdateWebhook stores the URL, events and active flag of a webhook
+func (db *Memory) UpdateWebhook(ctx context.Context, tx Tx, webhook *Webhook) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return db.update(tx, func(state *memoryState) error {
+		stored, ok := state.webhooks[webhook.ID]
+		if !ok {
+			return ErrNotFound
+		}
+		stored.URL = webhook.URL
+		stored.Events = slices.Clone(webhook.Events)
+		stored.Active = webhook.Active
+		stored.UpdatedAt = time.Now()
+		state.webhooks[webhook.ID] = stored
+		*webhook = stored
+		webhook.Events = slices.Clone(stored.Events)
+		return nil
+	})
+}
+
+// DeleteWebhook deletes a webhook and its delivery log
+func (db *Memory) DeleteWebhook(ctx context.Context, tx Tx, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return db.update(tx, func(state *memoryState) error {
+		if _, ok := state.webhooks[id]; !ok {
+			return ErrNotFound
+		}
+		delete(state.webhooks, id)
+		state.webhookDeliveries = slices.DeleteFunc(state.webhookDeliveries, func(delivery WebhookDelivery) bool {
+			return delivery.WebhookID == id
+		})
+		return nil
+	})
+}
+
+// EnqueueWebhookDeliveries adds a pending delivery of an event for every active webhook subscribed to it
+func (db *Memory) EnqueueWebhookDeliveries(ctx context.Context, tx Tx, eventType, serverName, version string, payload json.RawMessage) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return db.update(tx, func(state *memoryState) error {
+		now := time.Now()
+		for _, id := range slices.Sorted(maps.Keys(state.webhooks)) {
+			webhook := state.webhooks[id]
+			if !webhook.Active || !slices.Contains(webhook.Events, eventType) {
+				continue
+			}
+			state.lastDeliveryID++
+			state.webhookDeliveries = append(state.webhookDeliveries, WebhookDelivery{
+				ID:            state.lastDeliveryID,
+				WebhookID:     webhook.ID,
+				EventType:     eventType,
+				ServerName:    serverName,
+				Version:       version,
+				Payload:       slices.Clone(payload),
+				Status:        WebhookDeliveryPending,
+				NextAttemptAt: now,
+				CreatedAt:     now,
+			})
+		}
+		return nil
+	})
+}
+
+// ClaimWebhookDeliveries returns due pending deliveries, postponing their next attempt by lease
+func (db *Memory) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*WebhookDelivery, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var results []*WebhookDelivery
+	err := db.update(nil, func(state *memoryState) error {
+		now := time.Now()
+		for i := range state.webhookDeliveries {
+			if len(results) >= limit {
+				break
+			}
+			delivery := &state.webhookDeliveries[i]
+			if delivery.Status != WebhookDeliveryPending || delivery.NextAttemptAt.After(now) {
+				continue
+			}
+			delivery.NextAttemptAt = now.Add(lease)
+			claimed := *delivery
+			results = append(results, &claimed)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// UpdateWebhookDelivery stores the status and attempt details of a delivery
+func (db *Memory) UpdateWebhookDelivery(ctx context.Context, tx Tx, delivery *WebhookDelivery) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return db.update(tx, func(state *memoryState) error {
+		for i := range state.webhookDeliveries {
+			stored := &state.webhookDeliveries[i]
+			if stored.ID != delivery.ID {
+				continue
+			}
+			stored.Status = delivery.Status
+			stored.Attempts = delivery.Attempts
+			stored.NextAttemptAt = delivery.NextAttemptAt
+			stored.LastAttemptAt = delivery.LastAttemptAt
+			stored.ResponseStatus = delivery.ResponseStatus
+			stored.LastError = delivery.LastError
+			return nil
+		}
+		return ErrNotFound
+	})
+}
+
+// GetWebhookDelivery retrieves a delivery by ID
+func (db *Memory) GetWebhookDelivery(ctx context.Context, tx Tx, id int64) (*WebhookDelivery, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var result *WebhookDelivery
+	err := db.view(tx, func(state *memoryState) error {
+		for _, delivery := range state.webhookDeliveries {
+			if delivery.ID == id {
+				result = &delivery
+				return nil
+			}
+		}
+		return ErrNotFound
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListWebhookDeliveries retrieves the deliveries of a webhook newest first, using the ID of the last delivery as the cursor
+func (db *Memory) ListWebhookDeliveries(ctx context.Context, tx Tx, webhookID int64, cursor string, limit int) ([]*WebhookDelivery, string, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+
+	var cursorID int64
+	if cursor != "" {
+		var err error
+		if cursorID, err = strconv.ParseInt(cursor, 10, 64); err != nil {
+			return nil, "", fmt.Errorf("%w: invalid cursor", ErrInvalidInput)
+		}
+	}
+
+	var results []*WebhookDelivery
+	err := db.view(tx, func(state *memoryState) error {
+		for i := len(state.webhookDeliveries) - 1; i >= 0 && len(results) < limit; i-- {
+			delivery := state.webhookDeliveries[i]
+			if delivery.WebhookID != webhookID || (cursor != "" && delivery.ID >= cursorID) {
+				continue
+			}
+			results = append(results, &delivery)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(results) > 0 && len(results) >= limit {
+		nextCursor = strconv.FormatInt(results[len(results)-1].ID, 10)
+	}
+
+	return results, nextCursor, nil
+}
@@ -2,7 +2,9 @@ package database
 
 import (
 	"context"
+	"crypto/sha256"
 	"embed"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
@@ -10,6 +12,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 )
@@ -17,11 +20,43 @@ import (
 //go:embed migrations/*.sql
 var migrationFiles embed.FS
 
+// downMigrationSuffix marks the file that reverts a migration, e.g. "012_add_full_text_search.down.sql"
+const downMigrationSuffix = ".down.sql"
+
+// ErrMigrationModified is returned when the SQL of an applied migration no longer matches what was applied
+var ErrMigrationModified = errors.New("applied migration has been modified")
+
 // Migration represents a database migration
 type Migration struct {
-	Version int
-	Name    string
-	SQL     string
+	Version  int
+	Name     string
+	SQL      string
+	DownSQL  string // SQL that reverts the migration, empty if it cannot be reverted
+	Checksum string // SHA-256 of SQL, recorded when the migration is applied
+}
+
+// MigrationStep is a migration to apply, or to revert when Down is set
+type MigrationStep struct {
+	Migration Migration
+	Down      bool
+}
+
+// MigrationStatus describes a migration known to this binary, the database, or both
+type MigrationStatus struct {
+	Version    int
+	Name       string
+	Applied    bool
+	AppliedAt  time.Time
+	Reversible bool // a down migration is available
+	Modified   bool // applied with different SQL than this binary has
+	Missing    bool // applied, but unknown to this binary (e.g. applied by a newer release)
+}
+
+// appliedMigration is a row of the schema_migrations table
+type appliedMigration struct {
+	name      string
+	checksum  string // empty for migrations applied before checksums were recorded
+	appliedAt time.Time
 }
 
 // Migrator handles database migrations
@@ -41,28 +76,30 @@ func (m *Migrator) ensureMigrationsTable(ctx context.Context) error {
 			version INTEGER PRIMARY KEY,
 			name VARCHAR(255) NOT NULL,
 			applied_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
-		)
+		);
+		ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS checksum VARCHAR(64)
 	`
 	_, err := m.conn.Exec(ctx, query)
 	return err
 }
 
-// getAppliedMigrations returns a map of already applied migration versions
-func (m *Migrator) getAppliedMigrations(ctx context.Context) (map[int]bool, error) {
-	query := "SELECT version FROM schema_migrations ORDER BY version"
+// getAppliedMigrations returns the already applied migrations by version
+func (m *Migrator) getAppliedMigrations(ctx context.Context) (map[int]appliedMigration, error) {
+	query := "SELECT version, name, COALESCE(checksum, ''), applied_at FROM schema_migrations ORDER BY version"
 	rows, err := m.conn.Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query applied migrations: %w", err)
 	}
 	defer rows.Close()
 
-	applied := make(map[int]bool)
+	applied := make(map[int]appliedMigration)
 	for rows.Next() {
 		var version int
-		if err := rows.Scan(&version); err != nil {
+		var migration appliedMigration
+		if err := rows.Scan(&version, &migration.name, &migration.checksum, &migration.appliedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan migration version: %w", err)
 		}
-		applied[version] = true
+		applied[version] = migration
 	}
 
 	return applied, rows.Err()
@@ -76,6 +113,7 @@ func (m *Migrator) loadMigrations() ([]Migration, error) {
 	}
 
 	var migrations []Migration
+	downSQL := make(map[int]string)
 	for _, entry := range entries {
 		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
 			continue
@@ -101,10 +139,16 @@ func (m *Migrator) loadMigrations() ([]Migration, error) {
 			return nil, fmt.Errorf("failed to read migration file %s: %w", name, err)
 		}
 
+		if strings.HasSuffix(name, downMigrationSuffix) {
+			downSQL[version] = string(content)
+			continue
+		}
+
 		migrations = append(migrations, Migration{
-			Version: version,
-			Name:    strings.TrimSuffix(name, ".sql"),
-			SQL:     string(content),
+			Version:  version,
+			Name:     strings.TrimSuffix(name, ".sql"),
+			SQL:      string(content),
+			Checksum: migrationChecksum(string(content)),
 		})
 	}
 
@@ -113,57 +157,307 @@ func (m *Migrator) loadMigrations() ([]Migration, error) {
 		return migrations[i].Version < migrations[j].Version
 	})
 
+	// Pair down migrations with the migrations they revert
+	for i := range migrations {
+		migrations[i].DownSQL = downSQL[migrations[i].Version]
+		delete(downSQL, migrations[i].Version)
+	}
+	if len(downSQL) > 0 {
+		orphans := make([]string, 0, len(downSQL))
+		for version := range downSQL {
+			orphans = append(orphans, strconv.Itoa(version))
+		}
+		sort.Strings(orphans)
+		return nil, fmt.Errorf("down migrations without a matching migration: %s", strings.Join(orphans, ", "))
+	}
+
 	return migrations, nil
 }
 
-// Migrate runs all pending migrations
-func (m *Migrator) Migrate(ctx context.Context) error {
+// migrationChecksum returns the checksum recorded for a migration's SQL
+func migrationChecksum(sql string) string {
+	sum := sha256.Sum256([]byte(sql))
+	return hex.EncodeToString(sum[:])
+}
+
+// load reads the embedded migrations and the applied migrations, failing if any applied
+// migration was modified after it was applied
+func (m *Migrator) load(ctx context.Context) ([]Migration, map[int]appliedMigration, error) {
 	// Ensure the migrations table exists
 	if err := m.ensureMigrationsTable(ctx); err != nil {
-		return fmt.Errorf("failed to create migrations table: %w", err)
+		return nil, nil, fmt.Errorf("failed to create migrations table: %w", err)
 	}
 
 	// Get applied migrations
 	applied, err := m.getAppliedMigrations(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get applied migrations: %w", err)
+		return nil, nil, fmt.Errorf("failed to get applied migrations: %w", err)
 	}
 
 	// Load all migration files
 	migrations, err := m.loadMigrations()
 	if err != nil {
-		return fmt.Errorf("failed to load migrations: %w", err)
+		return nil, nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	if err := verifyChecksums(migrations, applied); err != nil {
+		return nil, nil, err
 	}
 
-	// Find pending migrations
-	var pending []Migration
+	return migrations, applied, nil
+}
+
+// verifyChecksums checks applied migrations against their files. Migrations applied before
+// checksums were recorded have no checksum and are trusted.
+func verifyChecksums(migrations []Migration, applied map[int]appliedMigration) error {
+	var modified []string
 	for _, migration := range migrations {
-		if !applied[migration.Version] {
-			pending = append(pending, migration)
+		record, ok := applied[migration.Version]
+		if ok && record.checksum != "" && record.checksum != migration.Checksum {
+			modified = append(modified, migration.Name)
 		}
 	}
+	if len(modified) > 0 {
+		return fmt.Errorf("%w: %s (restore the original file and add a new migration instead)", ErrMigrationModified, strings.Join(modified, ", "))
+	}
+	return nil
+}
 
-	if len(pending) == 0 {
-		log.Println("No pending migrations")
-		return nil
+// Status lists every migration known to this binary or recorded in the database, by version
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	if err := m.ensureMigrationsTable(ctx); err != nil {
+		return nil, fmt.Errorf("failed to create migrations table: %w", err)
+	}
+	applied, err := m.getAppliedMigrations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+	migrations, err := m.loadMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
 	}
 
-	log.Printf("Applying %d pending migrations", len(pending))
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, migration := range migrations {
+		status := MigrationStatus{
+			Version:    migration.Version,
+			Name:       migration.Name,
+			Reversible: migration.DownSQL != "",
+		}
+		if record, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = record.appliedAt
+			status.Modified = record.checksum != "" && record.checksum != migration.Checksum
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for version, record := range applied {
+		statuses = append(statuses, MigrationStatus{
+			Version:   version,
+			Name:      record.name,
+			Applied:   true,
+			AppliedAt: record.appliedAt,
+			Missing:   true,
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses, nil
+}
+
+// PlanUp returns the steps that apply all pending migrations
+func (m *Migrator) PlanUp(ctx context.Context) ([]MigrationStep, error) {
+	migrations, applied, err := m.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	target := 0
+	if len(migrations) > 0 {
+		target = migrations[len(migrations)-1].Version
+	}
+	return planMigrations(migrations, applied, target)
+}
+
+// PlanTo returns the steps that bring the database to the given version, applying pending
+// migrations up to it and reverting applied migrations after it
+func (m *Migrator) PlanTo(ctx context.Context, target int) ([]MigrationStep, error) {
+	migrations, applied, err := m.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return planMigrations(migrations, applied, target)
+}
+
+// PlanDown returns the steps that revert the last n applied migrations
+func (m *Migrator) PlanDown(ctx context.Context, n int) ([]MigrationStep, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("number of migrations to revert must be positive, got %d", n)
+	}
+	migrations, applied, err := m.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]int, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Ints(versions)
+
+	// Revert down to (and excluding) the n+1th most recent applied migration
+	target := 0
+	if n < len(versions) {
+		target = versions[len(versions)-n-1]
+	}
+	return planMigrations(migrations, applied, target)
+}
+
+// planMigrations returns the steps that bring the applied migrations to target: reverting
+// applied migrations after target newest first, then applying pending migrations up to it
+func planMigrations(migrations []Migration, applied map[int]appliedMigration, target int) ([]MigrationStep, error) {
+	byVersion := make(map[int]Migration, len(migrations))
+	for _, migration := range migrations {
+		byVersion[migration.Version] = migration
+	}
+	if _, ok := byVersion[target]; !ok && target != 0 {
+		return nil, fmt.Errorf("unknown migration version %d", target)
+	}
+
+	var reverted []int
+	for version := range applied {
+		if version > target {
+			reverted = append(reverted, version)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(reverted)))
+
+	var steps []MigrationStep
+	for _, version := range reverted {
+		migration, ok := byVersion[version]
+		if !ok {
+			return nil, fmt.Errorf("cannot revert migration %d (%s): it is unknown to this version of the registry", version, applied[version].name)
+		}
+		if migration.DownSQL == "" {
+			return nil, fmt.Errorf("cannot revert migration %d (%s): it has no down migration", version, migration.Name)
+		}
+		steps = append(steps, MigrationStep{Migration: migration, Down: true})
+	}
+
+	for _, migration := range migrations {
+		if _, ok := applied[migration.Version]; !ok && migration.Version <= target {
+			steps = append(steps, MigrationStep{Migration: migration})
+		}
+	}
+
+	return steps, nil
+}
+
+// Apply runs the given steps in order, each in its own transaction
+func (m *Migrator) Apply(ctx context.Context, steps []MigrationStep) error {
+	if err := m.recordMissingChecksums(ctx); err != nil {
+		return err
+	}
+
+	for _, step := range steps {
+		migration := step.Migration
+		if step.Down {
+			if err := m.revertMigration(ctx, migration); err != nil {
+				return fmt.Errorf("failed to revert migration %d (%s): %w", migration.Version, migration.Name, err)
+			}
+			log.Printf("Reverted migration %d: %s", migration.Version, migration.Name)
+			continue
+		}
 
-	// Apply each pending migration in a transaction
-	for _, migration := range pending {
 		if err := m.applyMigration(ctx, migration); err != nil {
 			return fmt.Errorf("failed to apply migration %d (%s): %w", migration.Version, migration.Name, err)
 		}
 		log.Printf("Applied migration %d: %s", migration.Version, migration.Name)
 	}
+	return nil
+}
+
+// Migrate runs all pending migrations
+func (m *Migrator) Migrate(ctx context.Context) error {
+	pending, err := m.PlanUp(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(pending) == 0 {
+		log.Println("No pending migrations")
+		return m.recordMissingChecksums(ctx)
+	}
+
+	log.Printf("Applying %d pending migrations", len(pending))
+
+	// Apply each pending migration in a transaction
+	if err := m.Apply(ctx, pending); err != nil {
+		return err
+	}
 
 	log.Println("All migrations applied successfully")
 	return nil
 }
 
+// recordMissingChecksums records the checksums of migrations applied before checksums were
+// recorded, so that later edits to them are detected
+func (m *Migrator) recordMissingChecksums(ctx context.Context) error {
+	migrations, err := m.loadMigrations()
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+	for _, migration := range migrations {
+		_, err := m.conn.Exec(ctx,
+			"UPDATE schema_migrations SET checksum = $1 WHERE version = $2 AND checksum IS NULL",
+			migration.Checksum, migration.Version)
+		if err != nil {
+			return fmt.Errorf("failed to record checksum of migration %d: %w", migration.Version, err)
+		}
+	}
+	return nil
+}
+
 // applyMigration applies a single migration in a transaction
 func (m *Migrator) applyMigration(ctx context.Context, migration Migration) error {
+	return m.inTransaction(ctx, func(tx pgx.Tx) error {
+		// Execute the migration SQL
+		if _, err := tx.Exec(ctx, migration.SQL); err != nil {
+			return fmt.Errorf("failed to execute migration SQL: %w", err)
+		}
+
+		// Record the migration as applied
+		_, err := tx.Exec(ctx,
+			"INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+			migration.Version, migration.Name, migration.Checksum)
+		if err != nil {
+			return fmt.Errorf("failed to record migration: %w", err)
+		}
+		return nil
+	})
+}
+
+// revertMigration reverts a single migration in a transaction
+func (m *Migrator) revertMigration(ctx context.Context, migration Migration) error {
+	return m.inTransaction(ctx, func(tx pgx.Tx) error {
+		// Execute the down migration SQL
+		if _, err := tx.Exec(ctx, migration.DownSQL); err != nil {
+			return fmt.Errorf("failed to execute down migration SQL: %w", err)
+		}
+
+		// Record the migration as no longer applied
+		if _, err := tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version); err != nil {
+			return fmt.Errorf("failed to unrecord migration: %w", err)
+		}
+		return nil
+	})
+}
+
+// inTransaction runs fn in a transaction on the migrator's connection
+func (m *Migrator) inTransaction(ctx context.Context, fn func(tx pgx.Tx) error) error {
 	tx, err := m.conn.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -174,18 +468,8 @@ func (m *Migrator) applyMigration(ctx context.Context, migration Migration) erro
 		}
 	}()
 
-	// Execute the migration SQL
-	_, err = tx.Exec(ctx, migration.SQL)
-	if err != nil {
-		return fmt.Errorf("failed to execute migration SQL: %w", err)
-	}
-
-	// Record the migration as applied
-	_, err = tx.Exec(ctx,
-		"INSERT INTO schema_migrations (version, name) VALUES ($1, $2)",
-		migration.Version, migration.Name)
-	if err != nil {
-		return fmt.Errorf("failed to record migration: %w", err)
+	if err := fn(tx); err != nil {
+		return err
 	}
 
 	return tx.Commit(ctx)
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := (&Migrator{}).loadMigrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, migration := range migrations {
+		assert.Equal(t, i+1, migration.Version, "migration versions must be contiguous")
+		assert.NotContains(t, migration.Name, ".down", "down migrations must not be loaded as migrations")
+		assert.Equal(t, migrationChecksum(migration.SQL), migration.Checksum)
+	}
+
+	// Down migrations are paired with the migration they revert
+	byVersion := make(map[int]Migration)
+	for _, migration := range migrations {
+		byVersion[migration.Version] = migration
+	}
+	assert.Empty(t, byVersion[1].DownSQL)
+	assert.Contains(t, byVersion[15].DownSQL, "DROP TABLE IF EXISTS server_revisions")
+}
+
+func TestPlanMigrations(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Name: "001_initial", SQL: "-- 1"},
+		{Version: 2, Name: "002_second", SQL: "-- 2", DownSQL: "-- revert 2"},
+		{Version: 3, Name: "003_third", SQL: "-- 3", DownSQL: "-- revert 3"},
+	}
+	applied := func(versions ...int) map[int]appliedMigration {
+		result := make(map[int]appliedMigration)
+		for _, version := range versions {
+			result[version] = appliedMigration{name: migrations[version-1].Name}
+		}
+		return result
+	}
+	describe := func(steps []MigrationStep) []string {
+		var result []string
+		for _, step := range steps {
+			if step.Down {
+				result = append(result, "down "+step.Migration.Name)
+			} else {
+				result = append(result, "up "+step.Migration.Name)
+			}
+		}
+		return result
+	}
+
+	t.Run("applies pending migrations in order", func(t *testing.T) {
+		steps, err := planMigrations(migrations, applied(1), 3)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"up 002_second", "up 003_third"}, describe(steps))
+	})
+
+	t.Run("stops at the target", func(t *testing.T) {
+		steps, err := planMigrations(migrations, applied(), 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"up 001_initial", "up 002_second"}, describe(steps))
+	})
+
+	t.Run("reverts newest first", func(t *testing.T) {
+		steps, err := planMigrations(migrations, applied(1, 2, 3), 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"down 003_third", "down 002_second"}, describe(steps))
+	})
+
+	t.Run("nothing to do at the target", func(t *testing.T) {
+		steps, err := planMigrations(migrations, applied(1, 2, 3), 3)
+		require.NoError(t, err)
+		assert.Empty(t, steps)
+	})
+
+	t.Run("fails without a down migration", func(t *testing.T) {
+		_, err := planMigrations(migrations, applied(1, 2, 3), 0)
+		assert.ErrorContains(t, err, "001_initial")
+	})
+
+	t.Run("fails on a migration unknown to this binary", func(t *testing.T) {
+		newer := applied(1, 2, 3)
+		newer[4] = appliedMigration{name: "004_newer"}
+		_, err := planMigrations(migrations, newer, 3)
+		assert.ErrorContains(t, err, "004_newer")
+	})
+
+	t.Run("fails on an unknown target", func(t *testing.T) {
+		_, err := planMigrations(migrations, applied(1), 7)
+		assert.Error(t, err)
+	})
+}
+
+func TestVerifyChecksums(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Name: "001_initial", Checksum: migrationChecksum("-- 1")},
+		{Version: 2, Name: "002_second", Checksum: migrationChecksum("-- 2")},
+	}
+
+	// Migrations applied before checksums were recorded are trusted
+	assert.NoError(t, verifyChecksums(migrations, map[int]appliedMigration{
+		1: {name: "001_initial"},
+		2: {name: "002_second", checksum: migrationChecksum("-- 2")},
+	}))
+
+	err := verifyChecksums(migrations, map[int]appliedMigration{
+		1: {name: "001_initial", checksum: migrationChecksum("-- 1")},
+		2: {name: "002_second", checksum: migrationChecksum("-- 2 edited")},
+	})
+	assert.ErrorIs(t, err, ErrMigrationModified)
+	assert.ErrorContains(t, err, "002_second")
+}
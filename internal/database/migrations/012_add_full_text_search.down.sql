@@ -0,0 +1,6 @@
+-- Revert 012: drop the search vector and trigram index
+-- The pg_trgm extension is left installed as other databases on the server may rely on it
+
+DROP INDEX IF EXISTS idx_servers_name_trgm;
+DROP INDEX IF EXISTS idx_servers_search_vector;
+ALTER TABLE servers DROP COLUMN IF EXISTS search_vector;
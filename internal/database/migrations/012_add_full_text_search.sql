@@ -0,0 +1,22 @@
+-- Add relevance-ranked full-text search over server name, title, description and package identifiers
+-- Trigram indexes back a typo-tolerant fallback for names that full-text matching misses
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Weighted search document, maintained by PostgreSQL on every insert and update.
+-- Punctuation in names and package identifiers is replaced with spaces so that
+-- "io.github.example/weather-mcp" is indexed as the words "io github example weather mcp".
+ALTER TABLE servers ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', regexp_replace(server_name, '[^[:alnum:]]+', ' ', 'g')), 'A') ||
+    setweight(to_tsvector('english', coalesce(value->>'title', '')), 'B') ||
+    setweight(to_tsvector('english', regexp_replace(
+        coalesce(jsonb_path_query_array(value, '$.packages[*].identifier')::text, ''),
+        '[^[:alnum:]]+', ' ', 'g'
+    )), 'B') ||
+    setweight(to_tsvector('english', coalesce(value->>'description', '')), 'C')
+) STORED;
+
+CREATE INDEX idx_servers_search_vector ON servers USING GIN (search_vector);
+
+-- Supports both the substring (ILIKE) and word similarity (<%) operators used by search
+CREATE INDEX idx_servers_name_trgm ON servers USING GIN (server_name gin_trgm_ops);
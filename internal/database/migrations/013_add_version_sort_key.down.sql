@@ -0,0 +1,4 @@
+-- Revert 013: drop the stored version sort key
+
+DROP INDEX IF EXISTS idx_servers_name_version_sort_key;
+ALTER TABLE servers DROP COLUMN IF EXISTS version_sort_key;
@@ -0,0 +1,59 @@
+-- Add a sortable version key so that versions are ordered by release rather than as text
+-- (1.10.0 after 1.9.0). The key is computed by database.VersionSortKey when a version is
+-- published; this migration backfills it for existing rows using the same encoding.
+
+ALTER TABLE servers ADD COLUMN version_sort_key TEXT COLLATE "C";
+
+CREATE OR REPLACE FUNCTION compute_version_sort_key(v TEXT, published TIMESTAMP WITH TIME ZONE)
+RETURNS TEXT AS $$
+DECLARE
+    parts TEXT[];
+    sort_key TEXT;
+    identifier TEXT;
+    identifier_count INTEGER := 0;
+BEGIN
+    -- Same rules as service.IsSemanticVersion: major.minor.patch with optional "v" prefix,
+    -- prerelease and build metadata
+    parts := regexp_match(v, '^v?(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)(?:-((?:0|[1-9][0-9]*|[0-9]*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9][0-9]*|[0-9]*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+[0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*)?$');
+
+    -- Non-semantic versions sort by publish time, below every semantic version
+    IF parts IS NULL THEN
+        RETURN '0' || to_char(published AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"');
+    END IF;
+
+    -- Length-prefixed major, minor and patch
+    sort_key := '1'
+        || lpad(length(parts[1])::TEXT, 2, '0') || parts[1]
+        || lpad(length(parts[2])::TEXT, 2, '0') || parts[2]
+        || lpad(length(parts[3])::TEXT, 2, '0') || parts[3];
+
+    -- Releases sort after all of their prereleases
+    IF parts[4] IS NULL THEN
+        RETURN sort_key || '~';
+    END IF;
+
+    sort_key := sort_key || '-';
+    FOREACH identifier IN ARRAY string_to_array(parts[4], '.') LOOP
+        IF identifier_count > 0 THEN
+            sort_key := sort_key || ' ';
+        END IF;
+        IF identifier ~ '^[0-9]+$' THEN
+            sort_key := sort_key || '0' || lpad(length(identifier)::TEXT, 2, '0') || identifier;
+        ELSE
+            sort_key := sort_key || '1' || identifier;
+        END IF;
+        identifier_count := identifier_count + 1;
+    END LOOP;
+
+    RETURN sort_key;
+END;
+$$ LANGUAGE plpgsql;
+
+UPDATE servers SET version_sort_key = compute_version_sort_key(version, published_at);
+
+DROP FUNCTION compute_version_sort_key(TEXT, TIMESTAMP WITH TIME ZONE);
+
+ALTER TABLE servers ALTER COLUMN version_sort_key SET NOT NULL;
+
+-- Supports listing servers ordered by name and release order, and cursor lookups
+CREATE INDEX idx_servers_name_version_sort_key ON servers (server_name, version_sort_key, version);
@@ -0,0 +1,5 @@
+-- Revert 014: drop the audit log
+-- Reverting discards every recorded event
+
+DROP TABLE IF EXISTS audit_events;
+DROP FUNCTION IF EXISTS reject_audit_event_changes();
@@ -0,0 +1,32 @@
+-- Add an append-only audit log of registry mutations
+-- Events are written in the same transaction as the change they describe
+
+CREATE TABLE audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    action VARCHAR(50) NOT NULL,
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    actor_method VARCHAR(50) NOT NULL,
+    actor_subject VARCHAR(255) NOT NULL DEFAULT '',
+    source_ip VARCHAR(64) NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    before JSONB,
+    after JSONB
+);
+
+CREATE INDEX idx_audit_events_server ON audit_events (server_name, id);
+CREATE INDEX idx_audit_events_actor ON audit_events (actor_subject, id);
+CREATE INDEX idx_audit_events_occurred_at ON audit_events (occurred_at);
+
+-- Reject updates and deletes so that the log cannot be rewritten through the application
+CREATE FUNCTION reject_audit_event_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION reject_audit_event_changes();
@@ -0,0 +1,4 @@
+-- Revert 015: drop the revision history
+-- Reverting discards every revision; current server versions are unaffected
+
+DROP TABLE IF EXISTS server_revisions;
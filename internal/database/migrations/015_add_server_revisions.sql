@@ -0,0 +1,17 @@
+-- Keep every revision of a server version's server.json so that admin edits can be reviewed and undone
+-- Revision 1 is the server.json as published; each edit that changes it appends the next revision
+
+CREATE TABLE server_revisions (
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    revision INTEGER NOT NULL CHECK (revision > 0),
+    value JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (server_name, version, revision),
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version) ON DELETE CASCADE
+);
+
+-- Content replaced by earlier edits is not recoverable, so existing versions start from their current state
+INSERT INTO server_revisions (server_name, version, revision, value, created_at)
+SELECT server_name, version, 1, value, COALESCE(updated_at, published_at, NOW())
+FROM servers;
@@ -0,0 +1,4 @@
+-- Revert 016: drop the change feed
+-- Mirrors following the feed must resync from scratch if it is added again
+
+DROP TABLE IF EXISTS server_changes;
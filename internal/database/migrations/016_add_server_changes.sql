@@ -0,0 +1,22 @@
+-- Add a change feed so that mirrors can sync incrementally by sequence number instead of by timestamp
+-- Every create, update and status change of a server version appends a change in the same transaction.
+-- Writers serialize on an advisory lock from their first change until commit, so changes become
+-- visible in sequence order and a reader that has seen a sequence number has seen all earlier ones.
+
+CREATE TABLE server_changes (
+    seq BIGSERIAL PRIMARY KEY,
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    change_type VARCHAR(20) NOT NULL CHECK (change_type IN ('created', 'updated', 'status_changed')),
+    status VARCHAR(50) NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_server_changes_server ON server_changes (server_name, version);
+
+-- Start the feed with every existing version, in publish order, so mirrors can bootstrap from it
+INSERT INTO server_changes (server_name, version, change_type, status, occurred_at)
+SELECT server_name, version, 'created', status, published_at
+FROM servers
+ORDER BY published_at, server_name, version;
@@ -0,0 +1,3 @@
+-- Revert 017: drop the namespace index
+
+DROP INDEX IF EXISTS idx_servers_namespace;
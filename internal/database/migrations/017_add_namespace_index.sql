@@ -0,0 +1,5 @@
+-- Index the namespace of server names for the namespace list filter
+-- The registry type, package identifier and transport filters use containment (@>) queries,
+-- which are served by the existing GIN indexes on value->'packages' and value->'remotes'
+
+CREATE INDEX idx_servers_namespace ON servers (split_part(server_name, '/', 1));
@@ -0,0 +1,7 @@
+-- Revert 018: restore the single-column time indexes
+
+DROP INDEX IF EXISTS idx_servers_published_at_keyset;
+DROP INDEX IF EXISTS idx_servers_updated_at_keyset;
+
+CREATE INDEX idx_servers_published_at ON servers (published_at DESC);
+CREATE INDEX idx_servers_updated_at ON servers (updated_at DESC);
@@ -0,0 +1,8 @@
+-- Index the keyset of listings sorted by time: the sort column, then name and version
+-- These replace the single-column time indexes, which cannot serve the tie-breakers
+
+DROP INDEX IF EXISTS idx_servers_published_at;
+DROP INDEX IF EXISTS idx_servers_updated_at;
+
+CREATE INDEX idx_servers_published_at_keyset ON servers (published_at, server_name, version);
+CREATE INDEX idx_servers_updated_at_keyset ON servers (updated_at, server_name, version);
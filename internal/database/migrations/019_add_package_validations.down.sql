@@ -0,0 +1,3 @@
+-- Revert 019: drop the package validation records
+
+DROP TABLE IF EXISTS package_validations;
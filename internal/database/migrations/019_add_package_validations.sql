@@ -0,0 +1,19 @@
+-- Keep the evidence of the registry validation of each package of each published version: which
+-- check ran, the facts the upstream registry reported and the outcome. Records are append-only;
+-- every publish or edit that validates the packages adds a record per package.
+
+CREATE TABLE package_validations (
+    id BIGSERIAL PRIMARY KEY,
+    server_name VARCHAR(255) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    package_index INTEGER NOT NULL CHECK (package_index >= 0),
+    registry_type VARCHAR(50) NOT NULL,
+    identifier TEXT NOT NULL,
+    check_name VARCHAR(100) NOT NULL,
+    outcome VARCHAR(20) NOT NULL CHECK (outcome IN ('passed', 'skipped')),
+    facts JSONB NOT NULL DEFAULT '{}',
+    validated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_package_validations_server ON package_validations (server_name, version, id);
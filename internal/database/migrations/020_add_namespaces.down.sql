@@ -0,0 +1,4 @@
+-- Revert 020: drop namespace ownership records
+-- Reverting discards every owner; publish rights fall back to token permissions alone
+
+DROP TABLE IF EXISTS namespaces;
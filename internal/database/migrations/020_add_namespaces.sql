@@ -0,0 +1,17 @@
+-- Record who owns each namespace, so that publish rights do not only depend on what a token's
+-- auth method can prove at exchange time. Owners are stable identities: a GitHub account ID
+-- rather than its login, which can be renamed and re-registered by someone else, or a domain.
+-- Existing namespaces are claimed by the next publish to them.
+
+CREATE TABLE namespaces (
+    namespace VARCHAR(255) PRIMARY KEY,
+    owner_type VARCHAR(50) NOT NULL CHECK (owner_type IN ('github', 'domain')),
+    owner_id VARCHAR(255) NOT NULL CHECK (owner_id <> ''),
+    owner_name VARCHAR(255) NOT NULL DEFAULT '',
+    verification_method VARCHAR(50) NOT NULL,
+    verified_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_namespaces_owner ON namespaces (owner_type, owner_id);
@@ -0,0 +1,5 @@
+-- Revert 021: drop webhook subscriptions
+-- Reverting discards every subscription and the delivery log, including undelivered events
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
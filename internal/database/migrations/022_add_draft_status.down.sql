@@ -0,0 +1,7 @@
+-- Revert 022: drop unpromoted drafts and disallow the draft status
+
+DELETE FROM servers WHERE status = 'draft';
+
+ALTER TABLE servers DROP CONSTRAINT check_status_valid;
+ALTER TABLE servers ADD CONSTRAINT check_status_valid
+CHECK (status IN ('active', 'deprecated', 'deleted'));
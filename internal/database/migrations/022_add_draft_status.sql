@@ -0,0 +1,6 @@
+-- Allow versions to be published as drafts, which are only visible to their publishers until
+-- they are promoted to active. Drafts are never latest and are left out of the change feed.
+
+ALTER TABLE servers DROP CONSTRAINT check_status_valid;
+ALTER TABLE servers ADD CONSTRAINT check_status_valid
+CHECK (status IN ('active', 'deprecated', 'deleted', 'draft'));
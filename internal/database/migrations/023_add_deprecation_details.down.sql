@@ -0,0 +1,6 @@
+-- Revert 023: drop the deprecation details of server versions
+
+ALTER TABLE servers DROP CONSTRAINT check_replaced_by_version_has_name;
+ALTER TABLE servers DROP COLUMN replaced_by_version;
+ALTER TABLE servers DROP COLUMN replaced_by_name;
+ALTER TABLE servers DROP COLUMN deprecation_message;
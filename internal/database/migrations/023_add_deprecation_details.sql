@@ -0,0 +1,10 @@
+-- Explain deprecations to consumers: a message, and the server (optionally a specific version
+-- of it) to move to. The replacement is checked to exist when it is set, but not kept in sync
+-- afterwards, so it is not a foreign key.
+
+ALTER TABLE servers ADD COLUMN deprecation_message TEXT;
+ALTER TABLE servers ADD COLUMN replaced_by_name VARCHAR(255);
+ALTER TABLE servers ADD COLUMN replaced_by_version VARCHAR(255);
+
+ALTER TABLE servers ADD CONSTRAINT check_replaced_by_version_has_name
+CHECK (replaced_by_version IS NULL OR replaced_by_name IS NOT NULL);
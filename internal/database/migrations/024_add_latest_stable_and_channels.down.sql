@@ -0,0 +1,5 @@
+-- Revert 024: drop channels and the latest stable flag
+
+DROP TABLE server_channels;
+DROP INDEX idx_unique_latest_stable_per_server;
+ALTER TABLE servers DROP COLUMN is_latest_stable;
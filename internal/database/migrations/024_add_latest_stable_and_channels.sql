@@ -0,0 +1,37 @@
+-- Track the latest stable version of each server next to its latest version, so that clients
+-- can avoid prereleases, and let publishers point named channels (e.g. "next", "beta") at
+-- versions of their servers.
+--
+-- Stable versions are releases, and versions that are not semantic versions: their sort keys
+-- end in "~" or start with "0" (see VersionSortKey). Like the latest version, the latest stable
+-- version is the highest active stable version, else the highest deprecated one.
+
+ALTER TABLE servers ADD COLUMN is_latest_stable BOOLEAN NOT NULL DEFAULT false;
+
+WITH ranked AS (
+    SELECT server_name, version,
+           ROW_NUMBER() OVER (
+               PARTITION BY server_name
+               ORDER BY (status = 'active') DESC, version_sort_key DESC, version DESC
+           ) AS position
+    FROM servers
+    WHERE status IN ('active', 'deprecated')
+      AND (version_sort_key LIKE '0%' OR version_sort_key LIKE '%~')
+)
+UPDATE servers s
+SET is_latest_stable = true
+FROM ranked r
+WHERE s.server_name = r.server_name AND s.version = r.version AND r.position = 1;
+
+CREATE UNIQUE INDEX idx_unique_latest_stable_per_server
+ON servers (server_name)
+WHERE is_latest_stable = true;
+
+CREATE TABLE server_channels (
+    server_name VARCHAR(255) NOT NULL,
+    channel VARCHAR(64) NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (server_name, channel),
+    FOREIGN KEY (server_name, version) REFERENCES servers (server_name, version)
+);
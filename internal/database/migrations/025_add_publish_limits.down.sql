@@ -0,0 +1,5 @@
+-- Revert 025: drop publish rate limits and quotas
+-- Reverting discards the recorded publishes and the limits set for namespaces
+
+DROP TABLE IF EXISTS publish_limits;
+DROP TABLE IF EXISTS publish_events;
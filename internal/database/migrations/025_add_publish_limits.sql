@@ -0,0 +1,23 @@
+-- Add publish rate limits and quotas
+-- publish_events records recent publishes, keyed by the token subject and the namespace
+-- publish_limits holds the limits admins set for namespaces, overriding the configured defaults
+
+CREATE TABLE publish_events (
+    id BIGSERIAL PRIMARY KEY,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    actor_subject VARCHAR(255) NOT NULL,
+    namespace VARCHAR(255) NOT NULL,
+    server_name VARCHAR(255) NOT NULL,
+    new_server BOOLEAN NOT NULL DEFAULT false
+);
+
+CREATE INDEX idx_publish_events_actor ON publish_events (actor_subject, occurred_at);
+CREATE INDEX idx_publish_events_namespace ON publish_events (namespace, occurred_at);
+CREATE INDEX idx_publish_events_occurred_at ON publish_events (occurred_at);
+
+CREATE TABLE publish_limits (
+    namespace VARCHAR(255) PRIMARY KEY,
+    publishes_per_hour INTEGER NOT NULL CHECK (publishes_per_hour >= 0),
+    new_servers_per_day INTEGER NOT NULL CHECK (new_servers_per_day >= 0),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
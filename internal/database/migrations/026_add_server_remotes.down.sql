@@ -0,0 +1,4 @@
+-- Revert 026: drop the remote URL owners
+-- Remote URLs are still stored in each server.json, so nothing is lost
+
+DROP TABLE IF EXISTS server_remotes;
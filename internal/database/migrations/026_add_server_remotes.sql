@@ -0,0 +1,47 @@
+-- Track which server owns each remote URL, so that publishes claim URLs with an indexed insert
+-- rather than scanning every server.json, and concurrent publishes of different servers cannot
+-- both claim the same URL. URLs are keyed by database.NormalizeRemoteURL: scheme and host
+-- lowercased and trailing slashes removed.
+
+CREATE TABLE server_remotes (
+    normalized_url TEXT PRIMARY KEY,
+    server_name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Supports releasing the URLs of a server
+CREATE INDEX idx_server_remotes_server_name ON server_remotes (server_name);
+
+-- Same rules as database.NormalizeRemoteURL
+CREATE OR REPLACE FUNCTION normalize_remote_url(url TEXT)
+RETURNS TEXT AS $$
+DECLARE
+    rest TEXT;
+BEGIN
+    IF position('://' IN url) = 0 THEN
+        RETURN rtrim(url, '/');
+    END IF;
+
+    rest := substr(url, position('://' IN url) + 3);
+    RETURN rtrim(
+        lower(split_part(url, '://', 1)) || '://'
+            || lower(substring(rest FROM '^[^/?#]*'))
+            || COALESCE(substring(rest FROM '[/?#].*$'), ''),
+        '/');
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+-- Every version of a server holds on to its remote URLs, whatever its status. URLs that
+-- normalize the same but are listed by several servers go to the server that published first.
+INSERT INTO server_remotes (normalized_url, server_name, created_at)
+SELECT DISTINCT ON (normalized_url) normalized_url, server_name, published_at
+FROM (
+    SELECT normalize_remote_url(remote->>'url') AS normalized_url, s.server_name, s.published_at
+    FROM servers s, jsonb_array_elements(
+        CASE WHEN jsonb_typeof(s.value->'remotes') = 'array' THEN s.value->'remotes' ELSE '[]'::jsonb END
+    ) AS remote
+    WHERE remote->>'url' IS NOT NULL
+) AS remotes
+ORDER BY normalized_url, published_at, server_name;
+
+DROP FUNCTION normalize_remote_url(TEXT);
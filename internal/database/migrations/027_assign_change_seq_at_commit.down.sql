@@ -0,0 +1,12 @@
+-- Revert 027: assign change feed sequence numbers at insert again
+-- Writers must then hold the change feed lock from their first change until commit
+
+DROP TRIGGER IF EXISTS server_changes_assign_seq ON server_changes;
+DROP FUNCTION IF EXISTS assign_server_change_seq();
+
+ALTER TABLE server_changes DROP CONSTRAINT IF EXISTS server_changes_seq_key;
+ALTER TABLE server_changes DROP CONSTRAINT IF EXISTS server_changes_pkey;
+ALTER TABLE server_changes DROP COLUMN IF EXISTS id;
+ALTER TABLE server_changes ALTER COLUMN seq SET DEFAULT nextval('server_changes_seq_seq');
+ALTER TABLE server_changes ALTER COLUMN seq SET NOT NULL;
+ALTER TABLE server_changes ADD PRIMARY KEY (seq);
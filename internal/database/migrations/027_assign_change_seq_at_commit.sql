@@ -0,0 +1,29 @@
+-- Assign change feed sequence numbers at commit instead of at insert, so that writers no longer
+-- serialize on the change feed lock from their first change until commit.
+-- Changes are inserted without a sequence number. A deferred constraint trigger, which runs as
+-- the transaction commits after every other statement, takes the change feed lock and numbers
+-- the transaction's changes. The lock is released once the commit is visible, so sequence
+-- numbers still become visible in order: a reader that has seen a sequence number has seen all
+-- earlier ones. No other lock is taken after the change feed lock, so it cannot deadlock with
+-- the publish locks or row locks writers hold.
+
+ALTER TABLE server_changes DROP CONSTRAINT server_changes_pkey;
+ALTER TABLE server_changes ADD COLUMN id BIGSERIAL PRIMARY KEY;
+ALTER TABLE server_changes ALTER COLUMN seq DROP NOT NULL;
+ALTER TABLE server_changes ALTER COLUMN seq DROP DEFAULT;
+ALTER TABLE server_changes ADD CONSTRAINT server_changes_seq_key UNIQUE (seq);
+
+CREATE FUNCTION assign_server_change_seq() RETURNS TRIGGER AS $$
+BEGIN
+    -- The change feed lock; the two-key form never conflicts with the single-key publish locks
+    PERFORM pg_advisory_xact_lock(1, 0);
+    UPDATE server_changes SET seq = nextval('server_changes_seq_seq') WHERE id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Deferred row triggers fire in insertion order, so a transaction's changes keep their order
+CREATE CONSTRAINT TRIGGER server_changes_assign_seq
+    AFTER INSERT ON server_changes
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION assign_server_change_seq();
@@ -0,0 +1,4 @@
+-- Revert 028: drop the diff of audited changes
+-- The full before and after snapshots are kept
+
+ALTER TABLE audit_events DROP COLUMN IF EXISTS diff;
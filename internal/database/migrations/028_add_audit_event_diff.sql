@@ -0,0 +1,5 @@
+-- Record what each audited change did as an RFC 6902 JSON Patch from the version before the change to the version after it
+-- before and after are kept alongside it as full snapshots of the version, so that it can be restored
+-- or inspected as it was; the diff is NULL for publishes and for events recorded before this migration
+
+ALTER TABLE audit_events ADD COLUMN diff JSONB;
@@ -19,7 +19,9 @@ import (
 
 // PostgreSQL is an implementation of the Database interface using PostgreSQL
 type PostgreSQL struct {
-	pool *pgxpool.Pool
+	pool        *pgxpool.Pool
+	replica     *readReplica // optional, see WithReadReplica
+	autoMigrate bool         // see WithoutAutoMigrate
 }
 
 // Executor is an interface for executing queries (satisfied by both pgx.Tx and pgxpool.Pool)
@@ -29,16 +31,28 @@ type Executor interface {
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 }
 
-// getExecutor returns the appropriate executor (transaction or pool)
-func (db *PostgreSQL) getExecutor(tx pgx.Tx) Executor {
-	if tx != nil {
-		return tx
+// getExecutor returns the appropriate executor (transaction or primary pool)
+func (db *PostgreSQL) getExecutor(tx Tx) Executor {
+	if pgTx, ok := tx.(pgx.Tx); ok {
+		return pgTx
 	}
 	return db.pool
 }
 
+// PostgreSQLOption configures optional behaviour of NewPostgreSQL
+type PostgreSQLOption func(ctx context.Context, db *PostgreSQL) error
+
+// WithoutAutoMigrate stops NewPostgreSQL from applying pending migrations, which are then
+// applied with 'registry migrate'. Pending migrations are logged instead.
+func WithoutAutoMigrate() PostgreSQLOption {
+	return func(_ context.Context, db *PostgreSQL) error {
+		db.autoMigrate = false
+		return nil
+	}
+}
+
 // NewPostgreSQL creates a new instance of the PostgreSQL database
-func NewPostgreSQL(ctx context.Context, connectionURI string) (*PostgreSQL, error) {
+func NewPostgreSQL(ctx context.Context, connectionURI string, opts ...PostgreSQLOption) (*PostgreSQL, error) {
 	// Parse connection config for pool settings
 	config, err := pgxpool.ParseConfig(connectionURI)
 	if err != nil {
@@ -62,26 +76,60 @@ func NewPostgreSQL(ctx context.Context, connectionURI string) (*PostgreSQL, erro
 		return nil, fmt.Errorf("failed to ping PostgreSQL: %w", err)
 	}
 
+	db := &PostgreSQL{
+		pool:        pool,
+		autoMigrate: true,
+	}
+	for _, opt := range opts {
+		if err := opt(ctx, db); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+	}
+
 	// Run migrations using a single connection from the pool
-	conn, err := pool.Acquire(ctx)
+	if err := db.migrate(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// migrate applies pending migrations, or only reports them when auto-migration is disabled
+func (db *PostgreSQL) migrate(ctx context.Context) error {
+	conn, err := db.pool.Acquire(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to acquire connection for migrations: %w", err)
+		return fmt.Errorf("failed to acquire connection for migrations: %w", err)
 	}
 	defer conn.Release()
 
 	migrator := NewMigrator(conn.Conn())
-	if err := migrator.Migrate(ctx); err != nil {
-		return nil, fmt.Errorf("failed to run database migrations: %w", err)
+	if db.autoMigrate {
+		if err := migrator.Migrate(ctx); err != nil {
+			return fmt.Errorf("failed to run database migrations: %w", err)
+		}
+		return nil
 	}
 
-	return &PostgreSQL{
-		pool: pool,
-	}, nil
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get migration status: %w", err)
+	}
+	for _, status := range statuses {
+		switch {
+		case !status.Applied:
+			log.Printf("Migration %d (%s) is pending: run 'registry migrate up' to apply it", status.Version, status.Name)
+		case status.Modified:
+			log.Printf("Migration %d (%s) was modified after it was applied", status.Version, status.Name)
+		}
+	}
+	return nil
 }
 
 func (db *PostgreSQL) ListServers(
 	ctx context.Context,
-	tx pgx.Tx,
+	tx Tx,
 	filter *ServerFilter,
 	cursor string,
 	limit int,
@@ -95,94 +143,115 @@ func (db *PostgreSQL) ListServers(
 	}
 
 	// Build WHERE clause for filtering using dedicated columns
-	var whereConditions []string
-	args := []any{}
-	argIndex := 1
+	whereConditions, args := serverFilterConditions(filter)
+	argIndex := len(args) + 1
+
+	// Search results are ranked unless another order is requested; everything else is ordered
+	// by name and release order by default
+	sort, ranked := serverSort(filter)
+	search := filter != nil && filter.Search != nil
+	rankExpr := "0::real"
+	if search {
+		whereConditions = append(whereConditions, fmt.Sprintf(searchMatchSQL, argIndex, argIndex+1))
+		rankExpr = fmt.Sprintf(searchRankSQL, argIndex)
+		args = append(args, *filter.Search, "%"+*filter.Search+"%")
+		argIndex += 2
+	}
+
+	direction, after := "", ">"
+	if sort.Descending {
+		direction, after = " DESC", "<"
+	}
+	var orderBy string
+	switch {
+	case ranked:
+		orderBy = "search_rank DESC, server_name, version_sort_key, version"
+	case sort.byTime():
+		orderBy = fmt.Sprintf("%[1]s%[2]s, server_name%[2]s, version%[2]s", sort.Field, direction)
+	default:
+		orderBy = fmt.Sprintf("server_name%[1]s, version_sort_key%[1]s, version%[1]s", direction)
+	}
 
-	// Add filters using dedicated columns for better performance
-	if filter != nil {
-		if filter.Name != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("server_name = $%d", argIndex))
-			args = append(args, *filter.Name)
-			argIndex++
-		}
-		if filter.RemoteURL != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("EXISTS (SELECT 1 FROM jsonb_array_elements(value->'remotes') AS remote WHERE remote->>'url' = $%d)", argIndex))
-			args = append(args, *filter.RemoteURL)
-			argIndex++
-		}
-		if filter.UpdatedSince != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("updated_at > $%d", argIndex))
-			args = append(args, *filter.UpdatedSince)
-			argIndex++
-		}
-		if filter.SubstringName != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("server_name ILIKE $%d", argIndex))
-			args = append(args, "%"+*filter.SubstringName+"%")
-			argIndex++
-		}
-		if filter.Version != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("version = $%d", argIndex))
-			args = append(args, *filter.Version)
-			argIndex++
-		}
-		if filter.IsLatest != nil {
-			whereConditions = append(whereConditions, fmt.Sprintf("is_latest = $%d", argIndex))
-			args = append(args, *filter.IsLatest)
-			argIndex++
-		}
+	// Build the WHERE clause
+	whereClause := ""
+	if len(whereConditions) > 0 {
+		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
 	}
 
-	// Add cursor pagination using compound serverName:version cursor
+	// Add cursor pagination, applied to the ranked rows. Versions of the cursor's server are
+	// compared by their sort key, looked up from the cursor's row, then by the version string.
+	var cursorConditions []string
 	if cursor != "" {
-		// Parse cursor format: "serverName:version"
-		parts := strings.SplitN(cursor, ":", 2)
-		if len(parts) == 2 {
-			cursorServerName := parts[0]
-			cursorVersion := parts[1]
-
-			// Use compound condition: (server_name > cursor_name) OR (server_name = cursor_name AND version > cursor_version)
-			whereConditions = append(whereConditions, fmt.Sprintf("(server_name > $%d OR (server_name = $%d AND version > $%d))", argIndex, argIndex+1, argIndex+2))
-			args = append(args, cursorServerName, cursorServerName, cursorVersion)
+		switch {
+		case ranked:
+			rank, cursorServerName, cursorVersion, err := parseSearchCursor(cursor)
+			if err != nil {
+				return nil, "", err
+			}
+			// Rows after the cursor have a lower rank, or the same rank and a later name:version
+			cursorConditions = append(cursorConditions, fmt.Sprintf(
+				"(search_rank < $%[1]d OR (search_rank = $%[1]d AND (server_name > $%[2]d OR (server_name = $%[2]d AND %[3]s))))",
+				argIndex, argIndex+1, versionAfterCursorSQL(">", argIndex+1, argIndex+2)))
+			args = append(args, rank, cursorServerName, cursorVersion)
 			argIndex += 3
-		} else {
-			// Fallback for malformed cursor - treat as server name only for backwards compatibility
-			whereConditions = append(whereConditions, fmt.Sprintf("server_name > $%d", argIndex))
-			args = append(args, cursor)
-			argIndex++
+		case sort.byTime():
+			cursorTime, cursorServerName, cursorVersion, err := parseTimeCursor(cursor)
+			if err != nil {
+				return nil, "", err
+			}
+			cursorConditions = append(cursorConditions, fmt.Sprintf("(%s, server_name, version) %s ($%d, $%d, $%d)",
+				sort.Field, after, argIndex, argIndex+1, argIndex+2))
+			args = append(args, cursorTime, cursorServerName, cursorVersion)
+			argIndex += 3
+		default:
+			cursorServerName, cursorVersion, err := parseNameCursor(cursor)
+			if err != nil {
+				return nil, "", err
+			}
+			// Use compound condition: (server_name after cursor_name) OR (server_name = cursor_name AND version after cursor_version)
+			cursorConditions = append(cursorConditions, fmt.Sprintf("(server_name %[1]s $%[2]d OR (server_name = $%[2]d AND %[3]s))",
+				after, argIndex, versionAfterCursorSQL(after, argIndex, argIndex+1)))
+			args = append(args, cursorServerName, cursorVersion)
+			argIndex += 2
 		}
 	}
 
-	// Build the WHERE clause
-	whereClause := ""
-	if len(whereConditions) > 0 {
-		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
+	cursorClause := ""
+	if len(cursorConditions) > 0 {
+		cursorClause = "WHERE " + strings.Join(cursorConditions, " AND ")
 	}
 
 	// Query servers table with hybrid column/JSON data
 	query := fmt.Sprintf(`
-        SELECT server_name, version, status, published_at, updated_at, is_latest, value
-        FROM servers
-        %s
-        ORDER BY server_name, version
-        LIMIT $%d
-    `, whereClause, argIndex)
+        SELECT server_name, version, status, published_at, updated_at, is_latest, is_latest_stable, value, %[1]s, search_rank
+        FROM (
+            SELECT server_name, version, version_sort_key, status, published_at, updated_at, is_latest, is_latest_stable, value, %[1]s, %[2]s AS search_rank
+            FROM servers
+            %[3]s
+        ) ranked
+        %[4]s
+        ORDER BY %[5]s
+        LIMIT $%[6]d
+    `, statusDetailColumns, rankExpr, whereClause, cursorClause, orderBy, argIndex)
 	args = append(args, limit)
 
-	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	rows, err := db.getReadExecutor(ctx, tx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to query servers: %w", err)
 	}
 	defer rows.Close()
 
 	var results []*apiv0.ServerResponse
+	var lastRank float32
 	for rows.Next() {
 		var serverName, version, status string
 		var publishedAt, updatedAt time.Time
-		var isLatest bool
+		var isLatest, isLatestStable bool
 		var valueJSON []byte
+		var details statusDetailColumnValues
 
-		err := rows.Scan(&serverName, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+		err := rows.Scan(&serverName, &version, &status, &publishedAt, &updatedAt, &isLatest, &isLatestStable, &valueJSON,
+			&details.message, &details.replacedByName, &details.replacedByVersion, &lastRank)
 		if err != nil {
 			return nil, "", fmt.Errorf("failed to scan server row: %w", err)
 		}
@@ -197,12 +266,13 @@ func (db *PostgreSQL) ListServers(
 		serverResponse := &apiv0.ServerResponse{
 			Server: serverJSON,
 			Meta: apiv0.ResponseMeta{
-				Official: &apiv0.RegistryExtensions{
-					Status:      model.Status(status),
-					PublishedAt: publishedAt,
-					UpdatedAt:   updatedAt,
-					IsLatest:    isLatest,
-				},
+				Official: details.apply(&apiv0.RegistryExtensions{
+					Status:         model.Status(status),
+					PublishedAt:    publishedAt,
+					UpdatedAt:      updatedAt,
+					IsLatest:       isLatest,
+					IsLatestStable: isLatestStable,
+				}),
 			},
 		}
 
@@ -213,24 +283,125 @@ func (db *PostgreSQL) ListServers(
 		return nil, "", fmt.Errorf("error iterating rows: %w", err)
 	}
 
-	// Determine next cursor using compound serverName:version format
+	// Determine next cursor using compound serverName:version format, prefixed with the rank for
+	// ranked searches or the sort time for listings sorted by time
 	nextCursor := ""
 	if len(results) > 0 && len(results) >= limit {
 		lastResult := results[len(results)-1]
-		nextCursor = lastResult.Server.Name + ":" + lastResult.Server.Version
+		official := lastResult.Meta.Official
+		switch {
+		case ranked:
+			nextCursor = formatSearchCursor(lastRank, lastResult.Server.Name, lastResult.Server.Version)
+		case sort.byTime():
+			nextCursor = formatTimeCursor(sort.sortTime(official.UpdatedAt, official.PublishedAt), lastResult.Server.Name, lastResult.Server.Version)
+		default:
+			nextCursor = formatNameCursor(lastResult.Server.Name, lastResult.Server.Version)
+		}
 	}
 
 	return results, nextCursor, nil
 }
 
+// versionAfterCursorSQL selects versions that follow the cursor's version of the same server in
+// release order ("after" is ">") or reverse release order ("<"), given the placeholders holding
+// the cursor's server name and version
+func versionAfterCursorSQL(after string, nameArg, versionArg int) string {
+	return fmt.Sprintf("(version_sort_key, version) %[3]s ((SELECT version_sort_key FROM servers WHERE server_name = $%[1]d AND version = $%[2]d), $%[2]d)", nameArg, versionArg, after)
+}
+
+// serverFilterConditions translates a filter into WHERE conditions on the dedicated columns,
+// numbering placeholders from $1
+func serverFilterConditions(filter *ServerFilter) ([]string, []any) {
+	var whereConditions []string
+	args := []any{}
+	argIndex := 1
+
+	if filter == nil || !filter.IncludeDrafts {
+		whereConditions = append(whereConditions, "status <> 'draft'")
+	}
+	if filter == nil {
+		return whereConditions, args
+	}
+
+	if filter.Name != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("server_name = $%d", argIndex))
+		args = append(args, *filter.Name)
+		argIndex++
+	}
+	// Conditions on packages and remotes are containment queries, served by their GIN indexes
+	if filter.RemoteURL != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("value->'remotes' @> jsonb_build_array(jsonb_build_object('url', $%d::text))", argIndex))
+		args = append(args, *filter.RemoteURL)
+		argIndex++
+	}
+	if filter.UpdatedSince != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("updated_at > $%d", argIndex))
+		args = append(args, *filter.UpdatedSince)
+		argIndex++
+	}
+	if filter.SubstringName != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("server_name ILIKE $%d", argIndex))
+		args = append(args, "%"+*filter.SubstringName+"%")
+		argIndex++
+	}
+	if filter.Version != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("version = $%d", argIndex))
+		args = append(args, *filter.Version)
+		argIndex++
+	}
+	if filter.IsLatest != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("is_latest = $%d", argIndex))
+		args = append(args, *filter.IsLatest)
+		argIndex++
+	}
+	if filter.IsLatestStable != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("is_latest_stable = $%d", argIndex))
+		args = append(args, *filter.IsLatestStable)
+		argIndex++
+	}
+	if filter.RegistryType != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("value->'packages' @> jsonb_build_array(jsonb_build_object('registryType', $%d::text))", argIndex))
+		args = append(args, *filter.RegistryType)
+		argIndex++
+	}
+	if filter.PackageIdentifier != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("value->'packages' @> jsonb_build_array(jsonb_build_object('identifier', $%d::text))", argIndex))
+		args = append(args, *filter.PackageIdentifier)
+		argIndex++
+	}
+	if filter.TransportType != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf(
+			"(value->'remotes' @> jsonb_build_array(jsonb_build_object('type', $%[1]d::text)) OR value->'packages' @> jsonb_build_array(jsonb_build_object('transport', jsonb_build_object('type', $%[1]d::text))))",
+			argIndex))
+		args = append(args, *filter.TransportType)
+		argIndex++
+	}
+	if filter.Status != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+	if filter.Namespace != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("split_part(server_name, '/', 1) = $%d", argIndex))
+		args = append(args, *filter.Namespace)
+		argIndex++
+	}
+	if filter.PublishedSince != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("published_at > $%d", argIndex))
+		args = append(args, *filter.PublishedSince)
+	}
+
+	return whereConditions, args
+}
+
 // GetServerByName retrieves the latest version of a server by server name
-func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerResponse, error) {
+func (db *PostgreSQL) GetServerByName(ctx context.Context, tx Tx, serverName string) (*apiv0.ServerResponse, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
 
 	query := `
-		SELECT server_name, version, status, published_at, updated_at, is_latest, value
+		SELECT server_name, version, status, published_at, updated_at, is_latest, is_latest_stable, value, ` + statusDetailColumns + `
 		FROM servers
 		WHERE server_name = $1 AND is_latest = true
 		ORDER BY published_at DESC
@@ -239,10 +410,12 @@ func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName
 
 	var name, version, status string
 	var publishedAt, updatedAt time.Time
-	var isLatest bool
+	var isLatest, isLatestStable bool
 	var valueJSON []byte
+	var details statusDetailColumnValues
 
-	err := db.getExecutor(tx).QueryRow(ctx, query, serverName).Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+	err := db.getReadExecutor(ctx, tx).QueryRow(ctx, query, serverName).Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &isLatestStable, &valueJSON,
+		&details.message, &details.replacedByName, &details.replacedByVersion)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -260,12 +433,13 @@ func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName
 	serverResponse := &apiv0.ServerResponse{
 		Server: serverJSON,
 		Meta: apiv0.ResponseMeta{
-			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(status),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
-			},
+			Official: details.apply(&apiv0.RegistryExtensions{
+				Status:         model.Status(status),
+				PublishedAt:    publishedAt,
+				UpdatedAt:      updatedAt,
+				IsLatest:       isLatest,
+				IsLatestStable: isLatestStable,
+			}),
 		},
 	}
 
@@ -273,13 +447,13 @@ func (db *PostgreSQL) GetServerByName(ctx context.Context, tx pgx.Tx, serverName
 }
 
 // GetServerByNameAndVersion retrieves a specific version of a server by server name and version
-func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx, serverName string, version string) (*apiv0.ServerResponse, error) {
+func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx Tx, serverName string, version string) (*apiv0.ServerResponse, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
 
 	query := `
-		SELECT server_name, version, status, published_at, updated_at, is_latest, value
+		SELECT server_name, version, status, published_at, updated_at, is_latest, is_latest_stable, value, ` + statusDetailColumns + `
 		FROM servers
 		WHERE server_name = $1 AND version = $2
 		LIMIT 1
@@ -287,10 +461,12 @@ func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx,
 
 	var name, vers, status string
 	var publishedAt, updatedAt time.Time
-	var isLatest bool
+	var isLatest, isLatestStable bool
 	var valueJSON []byte
+	var details statusDetailColumnValues
 
-	err := db.getExecutor(tx).QueryRow(ctx, query, serverName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+	err := db.getReadExecutor(ctx, tx).QueryRow(ctx, query, serverName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest, &isLatestStable, &valueJSON,
+		&details.message, &details.replacedByName, &details.replacedByVersion)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -308,32 +484,33 @@ func (db *PostgreSQL) GetServerByNameAndVersion(ctx context.Context, tx pgx.Tx,
 	serverResponse := &apiv0.ServerResponse{
 		Server: serverJSON,
 		Meta: apiv0.ResponseMeta{
-			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(status),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
-			},
+			Official: details.apply(&apiv0.RegistryExtensions{
+				Status:         model.Status(status),
+				PublishedAt:    publishedAt,
+				UpdatedAt:      updatedAt,
+				IsLatest:       isLatest,
+				IsLatestStable: isLatestStable,
+			}),
 		},
 	}
 
 	return serverResponse, nil
 }
 
-// GetAllVersionsByServerName retrieves all versions of a server by server name
-func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx, serverName string) ([]*apiv0.ServerResponse, error) {
+// GetAllVersionsByServerName retrieves all versions of a server by server name, highest version first
+func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx Tx, serverName string) ([]*apiv0.ServerResponse, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
 
 	query := `
-		SELECT server_name, version, status, published_at, updated_at, is_latest, value
+		SELECT server_name, version, status, published_at, updated_at, is_latest, is_latest_stable, value, ` + statusDetailColumns + `
 		FROM servers
 		WHERE server_name = $1
-		ORDER BY published_at DESC
+		ORDER BY version_sort_key DESC, version DESC
 	`
 
-	rows, err := db.getExecutor(tx).Query(ctx, query, serverName)
+	rows, err := db.getReadExecutor(ctx, tx).Query(ctx, query, serverName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query server versions: %w", err)
 	}
@@ -343,10 +520,12 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 	for rows.Next() {
 		var name, version, status string
 		var publishedAt, updatedAt time.Time
-		var isLatest bool
+		var isLatest, isLatestStable bool
 		var valueJSON []byte
+		var details statusDetailColumnValues
 
-		err := rows.Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+		err := rows.Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &isLatestStable, &valueJSON,
+			&details.message, &details.replacedByName, &details.replacedByVersion)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan server row: %w", err)
 		}
@@ -361,12 +540,13 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 		serverResponse := &apiv0.ServerResponse{
 			Server: serverJSON,
 			Meta: apiv0.ResponseMeta{
-				Official: &apiv0.RegistryExtensions{
-					Status:      model.Status(status),
-					PublishedAt: publishedAt,
-					UpdatedAt:   updatedAt,
-					IsLatest:    isLatest,
-				},
+				Official: details.apply(&apiv0.RegistryExtensions{
+					Status:         model.Status(status),
+					PublishedAt:    publishedAt,
+					UpdatedAt:      updatedAt,
+					IsLatest:       isLatest,
+					IsLatestStable: isLatestStable,
+				}),
 			},
 		}
 
@@ -385,7 +565,7 @@ func (db *PostgreSQL) GetAllVersionsByServerName(ctx context.Context, tx pgx.Tx,
 }
 
 // CreateServer inserts a new server version with official metadata
-func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *apiv0.ServerJSON, officialMeta *apiv0.RegistryExtensions) (*apiv0.ServerResponse, error) {
+func (db *PostgreSQL) CreateServer(ctx context.Context, tx Tx, serverJSON *apiv0.ServerJSON, officialMeta *apiv0.RegistryExtensions) (*apiv0.ServerResponse, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
@@ -407,8 +587,8 @@ func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *a
 
 	// Insert the new server version using composite primary key
 	insertQuery := `
-		INSERT INTO servers (server_name, version, status, published_at, updated_at, is_latest, value)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO servers (server_name, version, status, published_at, updated_at, is_latest, is_latest_stable, value, version_sort_key)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
 	_, err = db.getExecutor(tx).Exec(ctx, insertQuery,
@@ -418,13 +598,23 @@ func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *a
 		officialMeta.PublishedAt,
 		officialMeta.UpdatedAt,
 		officialMeta.IsLatest,
+		officialMeta.IsLatestStable,
 		valueJSON,
+		VersionSortKey(serverJSON.Version, officialMeta.PublishedAt),
 	)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert server: %w", err)
 	}
 
+	// Keep the server.json as published as the first revision
+	if err := db.appendServerRevision(ctx, tx, serverJSON.Name, serverJSON.Version, valueJSON); err != nil {
+		return nil, err
+	}
+	if err := db.appendServerChange(ctx, tx, serverJSON.Name, serverJSON.Version, ChangeTypeCreated); err != nil {
+		return nil, err
+	}
+
 	// Return the complete ServerResponse
 	serverResponse := &apiv0.ServerResponse{
 		Server: *serverJSON,
@@ -436,8 +626,8 @@ func (db *PostgreSQL) CreateServer(ctx context.Context, tx pgx.Tx, serverJSON *a
 	return serverResponse, nil
 }
 
-// UpdateServer updates an existing server record with new server details
-func (db *PostgreSQL) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, version string, serverJSON *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+// UpdateServer updates an existing server record with new server details, recording them as a new revision
+func (db *PostgreSQL) UpdateServer(ctx context.Context, tx Tx, serverName, version string, serverJSON *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
@@ -463,14 +653,16 @@ func (db *PostgreSQL) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, v
 		UPDATE servers
 		SET value = $1, updated_at = NOW()
 		WHERE server_name = $2 AND version = $3
-		RETURNING server_name, version, status, published_at, updated_at, is_latest
+		RETURNING server_name, version, status, published_at, updated_at, is_latest, is_latest_stable, ` + statusDetailColumns + `
 	`
 
 	var name, vers, status string
 	var publishedAt, updatedAt time.Time
-	var isLatest bool
+	var isLatest, isLatestStable bool
+	var details statusDetailColumnValues
 
-	err = db.getExecutor(tx).QueryRow(ctx, query, valueJSON, serverName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest)
+	err = db.getExecutor(tx).QueryRow(ctx, query, valueJSON, serverName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest, &isLatestStable,
+		&details.message, &details.replacedByName, &details.replacedByVersion)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -478,42 +670,55 @@ func (db *PostgreSQL) UpdateServer(ctx context.Context, tx pgx.Tx, serverName, v
 		return nil, fmt.Errorf("failed to update server: %w", err)
 	}
 
+	// Record the new server.json as the next revision
+	if err := db.appendServerRevision(ctx, tx, serverName, version, valueJSON); err != nil {
+		return nil, err
+	}
+	if err := db.appendServerChange(ctx, tx, serverName, version, ChangeTypeUpdated); err != nil {
+		return nil, err
+	}
+
 	// Return the updated ServerResponse
 	serverResponse := &apiv0.ServerResponse{
 		Server: *serverJSON,
 		Meta: apiv0.ResponseMeta{
-			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(status),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
-			},
+			Official: details.apply(&apiv0.RegistryExtensions{
+				Status:         model.Status(status),
+				PublishedAt:    publishedAt,
+				UpdatedAt:      updatedAt,
+				IsLatest:       isLatest,
+				IsLatestStable: isLatestStable,
+			}),
 		},
 	}
 
 	return serverResponse, nil
 }
 
-// SetServerStatus updates the status of a specific server version
-func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName, version string, status string) (*apiv0.ServerResponse, error) {
+// SetServerStatus updates the status of a specific server version, replacing its status details
+func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx Tx, serverName, version string, status string, statusDetails *StatusDetails) (*apiv0.ServerResponse, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
 
-	// Update the status column
+	// Update the status columns
 	query := `
 		UPDATE servers
-		SET status = $1, updated_at = NOW()
+		SET status = $1, deprecation_message = $4, replaced_by_name = $5, replaced_by_version = $6, updated_at = NOW()
 		WHERE server_name = $2 AND version = $3
-		RETURNING server_name, version, status, value, published_at, updated_at, is_latest
+		RETURNING server_name, version, status, value, published_at, updated_at, is_latest, is_latest_stable, ` + statusDetailColumns + `
 	`
 
 	var name, vers, currentStatus string
 	var publishedAt, updatedAt time.Time
-	var isLatest bool
+	var isLatest, isLatestStable bool
 	var valueJSON []byte
+	var details statusDetailColumnValues
 
-	err := db.getExecutor(tx).QueryRow(ctx, query, status, serverName, version).Scan(&name, &vers, &currentStatus, &valueJSON, &publishedAt, &updatedAt, &isLatest)
+	message, replacedByName, replacedByVersion := statusDetailArgs(statusDetails)
+	err := db.getExecutor(tx).QueryRow(ctx, query, status, serverName, version, message, replacedByName, replacedByVersion).Scan(
+		&name, &vers, &currentStatus, &valueJSON, &publishedAt, &updatedAt, &isLatest, &isLatestStable,
+		&details.message, &details.replacedByName, &details.replacedByVersion)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrNotFound
@@ -521,6 +726,10 @@ func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName
 		return nil, fmt.Errorf("failed to update server status: %w", err)
 	}
 
+	if err := db.appendServerChange(ctx, tx, serverName, version, ChangeTypeStatusChanged); err != nil {
+		return nil, err
+	}
+
 	// Unmarshal the JSON data
 	var serverJSON apiv0.ServerJSON
 	if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
@@ -531,12 +740,13 @@ func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName
 	serverResponse := &apiv0.ServerResponse{
 		Server: serverJSON,
 		Meta: apiv0.ResponseMeta{
-			Official: &apiv0.RegistryExtensions{
-				Status:      model.Status(currentStatus),
-				PublishedAt: publishedAt,
-				UpdatedAt:   updatedAt,
-				IsLatest:    isLatest,
-			},
+			Official: details.apply(&apiv0.RegistryExtensions{
+				Status:         model.Status(currentStatus),
+				PublishedAt:    publishedAt,
+				UpdatedAt:      updatedAt,
+				IsLatest:       isLatest,
+				IsLatestStable: isLatestStable,
+			}),
 		},
 	}
 
@@ -544,7 +754,7 @@ func (db *PostgreSQL) SetServerStatus(ctx context.Context, tx pgx.Tx, serverName
 }
 
 // InTransaction executes a function within a database transaction
-func (db *PostgreSQL) InTransaction(ctx context.Context, fn func(ctx context.Context, tx pgx.Tx) error) error {
+func (db *PostgreSQL) InTransaction(ctx context.Context, fn func(ctx context.Context, tx Tx) error) error {
 	if ctx.Err() != nil {
 		return ctx.Err()
 	}
@@ -576,7 +786,7 @@ func (db *PostgreSQL) InTransaction(ctx context.Context, fn func(ctx context.Con
 // AcquirePublishLock acquires an exclusive advisory lock for publishing a server
 // This prevents race conditions when multiple versions are published concurrently
 // Using pg_advisory_xact_lock which auto-releases on transaction end
-func (db *PostgreSQL) AcquirePublishLock(ctx context.Context, tx pgx.Tx, serverName string) error {
+func (db *PostgreSQL) AcquirePublishLock(ctx context.Context, tx Tx, serverName string) error {
 	if ctx.Err() != nil {
 		return ctx.Err()
 	}
@@ -607,12 +817,12 @@ func hashServerName(name string) int64 {
 }
 
 // GetCurrentLatestVersion retrieves the current latest version of a server by server name
-func (db *PostgreSQL) GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, serverName string) (*apiv0.ServerResponse, error) {
+func (db *PostgreSQL) GetCurrentLatestVersion(ctx context.Context, tx Tx, serverName string) (*apiv0.ServerResponse, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
 
-	executor := db.getExecutor(tx)
+	executor := db.getReadExecutor(ctx, tx)
 
 	query := `
 		SELECT server_name, version, status, value, published_at, updated_at, is_latest
@@ -646,6 +856,7 @@ func (db *PostgreSQL) GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, se
 		Server: serverJSON,
 		Meta: apiv0.ResponseMeta{
 			Official: &apiv0.RegistryExtensions{
+				Status:      model.Status(status),
 				PublishedAt: publishedAt,
 				UpdatedAt:   updatedAt,
 				IsLatest:    isLatest,
@@ -657,12 +868,12 @@ func (db *PostgreSQL) GetCurrentLatestVersion(ctx context.Context, tx pgx.Tx, se
 }
 
 // CountServerVersions counts the number of versions for a server
-func (db *PostgreSQL) CountServerVersions(ctx context.Context, tx pgx.Tx, serverName string) (int, error) {
+func (db *PostgreSQL) CountServerVersions(ctx context.Context, tx Tx, serverName string) (int, error) {
 	if ctx.Err() != nil {
 		return 0, ctx.Err()
 	}
 
-	executor := db.getExecutor(tx)
+	executor := db.getReadExecutor(ctx, tx)
 
 	query := `SELECT COUNT(*) FROM servers WHERE server_name = $1`
 
@@ -676,12 +887,12 @@ func (db *PostgreSQL) CountServerVersions(ctx context.Context, tx pgx.Tx, server
 }
 
 // CheckVersionExists checks if a specific version exists for a server
-func (db *PostgreSQL) CheckVersionExists(ctx context.Context, tx pgx.Tx, serverName, version string) (bool, error) {
+func (db *PostgreSQL) CheckVersionExists(ctx context.Context, tx Tx, serverName, version string) (bool, error) {
 	if ctx.Err() != nil {
 		return false, ctx.Err()
 	}
 
-	executor := db.getExecutor(tx)
+	executor := db.getReadExecutor(ctx, tx)
 
 	query := `SELECT EXISTS(SELECT 1 FROM servers WHERE server_name = $1 AND version = $2)`
 
@@ -695,16 +906,25 @@ func (db *PostgreSQL) CheckVersionExists(ctx context.Context, tx pgx.Tx, serverN
 }
 
 // UnmarkAsLatest marks the current latest version of a server as no longer latest
-func (db *PostgreSQL) UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName string) error {
+func (db *PostgreSQL) UnmarkAsLatest(ctx context.Context, tx Tx, serverName string) error {
 	if ctx.Err() != nil {
 		return ctx.Err()
 	}
 
 	executor := db.getExecutor(tx)
 
-	query := `UPDATE servers SET is_latest = false WHERE server_name = $1 AND is_latest = true`
+	// The version losing its latest flag is recorded as updated in the change feed
+	query := `
+		WITH unmarked AS (
+			UPDATE servers SET is_latest = false, updated_at = NOW()
+			WHERE server_name = $1 AND is_latest = true
+			RETURNING server_name, version, status
+		)
+		INSERT INTO server_changes (server_name, version, change_type, status)
+		SELECT server_name, version, $2, status FROM unmarked
+	`
 
-	_, err := executor.Exec(ctx, query, serverName)
+	_, err := executor.Exec(ctx, query, serverName, ChangeTypeUpdated)
 	if err != nil {
 		return fmt.Errorf("failed to unmark latest version: %w", err)
 	}
@@ -714,6 +934,9 @@ func (db *PostgreSQL) UnmarkAsLatest(ctx context.Context, tx pgx.Tx, serverName
 
 // Close closes the database connection
 func (db *PostgreSQL) Close() error {
+	if db.replica != nil {
+		db.replica.close()
+	}
 	db.pool.Close()
 	return nil
 }
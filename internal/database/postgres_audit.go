@@ -0,0 +1,146 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CreateAuditEvent appends an event to the audit log
+func (db *PostgreSQL) CreateAuditEvent(ctx context.Context, tx Tx, event *AuditEvent) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if event == nil || event.Action == "" || event.ServerName == "" {
+		return fmt.Errorf("%w: audit event action and server name are required", ErrInvalidInput)
+	}
+
+	query := `
+		INSERT INTO audit_events (action, server_name, version, actor_method, actor_subject, source_ip, reason, before, after, diff)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, occurred_at
+	`
+
+	err := db.getExecutor(tx).QueryRow(ctx, query,
+		event.Action,
+		event.ServerName,
+		event.Version,
+		event.ActorMethod,
+		event.ActorSubject,
+		event.SourceIP,
+		event.Reason,
+		nullableJSON(event.Before),
+		nullableJSON(event.After),
+		nullableJSON(event.Diff),
+	).Scan(&event.ID, &event.OccurredAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert audit event: %w", err)
+	}
+
+	return nil
+}
+
+// ListAuditEvents retrieves audit events newest first, using the ID of the last event as the cursor
+func (db *PostgreSQL) ListAuditEvents(ctx context.Context, tx Tx, filter *AuditEventFilter, cursor string, limit int) ([]*AuditEvent, string, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+
+	var whereConditions []string
+	args := []any{}
+	argIndex := 1
+
+	if filter != nil {
+		if filter.ServerName != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("server_name = $%d", argIndex))
+			args = append(args, *filter.ServerName)
+			argIndex++
+		}
+		if filter.ActorSubject != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("actor_subject = $%d", argIndex))
+			args = append(args, *filter.ActorSubject)
+			argIndex++
+		}
+		if filter.Since != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("occurred_at >= $%d", argIndex))
+			args = append(args, *filter.Since)
+			argIndex++
+		}
+		if filter.Until != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("occurred_at < $%d", argIndex))
+			args = append(args, *filter.Until)
+			argIndex++
+		}
+	}
+
+	if cursor != "" {
+		cursorID, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: invalid cursor", ErrInvalidInput)
+		}
+		whereConditions = append(whereConditions, fmt.Sprintf("id < $%d", argIndex))
+		args = append(args, cursorID)
+		argIndex++
+	}
+
+	whereClause := ""
+	if len(whereConditions) > 0 {
+		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, occurred_at, action, server_name, version, actor_method, actor_subject, source_ip, reason, before, after, diff
+		FROM audit_events
+		%s
+		ORDER BY id DESC
+		LIMIT $%d
+	`, whereClause, argIndex)
+	args = append(args, limit)
+
+	rows, err := db.getReadExecutor(ctx, tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query audit events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*AuditEvent
+	for rows.Next() {
+		var event AuditEvent
+		var before, after, diff []byte
+		if err := rows.Scan(
+			&event.ID, &event.OccurredAt, &event.Action, &event.ServerName, &event.Version,
+			&event.ActorMethod, &event.ActorSubject, &event.SourceIP, &event.Reason, &before, &after, &diff,
+		); err != nil {
+			return nil, "", fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		event.Before = before
+		event.After = after
+		event.Diff = diff
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("error iterating audit events: %w", err)
+	}
+
+	nextCursor := ""
+	if len(events) > 0 && len(events) >= limit {
+		nextCursor = strconv.FormatInt(events[len(events)-1].ID, 10)
+	}
+
+	return events, nextCursor, nil
+}
+
+// nullableJSON maps an empty JSON document to SQL NULL
+func nullableJSON(value []byte) any {
+	if len(value) == 0 {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Change feed sequence numbers are assigned as a transaction commits, by a deferred trigger
+// (migration 027) that takes the two-key advisory lock (1, 0) and numbers the transaction's
+// changes in insertion order. The lock is held only from that point until the commit is
+// visible, so changes become visible in sequence order without writers serializing for their
+// whole transaction.
+//
+// Lock order: writers take their publish locks (the single-key advisory locks of
+// AcquirePublishLock) first, then the publish limit locks of AcquirePublishLimitLocks and row
+// locks while the transaction runs, and the change feed lock last, at commit. Nothing may take a
+// lock after the change feed lock, so no code should take it directly or add other deferred
+// triggers that lock.
+
+// appendServerChange records a change to a server version in the change feed, unless it is a
+// draft. The change gets its sequence number when the transaction commits.
+func (db *PostgreSQL) appendServerChange(ctx context.Context, tx Tx, serverName, version, changeType string) error {
+	query := `
+		INSERT INTO server_changes (server_name, version, change_type, status)
+		SELECT server_name, version, $3, status
+		FROM servers
+		WHERE server_name = $1 AND version = $2 AND status <> 'draft'
+	`
+
+	if _, err := db.getExecutor(tx).Exec(ctx, query, serverName, version, changeType); err != nil {
+		return fmt.Errorf("failed to insert server change: %w", err)
+	}
+	return nil
+}
+
+// ListServerChanges retrieves changes with a sequence number after since, oldest first
+func (db *PostgreSQL) ListServerChanges(ctx context.Context, tx Tx, since int64, limit int) ([]*ServerChange, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT c.seq, c.change_type, c.status, c.occurred_at,
+		       s.server_name, s.version, s.status, s.value, s.published_at, s.updated_at, s.is_latest, s.is_latest_stable,
+		       s.deprecation_message, s.replaced_by_name, s.replaced_by_version
+		FROM server_changes c
+		JOIN servers s ON s.server_name = c.server_name AND s.version = c.version
+		WHERE c.seq > $1
+		ORDER BY c.seq
+		LIMIT $2
+	`
+
+	rows, err := db.getReadExecutor(ctx, tx).Query(ctx, query, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []*ServerChange
+	for rows.Next() {
+		var change ServerChange
+		var status string
+		var publishedAt, updatedAt time.Time
+		var isLatest, isLatestStable bool
+		var valueJSON []byte
+		var details statusDetailColumnValues
+		if err := rows.Scan(
+			&change.Seq, &change.ChangeType, &change.Status, &change.OccurredAt,
+			&change.ServerName, &change.Version, &status, &valueJSON, &publishedAt, &updatedAt, &isLatest, &isLatestStable,
+			&details.message, &details.replacedByName, &details.replacedByVersion,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan server change: %w", err)
+		}
+
+		var serverJSON apiv0.ServerJSON
+		if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+		}
+		change.Server = &apiv0.ServerResponse{
+			Server: serverJSON,
+			Meta: apiv0.ResponseMeta{
+				Official: details.apply(&apiv0.RegistryExtensions{
+					Status:         model.Status(status),
+					PublishedAt:    publishedAt,
+					UpdatedAt:      updatedAt,
+					IsLatest:       isLatest,
+					IsLatestStable: isLatestStable,
+				}),
+			},
+		}
+		changes = append(changes, &change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating server changes: %w", err)
+	}
+
+	return changes, nil
+}
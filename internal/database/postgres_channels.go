@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const serverChannelColumns = "server_name, channel, version, updated_at"
+
+// scanServerChannel reads a row of serverChannelColumns
+func scanServerChannel(row pgx.Row) (*ServerChannel, error) {
+	var c ServerChannel
+	if err := row.Scan(&c.ServerName, &c.Channel, &c.Version, &c.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// SetServerChannel points a channel of a server at a version, creating the channel if needed
+func (db *PostgreSQL) SetServerChannel(ctx context.Context, tx Tx, channel *ServerChannel) (*ServerChannel, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	// Selecting the version from servers makes a missing version insert nothing, rather than
+	// violate the foreign key
+	query := `
+		INSERT INTO server_channels (server_name, channel, version)
+		SELECT server_name, $2, version
+		FROM servers
+		WHERE server_name = $1 AND version = $3
+		ON CONFLICT (server_name, channel) DO UPDATE SET
+			version = EXCLUDED.version,
+			updated_at = NOW()
+		RETURNING ` + serverChannelColumns
+
+	record, err := scanServerChannel(db.getExecutor(tx).QueryRow(ctx, query, channel.ServerName, channel.Channel, channel.Version))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to set server channel: %w", err)
+	}
+
+	return record, nil
+}
+
+// GetServerChannel retrieves a channel of a server
+func (db *PostgreSQL) GetServerChannel(ctx context.Context, tx Tx, serverName, channel string) (*ServerChannel, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := "SELECT " + serverChannelColumns + " FROM server_channels WHERE server_name = $1 AND channel = $2"
+
+	record, err := scanServerChannel(db.getReadExecutor(ctx, tx).QueryRow(ctx, query, serverName, channel))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get server channel: %w", err)
+	}
+
+	return record, nil
+}
+
+// ListServerChannels retrieves the channels of a server ordered by name
+func (db *PostgreSQL) ListServerChannels(ctx context.Context, tx Tx, serverName string) ([]*ServerChannel, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := "SELECT " + serverChannelColumns + " FROM server_channels WHERE server_name = $1 ORDER BY channel"
+
+	rows, err := db.getReadExecutor(ctx, tx).Query(ctx, query, serverName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server channels: %w", err)
+	}
+	defer rows.Close()
+
+	var channels []*ServerChannel
+	for rows.Next() {
+		record, err := scanServerChannel(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan server channel: %w", err)
+		}
+		channels = append(channels, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating server channels: %w", err)
+	}
+
+	return channels, nil
+}
+
+// DeleteServerChannel deletes a channel of a server
+func (db *PostgreSQL) DeleteServerChannel(ctx context.Context, tx Tx, serverName, channel string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, "DELETE FROM server_channels WHERE server_name = $1 AND channel = $2", serverName, channel)
+	if err != nil {
+		return fmt.Errorf("failed to delete server channel: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// PromoteServer makes a draft version active, publishing it as of now
+func (db *PostgreSQL) PromoteServer(ctx context.Context, tx Tx, serverName, version string, isLatest, isLatestStable bool) (*apiv0.ServerResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	// The release order of versions that are not semantic versions depends on their publish time
+	publishedAt := time.Now()
+	query := `
+		UPDATE servers
+		SET status = 'active', published_at = $3, updated_at = $3, is_latest = $4, is_latest_stable = $5, version_sort_key = $6
+		WHERE server_name = $1 AND version = $2 AND status = 'draft'
+		RETURNING value
+	`
+
+	var valueJSON []byte
+	err := db.getExecutor(tx).QueryRow(ctx, query, serverName, version, publishedAt, isLatest, isLatestStable, VersionSortKey(version, publishedAt)).Scan(&valueJSON)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to promote server: %w", err)
+	}
+
+	if err := db.appendServerChange(ctx, tx, serverName, version, ChangeTypeCreated); err != nil {
+		return nil, err
+	}
+
+	var serverJSON apiv0.ServerJSON
+	if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	}
+
+	return &apiv0.ServerResponse{
+		Server: serverJSON,
+		Meta: apiv0.ResponseMeta{
+			Official: &apiv0.RegistryExtensions{
+				Status:         model.StatusActive,
+				PublishedAt:    publishedAt,
+				UpdatedAt:      publishedAt,
+				IsLatest:       isLatest,
+				IsLatestStable: isLatestStable,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// ExportServers runs fn with every server version as of a single REPEATABLE READ snapshot. The
+// change feed position is read in the same snapshot, and changes become visible in sequence
+// order, so the export contains exactly the changes up to that position.
+func (db *PostgreSQL) ExportServers(ctx context.Context, fn func(export *ServerExport) error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	// Exports read from the primary: a snapshot held open for the whole export on a replica
+	// can be cancelled by replication conflicts part way through
+	tx, err := db.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return fmt.Errorf("failed to begin export transaction: %w", err)
+	}
+	//nolint:contextcheck // Intentionally using separate context for rollback to ensure cleanup even if request is cancelled
+	defer func() {
+		rollbackCtx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+		defer cancel()
+		if rbErr := tx.Rollback(rollbackCtx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+			log.Printf("failed to rollback export transaction: %v", rbErr)
+		}
+	}()
+
+	// The snapshot is taken by the first query of the transaction
+	export := &ServerExport{}
+	if err := tx.QueryRow(ctx, "SELECT COALESCE(MAX(seq), 0), now() FROM server_changes").Scan(&export.Seq, &export.SnapshotAt); err != nil {
+		return fmt.Errorf("failed to read change feed position: %w", err)
+	}
+
+	query := `
+		SELECT server_name, version, status, published_at, updated_at, is_latest, is_latest_stable, value, ` + statusDetailColumns + `
+		FROM servers
+		WHERE status <> 'draft'
+		ORDER BY server_name, version_sort_key, version
+	`
+
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to query servers for export: %w", err)
+	}
+	defer rows.Close()
+
+	export.Servers = func(yield func(*apiv0.ServerResponse, error) bool) {
+		for rows.Next() {
+			var name, version, status string
+			var publishedAt, updatedAt time.Time
+			var isLatest, isLatestStable bool
+			var valueJSON []byte
+			var details statusDetailColumnValues
+
+			if err := rows.Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &isLatestStable, &valueJSON,
+				&details.message, &details.replacedByName, &details.replacedByVersion); err != nil {
+				yield(nil, fmt.Errorf("failed to scan server row: %w", err))
+				return
+			}
+
+			var serverJSON apiv0.ServerJSON
+			if err := json.Unmarshal(valueJSON, &serverJSON); err != nil {
+				yield(nil, fmt.Errorf("failed to unmarshal server JSON: %w", err))
+				return
+			}
+
+			serverResponse := &apiv0.ServerResponse{
+				Server: serverJSON,
+				Meta: apiv0.ResponseMeta{
+					Official: details.apply(&apiv0.RegistryExtensions{
+						Status:         model.Status(status),
+						PublishedAt:    publishedAt,
+						UpdatedAt:      updatedAt,
+						IsLatest:       isLatest,
+						IsLatestStable: isLatestStable,
+					}),
+				},
+			}
+			if !yield(serverResponse, nil) {
+				return
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			yield(nil, fmt.Errorf("error iterating rows: %w", err))
+		}
+	}
+
+	return fn(export)
+}
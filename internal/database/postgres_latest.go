@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// MarkAsLatest marks a version of a server as its latest version
+func (db *PostgreSQL) MarkAsLatest(ctx context.Context, tx Tx, serverName, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		UPDATE servers SET is_latest = true, updated_at = NOW()
+		WHERE server_name = $1 AND version = $2
+		RETURNING is_latest
+	`
+
+	var isLatest bool
+	if err := db.getExecutor(tx).QueryRow(ctx, query, serverName, version).Scan(&isLatest); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to mark latest version: %w", err)
+	}
+
+	// The version gaining its latest flag is recorded as updated in the change feed
+	return db.appendServerChange(ctx, tx, serverName, version, ChangeTypeUpdated)
+}
+
+// ListServerNames retrieves the names of all servers with at least one version, in name order,
+// only those in namespace unless it is empty
+func (db *PostgreSQL) ListServerNames(ctx context.Context, tx Tx, namespace string) ([]string, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT DISTINCT server_name
+		FROM servers
+		WHERE $1 = '' OR split_part(server_name, '/', 1) = $1
+		ORDER BY server_name
+	`
+
+	rows, err := db.getReadExecutor(ctx, tx).Query(ctx, query, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server names: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan server name: %w", err)
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating server names: %w", err)
+	}
+
+	return names, nil
+}
+
+// UnmarkAsLatestStable marks the current latest stable version of a server as no longer latest stable
+func (db *PostgreSQL) UnmarkAsLatestStable(ctx context.Context, tx Tx, serverName string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	// The version losing its latest stable flag is recorded as updated in the change feed
+	query := `
+		WITH unmarked AS (
+			UPDATE servers SET is_latest_stable = false, updated_at = NOW()
+			WHERE server_name = $1 AND is_latest_stable = true
+			RETURNING server_name, version, status
+		)
+		INSERT INTO server_changes (server_name, version, change_type, status)
+		SELECT server_name, version, $2, status FROM unmarked
+	`
+
+	if _, err := db.getExecutor(tx).Exec(ctx, query, serverName, ChangeTypeUpdated); err != nil {
+		return fmt.Errorf("failed to unmark latest stable version: %w", err)
+	}
+
+	return nil
+}
+
+// MarkAsLatestStable marks a version of a server as its latest stable version
+func (db *PostgreSQL) MarkAsLatestStable(ctx context.Context, tx Tx, serverName, version string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		UPDATE servers SET is_latest_stable = true, updated_at = NOW()
+		WHERE server_name = $1 AND version = $2
+		RETURNING is_latest_stable
+	`
+
+	var isLatestStable bool
+	if err := db.getExecutor(tx).QueryRow(ctx, query, serverName, version).Scan(&isLatestStable); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to mark latest stable version: %w", err)
+	}
+
+	// The version gaining its latest stable flag is recorded as updated in the change feed
+	return db.appendServerChange(ctx, tx, serverName, version, ChangeTypeUpdated)
+}
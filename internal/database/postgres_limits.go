@@ -0,0 +1,260 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const publishLimitsColumns = "namespace, publishes_per_hour, new_servers_per_day, updated_at"
+
+// Classes of the two-key advisory locks taken by AcquirePublishLimitLocks, keyed by the hash of
+// the namespace or actor subject. Class 1 is the change feed lock, see postgres_changes.go.
+const (
+	publishLimitNamespaceLockClass = 2
+	publishLimitActorLockClass     = 3
+)
+
+// validate mirrors the constraints of the publish_limits table
+func (l *PublishLimits) validate() error {
+	if l == nil || l.Namespace == "" {
+		return fmt.Errorf("%w: namespace is required", ErrInvalidInput)
+	}
+	if l.PublishesPerHour < 0 || l.NewServersPerDay < 0 {
+		return fmt.Errorf("%w: publish limits must not be negative", ErrInvalidInput)
+	}
+	return nil
+}
+
+// scanPublishLimits reads a row of publishLimitsColumns
+func scanPublishLimits(row pgx.Row) (*PublishLimits, error) {
+	var l PublishLimits
+	if err := row.Scan(&l.Namespace, &l.PublishesPerHour, &l.NewServersPerDay, &l.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// AcquirePublishLimitLocks acquires the advisory locks of the recent publishes of a namespace and
+// of an actor, namespace first
+func (db *PostgreSQL) AcquirePublishLimitLocks(ctx context.Context, tx Tx, namespace, actorSubject string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if namespace == "" {
+		return fmt.Errorf("%w: namespace is required", ErrInvalidInput)
+	}
+
+	query := "SELECT pg_advisory_xact_lock($1, hashtext($2))"
+
+	if _, err := db.getExecutor(tx).Exec(ctx, query, publishLimitNamespaceLockClass, namespace); err != nil {
+		return fmt.Errorf("failed to acquire namespace publish limit lock: %w", err)
+	}
+	if actorSubject != "" {
+		if _, err := db.getExecutor(tx).Exec(ctx, query, publishLimitActorLockClass, actorSubject); err != nil {
+			return fmt.Errorf("failed to acquire actor publish limit lock: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// RecordPublishEvent appends a publish to the recent publishes
+func (db *PostgreSQL) RecordPublishEvent(ctx context.Context, tx Tx, event *PublishEvent) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if event == nil || event.Namespace == "" || event.ServerName == "" {
+		return fmt.Errorf("%w: publish event namespace and server name are required", ErrInvalidInput)
+	}
+
+	query := `
+		INSERT INTO publish_events (actor_subject, namespace, server_name, new_server)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, occurred_at
+	`
+	err := db.getExecutor(tx).QueryRow(ctx, query, event.ActorSubject, event.Namespace, event.ServerName, event.NewServer).
+		Scan(&event.ID, &event.OccurredAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert publish event: %w", err)
+	}
+
+	return nil
+}
+
+// ListPublishEventTimes retrieves the times of up to limit recent publishes newest first
+func (db *PostgreSQL) ListPublishEventTimes(ctx context.Context, tx Tx, filter *PublishEventFilter, limit int) ([]time.Time, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var whereConditions []string
+	args := []any{}
+	argIndex := 1
+
+	if filter != nil {
+		if filter.ActorSubject != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("actor_subject = $%d", argIndex))
+			args = append(args, *filter.ActorSubject)
+			argIndex++
+		}
+		if filter.Namespace != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("namespace = $%d", argIndex))
+			args = append(args, *filter.Namespace)
+			argIndex++
+		}
+		if filter.NewServer {
+			whereConditions = append(whereConditions, "new_server")
+		}
+		if !filter.Since.IsZero() {
+			whereConditions = append(whereConditions, fmt.Sprintf("occurred_at >= $%d", argIndex))
+			args = append(args, filter.Since)
+			argIndex++
+		}
+	}
+
+	whereClause := ""
+	if len(whereConditions) > 0 {
+		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT occurred_at
+		FROM publish_events
+		%s
+		ORDER BY occurred_at DESC
+		LIMIT $%d
+	`, whereClause, argIndex)
+	args = append(args, limit)
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query publish events: %w", err)
+	}
+	defer rows.Close()
+
+	var times []time.Time
+	for rows.Next() {
+		var occurredAt time.Time
+		if err := rows.Scan(&occurredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan publish event: %w", err)
+		}
+		times = append(times, occurredAt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating publish events: %w", err)
+	}
+
+	return times, nil
+}
+
+// DeletePublishEventsBefore deletes the recorded publishes that occurred before a time
+func (db *PostgreSQL) DeletePublishEventsBefore(ctx context.Context, tx Tx, before time.Time) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if _, err := db.getExecutor(tx).Exec(ctx, "DELETE FROM publish_events WHERE occurred_at < $1", before); err != nil {
+		return fmt.Errorf("failed to delete publish events: %w", err)
+	}
+
+	return nil
+}
+
+// GetPublishLimits retrieves the publish limits set for a namespace
+func (db *PostgreSQL) GetPublishLimits(ctx context.Context, tx Tx, namespace string) (*PublishLimits, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := "SELECT " + publishLimitsColumns + " FROM publish_limits WHERE namespace = $1"
+
+	limits, err := scanPublishLimits(db.getReadExecutor(ctx, tx).QueryRow(ctx, query, namespace))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get publish limits: %w", err)
+	}
+
+	return limits, nil
+}
+
+// ListPublishLimits retrieves the publish limits set for namespaces ordered by namespace
+func (db *PostgreSQL) ListPublishLimits(ctx context.Context, tx Tx) ([]*PublishLimits, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := "SELECT " + publishLimitsColumns + " FROM publish_limits ORDER BY namespace"
+
+	rows, err := db.getReadExecutor(ctx, tx).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query publish limits: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*PublishLimits
+	for rows.Next() {
+		limits, err := scanPublishLimits(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan publish limits: %w", err)
+		}
+		results = append(results, limits)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating publish limits: %w", err)
+	}
+
+	return results, nil
+}
+
+// SetPublishLimits stores the publish limits of a namespace, replacing any previous ones
+func (db *PostgreSQL) SetPublishLimits(ctx context.Context, tx Tx, limits *PublishLimits) (*PublishLimits, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err := limits.validate(); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO publish_limits (namespace, publishes_per_hour, new_servers_per_day)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (namespace) DO UPDATE SET
+			publishes_per_hour = EXCLUDED.publishes_per_hour,
+			new_servers_per_day = EXCLUDED.new_servers_per_day,
+			updated_at = NOW()
+		RETURNING ` + publishLimitsColumns
+
+	record, err := scanPublishLimits(db.getExecutor(tx).QueryRow(ctx, query, limits.Namespace, limits.PublishesPerHour, limits.NewServersPerDay))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set publish limits: %w", err)
+	}
+
+	return record, nil
+}
+
+// DeletePublishLimits deletes the publish limits set for a namespace
+func (db *PostgreSQL) DeletePublishLimits(ctx context.Context, tx Tx, namespace string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, "DELETE FROM publish_limits WHERE namespace = $1", namespace)
+	if err != nil {
+		return fmt.Errorf("failed to delete publish limits: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
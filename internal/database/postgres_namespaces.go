@@ -0,0 +1,190 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const namespaceColumns = "namespace, owner_type, owner_id, owner_name, verification_method, verified_at, created_at, updated_at"
+
+// validate mirrors the constraints of the namespaces table
+func (n *Namespace) validate() error {
+	if n == nil || n.Namespace == "" || n.OwnerID == "" || n.VerificationMethod == "" || n.VerifiedAt.IsZero() {
+		return fmt.Errorf("%w: namespace, owner ID, verification method and verification time are required", ErrInvalidInput)
+	}
+	switch n.OwnerType {
+	case "github", "domain":
+	default:
+		return fmt.Errorf("%w: invalid namespace owner type %q", ErrInvalidInput, n.OwnerType)
+	}
+	return nil
+}
+
+// scanNamespace reads a row of namespaceColumns
+func scanNamespace(row pgx.Row) (*Namespace, error) {
+	var n Namespace
+	if err := row.Scan(&n.Namespace, &n.OwnerType, &n.OwnerID, &n.OwnerName, &n.VerificationMethod, &n.VerifiedAt, &n.CreatedAt, &n.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// GetNamespace retrieves the owner record of a namespace
+func (db *PostgreSQL) GetNamespace(ctx context.Context, tx Tx, namespace string) (*Namespace, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := "SELECT " + namespaceColumns + " FROM namespaces WHERE namespace = $1"
+
+	record, err := scanNamespace(db.getReadExecutor(ctx, tx).QueryRow(ctx, query, namespace))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get namespace: %w", err)
+	}
+
+	return record, nil
+}
+
+// ListNamespaces retrieves namespace owner records ordered by namespace, using the last namespace as the cursor
+func (db *PostgreSQL) ListNamespaces(ctx context.Context, tx Tx, filter *NamespaceFilter, cursor string, limit int) ([]*Namespace, string, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+
+	var whereConditions []string
+	args := []any{}
+	argIndex := 1
+
+	if filter != nil {
+		if filter.OwnerType != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("owner_type = $%d", argIndex))
+			args = append(args, *filter.OwnerType)
+			argIndex++
+		}
+		if filter.OwnerID != nil {
+			whereConditions = append(whereConditions, fmt.Sprintf("owner_id = $%d", argIndex))
+			args = append(args, *filter.OwnerID)
+			argIndex++
+		}
+	}
+
+	if cursor != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("namespace > $%d", argIndex))
+		args = append(args, cursor)
+		argIndex++
+	}
+
+	whereClause := ""
+	if len(whereConditions) > 0 {
+		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM namespaces
+		%s
+		ORDER BY namespace
+		LIMIT $%d
+	`, namespaceColumns, whereClause, argIndex)
+	args = append(args, limit)
+
+	rows, err := db.getReadExecutor(ctx, tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query namespaces: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*Namespace
+	for rows.Next() {
+		record, err := scanNamespace(rows)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to scan namespace: %w", err)
+		}
+		results = append(results, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("error iterating namespaces: %w", err)
+	}
+
+	nextCursor := ""
+	if len(results) > 0 && len(results) >= limit {
+		nextCursor = results[len(results)-1].Namespace
+	}
+
+	return results, nextCursor, nil
+}
+
+// ClaimNamespace stores the owner record of a namespace unless it already has one
+func (db *PostgreSQL) ClaimNamespace(ctx context.Context, tx Tx, namespace *Namespace) (*Namespace, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err := namespace.validate(); err != nil {
+		return nil, err
+	}
+
+	// A concurrent claim makes the insert wait for its transaction, and then do nothing if it committed
+	insert := `
+		INSERT INTO namespaces (namespace, owner_type, owner_id, owner_name, verification_method, verified_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (namespace) DO NOTHING
+	`
+	_, err := db.getExecutor(tx).Exec(ctx, insert,
+		namespace.Namespace, namespace.OwnerType, namespace.OwnerID, namespace.OwnerName,
+		namespace.VerificationMethod, namespace.VerifiedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim namespace: %w", err)
+	}
+
+	// Read back from the primary, which holds the winning claim
+	query := "SELECT " + namespaceColumns + " FROM namespaces WHERE namespace = $1"
+	record, err := scanNamespace(db.getExecutor(tx).QueryRow(ctx, query, namespace.Namespace))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace: %w", err)
+	}
+
+	return record, nil
+}
+
+// SetNamespaceOwner stores the owner record of a namespace, replacing any previous owner
+func (db *PostgreSQL) SetNamespaceOwner(ctx context.Context, tx Tx, namespace *Namespace) (*Namespace, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err := namespace.validate(); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO namespaces (namespace, owner_type, owner_id, owner_name, verification_method, verified_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (namespace) DO UPDATE SET
+			owner_type = EXCLUDED.owner_type,
+			owner_id = EXCLUDED.owner_id,
+			owner_name = EXCLUDED.owner_name,
+			verification_method = EXCLUDED.verification_method,
+			verified_at = EXCLUDED.verified_at,
+			updated_at = NOW()
+		RETURNING ` + namespaceColumns
+
+	record, err := scanNamespace(db.getExecutor(tx).QueryRow(ctx, query,
+		namespace.Namespace, namespace.OwnerType, namespace.OwnerID, namespace.OwnerName,
+		namespace.VerificationMethod, namespace.VerifiedAt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set namespace owner: %w", err)
+	}
+
+	return record, nil
+}
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// scanRemoteURLOwners reads rows of (url, server_name)
+func scanRemoteURLOwners(rows pgx.Rows) ([]*RemoteURLOwner, error) {
+	defer rows.Close()
+
+	var owners []*RemoteURLOwner
+	for rows.Next() {
+		var owner RemoteURLOwner
+		if err := rows.Scan(&owner.URL, &owner.ServerName); err != nil {
+			return nil, fmt.Errorf("failed to scan remote URL owner: %w", err)
+		}
+		owners = append(owners, &owner)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating remote URL owners: %w", err)
+	}
+
+	return owners, nil
+}
+
+// remoteURLArgs returns urls and their normalized forms as parallel arrays, never nil
+func remoteURLArgs(urls []string) ([]string, []string) {
+	raw := make([]string, len(urls))
+	normalized := make([]string, len(urls))
+	for i, url := range urls {
+		raw[i] = url
+		normalized[i] = NormalizeRemoteURL(url)
+	}
+	return raw, normalized
+}
+
+// ClaimRemoteURLs makes a server the owner of the remote URLs that have none, and returns the
+// owners of the URLs owned by other servers
+func (db *PostgreSQL) ClaimRemoteURLs(ctx context.Context, tx Tx, serverName string, urls []string) ([]*RemoteURLOwner, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if serverName == "" {
+		return nil, fmt.Errorf("%w: server name is required", ErrInvalidInput)
+	}
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	// A concurrent claim of the same URL makes the insert wait for its transaction. If it
+	// committed, the no-op update locks the row and returns the winning owner.
+	raw, normalized := remoteURLArgs(urls)
+	query := `
+		WITH claimed AS (
+			INSERT INTO server_remotes (normalized_url, server_name)
+			SELECT normalized_url, $1 FROM unnest($2::text[]) AS normalized_url
+			ON CONFLICT (normalized_url) DO UPDATE SET server_name = server_remotes.server_name
+			RETURNING normalized_url, server_name
+		)
+		SELECT input.url, claimed.server_name
+		FROM unnest($3::text[], $4::text[]) WITH ORDINALITY AS input(url, normalized_url, position)
+		JOIN claimed ON claimed.normalized_url = input.normalized_url
+		WHERE claimed.server_name <> $1
+		ORDER BY input.position
+	`
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, serverName, normalizeRemoteURLs(urls), raw, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim remote URLs: %w", err)
+	}
+
+	return scanRemoteURLOwners(rows)
+}
+
+// ListRemoteURLOwners retrieves the owners of the remote URLs that have one
+func (db *PostgreSQL) ListRemoteURLOwners(ctx context.Context, tx Tx, urls []string) ([]*RemoteURLOwner, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	raw, normalized := remoteURLArgs(urls)
+	query := `
+		SELECT input.url, server_remotes.server_name
+		FROM unnest($1::text[], $2::text[]) WITH ORDINALITY AS input(url, normalized_url, position)
+		JOIN server_remotes ON server_remotes.normalized_url = input.normalized_url
+		ORDER BY input.position
+	`
+
+	rows, err := db.getReadExecutor(ctx, tx).Query(ctx, query, raw, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remote URL owners: %w", err)
+	}
+
+	return scanRemoteURLOwners(rows)
+}
+
+// ReleaseRemoteURLs gives up a server's ownership of its remote URLs, except those in keep
+func (db *PostgreSQL) ReleaseRemoteURLs(ctx context.Context, tx Tx, serverName string, keep []string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `DELETE FROM server_remotes WHERE server_name = $1 AND NOT (normalized_url = ANY($2::text[]))`
+
+	if _, err := db.getExecutor(tx).Exec(ctx, query, serverName, normalizeRemoteURLs(keep)); err != nil {
+		return fmt.Errorf("failed to release remote URLs: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// replicaCheckInterval is how often the health and replication lag of a read replica are checked
+	replicaCheckInterval = 5 * time.Second
+	// replicaCheckTimeout bounds a single health check, so a hung replica is treated as unhealthy
+	replicaCheckTimeout = 2 * time.Second
+)
+
+// replicaLagQuery returns whether a standby is streaming WAL from its primary, and its
+// replication lag in seconds. A streaming standby that has replayed everything it received is
+// not lagging, however long ago the last transaction was; one whose WAL receiver disconnected
+// stops receiving, so it catches up with what it has and then falls behind without its lag
+// showing, and is reported as not streaming instead. The receiver status is only visible to
+// roles with pg_read_all_stats, so for other roles a running receiver counts as streaming. NULL
+// lag means the standby has not replayed anything yet. Primaries report no lag.
+const replicaLagQuery = `
+	SELECT
+		NOT pg_is_in_recovery() OR EXISTS (
+			SELECT 1 FROM pg_stat_wal_receiver WHERE status IS NULL OR status = 'streaming'
+		),
+		CASE
+			WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+			ELSE EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())::float8
+		END
+`
+
+// WithReadReplica routes reads made outside a transaction to the read replica at connectionURI.
+// Reads fall back to the primary while the replica is unreachable or lags by more than maxLag.
+func WithReadReplica(connectionURI string, maxLag time.Duration) PostgreSQLOption {
+	return func(ctx context.Context, db *PostgreSQL) error {
+		return db.attachReadReplica(ctx, connectionURI, maxLag)
+	}
+}
+
+// MaxReplicaStaleness is the longest a read from a replica attached with WithReadReplica can
+// lag behind the primary: the lag allowed by maxLag, plus the time it takes to notice that the
+// replica fell further behind
+func MaxReplicaStaleness(maxLag time.Duration) time.Duration {
+	return maxLag + replicaCheckInterval + replicaCheckTimeout
+}
+
+// readReplica is a connection pool to a read replica together with its last known health
+type readReplica struct {
+	pool    *pgxpool.Pool
+	maxLag  time.Duration
+	healthy atomic.Bool
+	stop    context.CancelFunc
+	done    chan struct{}
+}
+
+// attachReadReplica connects to a read replica and starts monitoring its health. The replica
+// being down is not an error: reads stay on the primary until it becomes healthy.
+func (db *PostgreSQL) attachReadReplica(ctx context.Context, connectionURI string, maxLag time.Duration) error {
+	config, err := pgxpool.ParseConfig(connectionURI)
+	if err != nil {
+		return fmt.Errorf("failed to parse PostgreSQL read replica config: %w", err)
+	}
+
+	// Reads are the bulk of the traffic, so the replica gets the same pool settings as the primary
+	config.MaxConns = 30
+	config.MinConns = 5
+	config.MaxConnIdleTime = 30 * time.Minute
+	config.MaxConnLifetime = 2 * time.Hour
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return fmt.Errorf("failed to create PostgreSQL read replica pool: %w", err)
+	}
+
+	//nolint:contextcheck // The monitor outlives the connection context and is stopped by Close
+	monitorCtx, stop := context.WithCancel(context.Background())
+	replica := &readReplica{
+		pool:   pool,
+		maxLag: maxLag,
+		stop:   stop,
+		done:   make(chan struct{}),
+	}
+	replica.check(ctx)
+	if !replica.healthy.Load() {
+		log.Println("PostgreSQL read replica is not available yet: reading from the primary")
+	}
+
+	go replica.monitor(monitorCtx)
+	db.replica = replica
+
+	return nil
+}
+
+// monitor periodically re-checks the replica until ctx is cancelled
+func (r *readReplica) monitor(ctx context.Context) {
+	defer close(r.done)
+
+	ticker := time.NewTicker(replicaCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.check(ctx)
+		}
+	}
+}
+
+// check marks the replica healthy if it is reachable and within the allowed replication lag,
+// logging whenever reads switch between the replica and the primary
+func (r *readReplica) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, replicaCheckTimeout)
+	defer cancel()
+
+	var streaming bool
+	var lagSeconds *float64
+	err := r.pool.QueryRow(ctx, replicaLagQuery).Scan(&streaming, &lagSeconds)
+
+	healthy := false
+	switch {
+	case err != nil:
+		if r.healthy.Load() {
+			log.Printf("PostgreSQL read replica health check failed: %v: reading from the primary", err)
+		}
+	case !streaming:
+		if r.healthy.Load() {
+			log.Println("PostgreSQL read replica is not streaming from the primary: reading from the primary")
+		}
+	case lagSeconds == nil:
+		// Nothing replayed yet, so the replica is still catching up
+	default:
+		lag := time.Duration(*lagSeconds * float64(time.Second))
+		healthy = lag <= r.maxLag
+		if !healthy && r.healthy.Load() {
+			log.Printf("PostgreSQL read replica lags by %s (max %s): reading from the primary", lag.Round(time.Millisecond), r.maxLag)
+		}
+	}
+
+	if healthy && !r.healthy.Load() {
+		log.Println("PostgreSQL read replica is healthy: routing reads to the replica")
+	}
+	r.healthy.Store(healthy)
+}
+
+// close stops monitoring the replica and closes its pool
+func (r *readReplica) close() {
+	r.stop()
+	<-r.done
+	r.pool.Close()
+}
+
+// primaryReadsContextKey marks contexts created by WithPrimaryReads
+type primaryReadsContextKey struct{}
+
+// WithPrimaryReads returns a context whose reads go to the primary even when a healthy read
+// replica is attached, for reads that must see writes this instance just committed
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryReadsContextKey{}, true)
+}
+
+// getReadExecutor returns the executor for a read-only query: the transaction if there is one,
+// otherwise the read replica when it is healthy and ctx does not ask for the primary, falling
+// back to the primary pool
+func (db *PostgreSQL) getReadExecutor(ctx context.Context, tx Tx) Executor {
+	if pgTx, ok := tx.(pgx.Tx); ok {
+		return pgTx
+	}
+	if primary, _ := ctx.Value(primaryReadsContextKey{}).(bool); primary {
+		return db.pool
+	}
+	if db.replica != nil && db.replica.healthy.Load() {
+		return db.replica.pool
+	}
+	return db.pool
+}
@@ -0,0 +1,111 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// appendServerRevision stores valueJSON as the next revision of a server version, unless it is
+// identical to the latest revision
+func (db *PostgreSQL) appendServerRevision(ctx context.Context, tx Tx, serverName, version string, valueJSON []byte) error {
+	query := `
+		WITH latest AS (
+			SELECT revision, value
+			FROM server_revisions
+			WHERE server_name = $1 AND version = $2
+			ORDER BY revision DESC
+			LIMIT 1
+		)
+		INSERT INTO server_revisions (server_name, version, revision, value)
+		SELECT $1, $2, COALESCE((SELECT revision FROM latest), 0) + 1, $3::jsonb
+		WHERE NOT EXISTS (SELECT 1 FROM latest WHERE value = $3::jsonb)
+	`
+
+	if _, err := db.getExecutor(tx).Exec(ctx, query, serverName, version, valueJSON); err != nil {
+		return fmt.Errorf("failed to insert server revision: %w", err)
+	}
+	return nil
+}
+
+// ListServerRevisions retrieves all revisions of a server version, oldest first
+func (db *PostgreSQL) ListServerRevisions(ctx context.Context, tx Tx, serverName, version string) ([]*ServerRevision, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT revision, created_at, value
+		FROM server_revisions
+		WHERE server_name = $1 AND version = $2
+		ORDER BY revision
+	`
+
+	rows, err := db.getReadExecutor(ctx, tx).Query(ctx, query, serverName, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query server revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*ServerRevision
+	for rows.Next() {
+		revision := &ServerRevision{ServerName: serverName, Version: version}
+		var valueJSON []byte
+		if err := rows.Scan(&revision.Revision, &revision.CreatedAt, &valueJSON); err != nil {
+			return nil, fmt.Errorf("failed to scan server revision: %w", err)
+		}
+		if err := json.Unmarshal(valueJSON, &revision.Server); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating server revisions: %w", err)
+	}
+
+	if len(revisions) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return revisions, nil
+}
+
+// GetServerRevision retrieves a single revision of a server version
+func (db *PostgreSQL) GetServerRevision(ctx context.Context, tx Tx, serverName, version string, revision int) (*ServerRevision, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT created_at, value
+		FROM server_revisions
+		WHERE server_name = $1 AND version = $2 AND revision = $3
+	`
+
+	var createdAt time.Time
+	var valueJSON []byte
+	err := db.getReadExecutor(ctx, tx).QueryRow(ctx, query, serverName, version, revision).Scan(&createdAt, &valueJSON)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get server revision: %w", err)
+	}
+
+	result := &ServerRevision{
+		ServerName: serverName,
+		Version:    version,
+		Revision:   revision,
+		CreatedAt:  createdAt,
+	}
+	if err := json.Unmarshal(valueJSON, &result.Server); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	}
+
+	return result, nil
+}
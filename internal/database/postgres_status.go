@@ -0,0 +1,47 @@
+package database
+
+import (
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// statusDetailColumns are the nullable columns holding the StatusDetails of a version, in the
+// order they are scanned into a statusDetailColumnValues
+const statusDetailColumns = "deprecation_message, replaced_by_name, replaced_by_version"
+
+// statusDetailColumnValues holds the statusDetailColumns of a row
+type statusDetailColumnValues struct {
+	message           *string
+	replacedByName    *string
+	replacedByVersion *string
+}
+
+// apply sets the status details of a row on its official metadata
+func (v statusDetailColumnValues) apply(official *apiv0.RegistryExtensions) *apiv0.RegistryExtensions {
+	if v.message != nil {
+		official.DeprecationMessage = *v.message
+	}
+	if v.replacedByName != nil {
+		official.ReplacedBy = &apiv0.ReplacedBy{Name: *v.replacedByName}
+		if v.replacedByVersion != nil {
+			official.ReplacedBy.Version = *v.replacedByVersion
+		}
+	}
+	return official
+}
+
+// statusDetailArgs returns the values of the statusDetailColumns for details, NULL for those not set
+func statusDetailArgs(details *StatusDetails) (message, replacedByName, replacedByVersion *string) {
+	if details == nil {
+		return nil, nil, nil
+	}
+	if details.Message != "" {
+		message = &details.Message
+	}
+	if details.ReplacedBy != nil {
+		replacedByName = &details.ReplacedBy.Name
+		if details.ReplacedBy.Version != "" {
+			replacedByVersion = &details.ReplacedBy.Version
+		}
+	}
+	return message, replacedByName, replacedByVersion
+}
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// validate mirrors the CHECK constraints of the package_validations table
+func (v *PackageValidation) validate() error {
+	if v == nil || v.ServerName == "" || v.Version == "" || v.Check == "" {
+		return fmt.Errorf("%w: package validation server name, version and check are required", ErrInvalidInput)
+	}
+	if v.PackageIndex < 0 {
+		return fmt.Errorf("%w: invalid package index %d", ErrInvalidInput, v.PackageIndex)
+	}
+	switch v.Outcome {
+	case "passed", "skipped":
+	default:
+		return fmt.Errorf("%w: invalid package validation outcome %q", ErrInvalidInput, v.Outcome)
+	}
+	return nil
+}
+
+// CreatePackageValidations stores validation records of a server version's packages
+func (db *PostgreSQL) CreatePackageValidations(ctx context.Context, tx Tx, validations []*PackageValidation) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO package_validations (server_name, version, package_index, registry_type, identifier, check_name, outcome, facts)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, validated_at
+	`
+
+	for _, validation := range validations {
+		if err := validation.validate(); err != nil {
+			return err
+		}
+
+		facts := validation.Facts
+		if facts == nil {
+			facts = map[string]string{}
+		}
+		factsJSON, err := json.Marshal(facts)
+		if err != nil {
+			return fmt.Errorf("failed to marshal package validation facts: %w", err)
+		}
+
+		err = db.getExecutor(tx).QueryRow(ctx, query,
+			validation.ServerName,
+			validation.Version,
+			validation.PackageIndex,
+			validation.RegistryType,
+			validation.Identifier,
+			validation.Check,
+			validation.Outcome,
+			factsJSON,
+		).Scan(&validation.ID, &validation.ValidatedAt)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+				return fmt.Errorf("%w: server %s version %s", ErrNotFound, validation.ServerName, validation.Version)
+			}
+			return fmt.Errorf("failed to insert package validation: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListPackageValidations retrieves the validation records of a server version, oldest first
+func (db *PostgreSQL) ListPackageValidations(ctx context.Context, tx Tx, serverName, version string) ([]*PackageValidation, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		SELECT id, package_index, registry_type, identifier, check_name, outcome, facts, validated_at
+		FROM package_validations
+		WHERE server_name = $1 AND version = $2
+		ORDER BY id
+	`
+
+	rows, err := db.getReadExecutor(ctx, tx).Query(ctx, query, serverName, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query package validations: %w", err)
+	}
+	defer rows.Close()
+
+	var validations []*PackageValidation
+	for rows.Next() {
+		validation := &PackageValidation{ServerName: serverName, Version: version}
+		var factsJSON []byte
+		if err := rows.Scan(
+			&validation.ID, &validation.PackageIndex, &validation.RegistryType, &validation.Identifier,
+			&validation.Check, &validation.Outcome, &factsJSON, &validation.ValidatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan package validation: %w", err)
+		}
+		if err := json.Unmarshal(factsJSON, &validation.Facts); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal package validation facts: %w", err)
+		}
+		validations = append(validations, validation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating package validations: %w", err)
+	}
+
+	return validations, nil
+}
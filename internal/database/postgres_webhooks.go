@@ -0,0 +1,314 @@
+package database
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const webhookColumns = "id, url, secret, events, active, created_at, updated_at"
+
+const webhookDeliveryColumns = "id, webhook_id, event_type, server_name, version, payload, status, attempts, " +
+	"next_attempt_at, last_attempt_at, response_status, last_error, created_at"
+
+// scanWebhook reads a row of webhookColumns
+func scanWebhook(row pgx.Row) (*Webhook, error) {
+	var w Webhook
+	if err := row.Scan(&w.ID, &w.URL, &w.Secret, &w.Events, &w.Active, &w.CreatedAt, &w.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// scanWebhookDelivery reads a row of webhookDeliveryColumns
+func scanWebhookDelivery(row pgx.Row) (*WebhookDelivery, error) {
+	var d WebhookDelivery
+	var payload []byte
+	if err := row.Scan(
+		&d.ID, &d.WebhookID, &d.EventType, &d.ServerName, &d.Version, &payload, &d.Status, &d.Attempts,
+		&d.NextAttemptAt, &d.LastAttemptAt, &d.ResponseStatus, &d.LastError, &d.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	d.Payload = payload
+	return &d, nil
+}
+
+// CreateWebhook stores a new webhook
+func (db *PostgreSQL) CreateWebhook(ctx context.Context, tx Tx, webhook *Webhook) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO webhooks (url, secret, events, active)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := db.getExecutor(tx).QueryRow(ctx, query, webhook.URL, webhook.Secret, webhook.Events, webhook.Active).
+		Scan(&webhook.ID, &webhook.CreatedAt, &webhook.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert webhook: %w", err)
+	}
+
+	return nil
+}
+
+// GetWebhook retrieves a webhook by ID
+func (db *PostgreSQL) GetWebhook(ctx context.Context, tx Tx, id int64) (*Webhook, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := "SELECT " + webhookColumns + " FROM webhooks WHERE id = $1"
+
+	webhook, err := scanWebhook(db.getReadExecutor(ctx, tx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// ListWebhooks retrieves all webhooks ordered by ID
+func (db *PostgreSQL) ListWebhooks(ctx context.Context, tx Tx) ([]*Webhook, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := "SELECT " + webhookColumns + " FROM webhooks ORDER BY id"
+
+	rows, err := db.getReadExecutor(ctx, tx).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var webhooks []*Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// UpdateWebhook stores the URL, events and active flag of a webhook
+func (db *PostgreSQL) UpdateWebhook(ctx context.Context, tx Tx, webhook *Webhook) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		UPDATE webhooks
+		SET url = $2, events = $3, active = $4, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + webhookColumns
+
+	updated, err := scanWebhook(db.getExecutor(tx).QueryRow(ctx, query, webhook.ID, webhook.URL, webhook.Events, webhook.Active))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	*webhook = *updated
+	return nil
+}
+
+// DeleteWebhook deletes a webhook and its delivery log
+func (db *PostgreSQL) DeleteWebhook(ctx context.Context, tx Tx, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	result, err := db.getExecutor(tx).Exec(ctx, "DELETE FROM webhooks WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// EnqueueWebhookDeliveries adds a pending delivery of an event for every active webhook subscribed to it
+func (db *PostgreSQL) EnqueueWebhookDeliveries(ctx context.Context, tx Tx, eventType, serverName, version string, payload json.RawMessage) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, event_type, server_name, version, payload)
+		SELECT id, $1, $2, $3, $4
+		FROM webhooks
+		WHERE active AND $1 = ANY(events)
+		ORDER BY id
+	`
+
+	if _, err := db.getExecutor(tx).Exec(ctx, query, eventType, serverName, version, []byte(payload)); err != nil {
+		return fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimWebhookDeliveries returns due pending deliveries, postponing their next attempt by lease.
+// Rows claimed by a concurrent dispatcher are skipped rather than waited for.
+func (db *PostgreSQL) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*WebhookDelivery, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = NOW() + $2 * INTERVAL '1 microsecond'
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + webhookDeliveryColumns
+
+	rows, err := db.getExecutor(nil).Query(ctx, query, limit, lease.Microseconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook deliveries: %w", err)
+	}
+
+	// RETURNING does not keep the order of the subquery
+	slices.SortFunc(deliveries, func(a, b *WebhookDelivery) int { return cmp.Compare(a.ID, b.ID) })
+	return deliveries, nil
+}
+
+// UpdateWebhookDelivery stores the status and attempt details of a delivery
+func (db *PostgreSQL) UpdateWebhookDelivery(ctx context.Context, tx Tx, delivery *WebhookDelivery) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_attempt_at = $5, response_status = $6, last_error = $7
+		WHERE id = $1
+	`
+
+	result, err := db.getExecutor(tx).Exec(ctx, query,
+		delivery.ID, delivery.Status, delivery.Attempts, delivery.NextAttemptAt,
+		delivery.LastAttemptAt, delivery.ResponseStatus, delivery.LastError)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetWebhookDelivery retrieves a delivery by ID
+func (db *PostgreSQL) GetWebhookDelivery(ctx context.Context, tx Tx, id int64) (*WebhookDelivery, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := "SELECT " + webhookDeliveryColumns + " FROM webhook_deliveries WHERE id = $1"
+
+	delivery, err := scanWebhookDelivery(db.getReadExecutor(ctx, tx).QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// ListWebhookDeliveries retrieves the deliveries of a webhook newest first, using the ID of the last delivery as the cursor
+func (db *PostgreSQL) ListWebhookDeliveries(ctx context.Context, tx Tx, webhookID int64, cursor string, limit int) ([]*WebhookDelivery, string, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+
+	cursorID := int64(-1)
+	if cursor != "" {
+		var err error
+		if cursorID, err = strconv.ParseInt(cursor, 10, 64); err != nil {
+			return nil, "", fmt.Errorf("%w: invalid cursor", ErrInvalidInput)
+		}
+	}
+
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE webhook_id = $1 AND ($2 < 0 OR id < $2)
+		ORDER BY id DESC
+		LIMIT $3
+	`
+
+	rows, err := db.getReadExecutor(ctx, tx).Query(ctx, query, webhookID, cursorID, limit)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("error iterating webhook deliveries: %w", err)
+	}
+
+	nextCursor := ""
+	if len(deliveries) > 0 && len(deliveries) >= limit {
+		nextCursor = strconv.FormatInt(deliveries[len(deliveries)-1].ID, 10)
+	}
+
+	return deliveries, nextCursor, nil
+}
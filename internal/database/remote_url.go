@@ -0,0 +1,34 @@
+package database
+
+import "strings"
+
+// NormalizeRemoteURL returns the form of a remote URL that the server_remotes table is keyed by,
+// so that URLs differing only in the case of the scheme and host or in trailing slashes belong
+// to the same server. The scheme and the authority (up to the first "/", "?" or "#" after
+// "://") are lowercased and trailing slashes are removed; the rest is kept as is, since paths
+// and queries are case sensitive. Migration 026 backfills the table using the same rules.
+func NormalizeRemoteURL(rawURL string) string {
+	normalized := rawURL
+	if scheme, rest, ok := strings.Cut(rawURL, "://"); ok {
+		authorityEnd := strings.IndexAny(rest, "/?#")
+		if authorityEnd < 0 {
+			authorityEnd = len(rest)
+		}
+		normalized = strings.ToLower(scheme) + "://" + strings.ToLower(rest[:authorityEnd]) + rest[authorityEnd:]
+	}
+	return strings.TrimRight(normalized, "/")
+}
+
+// normalizeRemoteURLs returns the distinct normalized forms of urls, in order of first appearance
+func normalizeRemoteURLs(urls []string) []string {
+	normalized := make([]string, 0, len(urls))
+	seen := make(map[string]bool, len(urls))
+	for _, url := range urls {
+		key := NormalizeRemoteURL(url)
+		if !seen[key] {
+			seen[key] = true
+			normalized = append(normalized, key)
+		}
+	}
+	return normalized
+}
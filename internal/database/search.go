@@ -0,0 +1,150 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Search results are ordered by relevance, so their cursors carry the rank of the last row
+// in addition to the usual name and version: "rank:serverName:version".
+
+// searchRankSQL scores a row against the search term in $1. Full-text hits dominate; the
+// trigram word similarity on the name only breaks ties and ranks typo-only matches.
+const searchRankSQL = `(ts_rank(search_vector, websearch_to_tsquery('english', $%[1]d)) + 0.1 * word_similarity($%[1]d, server_name))::real`
+
+// searchMatchSQL selects rows that match the search term in $1 through full-text search,
+// substring match on the name (the previous behaviour of search) or trigram similarity ($2 is
+// the ILIKE pattern)
+const searchMatchSQL = `(search_vector @@ websearch_to_tsquery('english', $%[1]d) OR server_name ILIKE $%[2]d OR $%[1]d <%% server_name)`
+
+// Weights applied to the parts of the search document, matching ts_rank's defaults for the
+// A, B and C labels assigned in the search_vector column
+const (
+	searchWeightName        = 1.0
+	searchWeightTitle       = 0.4
+	searchWeightPackage     = 0.4
+	searchWeightDescription = 0.2
+)
+
+// trigramMatchThreshold is pg_trgm's default pg_trgm.word_similarity_threshold
+const trigramMatchThreshold = 0.6
+
+// formatSearchCursor builds the cursor that follows the given row of a search result
+func formatSearchCursor(rank float32, serverName, version string) string {
+	return strconv.FormatFloat(float64(rank), 'g', -1, 32) + ":" + serverName + ":" + version
+}
+
+// parseSearchCursor splits a search cursor into its parts
+func parseSearchCursor(cursor string) (float32, string, string, error) {
+	parts := strings.SplitN(cursor, ":", 3)
+	if len(parts) != 3 {
+		return 0, "", "", fmt.Errorf("%w: malformed cursor", ErrInvalidInput)
+	}
+	rank, err := strconv.ParseFloat(parts[0], 32)
+	if err != nil {
+		return 0, "", "", fmt.Errorf("%w: malformed cursor", ErrInvalidInput)
+	}
+	return float32(rank), parts[1], parts[2], nil
+}
+
+// searchRank approximates the PostgreSQL search in memory: every search word must appear in
+// the name, title, package identifiers or description, the name contains the term, or a word
+// of the name is within trigram distance of it. Words are compared without stemming.
+func (row memoryServer) searchRank(term string) (float32, bool, error) {
+	var serverJSON apiv0.ServerJSON
+	if err := json.Unmarshal(row.value, &serverJSON); err != nil {
+		return 0, false, fmt.Errorf("failed to unmarshal server JSON: %w", err)
+	}
+
+	fields := []struct {
+		words  []string
+		weight float32
+	}{
+		{searchWords(row.serverName), searchWeightName},
+		{searchWords(serverJSON.Title), searchWeightTitle},
+		{searchWords(serverJSON.Description), searchWeightDescription},
+	}
+	for _, pkg := range serverJSON.Packages {
+		fields = append(fields, struct {
+			words  []string
+			weight float32
+		}{searchWords(pkg.Identifier), searchWeightPackage})
+	}
+
+	var textRank float32
+	textMatch := true
+	termWords := searchWords(term)
+	for _, word := range termWords {
+		var best float32
+		for _, field := range fields {
+			for _, candidate := range field.words {
+				if candidate == word && field.weight > best {
+					best = field.weight
+				}
+			}
+		}
+		if best == 0 {
+			textMatch = false
+		}
+		textRank += best
+	}
+	if !textMatch || len(termWords) == 0 {
+		textRank = 0
+		textMatch = false
+	}
+
+	similarity := wordSimilarity(term, row.serverName)
+	substring := strings.Contains(strings.ToLower(row.serverName), strings.ToLower(term))
+	if !textMatch && !substring && similarity < trigramMatchThreshold {
+		return 0, false, nil
+	}
+	return textRank + 0.1*similarity, true, nil
+}
+
+// searchWords splits text into lower-case alphanumeric words
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// wordSimilarity approximates pg_trgm's word_similarity: the largest share of the term's
+// trigrams found in a single word of the text
+func wordSimilarity(term, text string) float32 {
+	termTrigrams := trigrams(term)
+	if len(termTrigrams) == 0 {
+		return 0
+	}
+
+	var best float32
+	for _, word := range searchWords(text) {
+		wordTrigrams := trigrams(word)
+		shared := 0
+		for trigram := range termTrigrams {
+			if wordTrigrams[trigram] {
+				shared++
+			}
+		}
+		if similarity := float32(shared) / float32(len(termTrigrams)); similarity > best {
+			best = similarity
+		}
+	}
+	return best
+}
+
+// trigrams returns the set of trigrams of each word, padded the way pg_trgm pads them
+func trigrams(text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range searchWords(text) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+	return set
+}
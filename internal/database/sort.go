@@ -0,0 +1,136 @@
+package database
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fields that server listings can be sorted by
+const (
+	SortByName        = "name"         // name, then release order
+	SortByUpdatedAt   = "updated_at"   // last update time, then name and version
+	SortByPublishedAt = "published_at" // publish time, then name and version
+)
+
+// ServerSort is the order of the results of ListServers
+type ServerSort struct {
+	Field      string // one of the SortBy constants
+	Descending bool
+}
+
+// IsValid reports whether the sort is on a known field
+func (s ServerSort) IsValid() bool {
+	switch s.Field {
+	case SortByName, SortByUpdatedAt, SortByPublishedAt:
+		return true
+	}
+	return false
+}
+
+// String returns the sort in the "field:direction" form accepted by the API
+func (s ServerSort) String() string {
+	if s.Descending {
+		return s.Field + ":desc"
+	}
+	return s.Field + ":asc"
+}
+
+// ParseServerSort parses a sort in the "field:direction" form, where the direction is "asc" or
+// "desc" and defaults to ascending
+func ParseServerSort(value string) (ServerSort, error) {
+	field, direction, _ := strings.Cut(value, ":")
+	sort := ServerSort{Field: field}
+	switch direction {
+	case "", "asc":
+	case "desc":
+		sort.Descending = true
+	default:
+		return ServerSort{}, fmt.Errorf("%w: unknown sort direction %q", ErrInvalidInput, direction)
+	}
+	if !sort.IsValid() {
+		return ServerSort{}, fmt.Errorf("%w: unknown sort field %q", ErrInvalidInput, field)
+	}
+	return sort, nil
+}
+
+// serverSort returns the sort requested by a filter, and whether search results keep their
+// relevance order because no sort was requested
+func serverSort(filter *ServerFilter) (ServerSort, bool) {
+	if filter != nil && filter.Sort != nil {
+		return *filter.Sort, false
+	}
+	return ServerSort{Field: SortByName}, filter != nil && filter.Search != nil
+}
+
+// Listings sorted by time use "unixNanos:serverName:version" cursors. Server names cannot
+// contain colons, so everything after the second colon is the version.
+
+// byTime reports whether the sort is on a timestamp column
+func (s ServerSort) byTime() bool {
+	return s.Field == SortByUpdatedAt || s.Field == SortByPublishedAt
+}
+
+// sortTime returns the value of the sort's timestamp column for a row
+func (s ServerSort) sortTime(updatedAt, publishedAt time.Time) time.Time {
+	if s.Field == SortByUpdatedAt {
+		return updatedAt
+	}
+	return publishedAt
+}
+
+// formatNameCursor builds the cursor that follows the given row of a listing in name and release order
+func formatNameCursor(serverName, version string) string {
+	return serverName + ":" + version
+}
+
+// parseNameCursor splits a cursor of a listing in name and release order into its parts
+func parseNameCursor(cursor string) (string, string, error) {
+	serverName, version, ok := strings.Cut(cursor, ":")
+	if !ok || serverName == "" || version == "" {
+		return "", "", fmt.Errorf("%w: malformed cursor", ErrInvalidInput)
+	}
+	return serverName, version, nil
+}
+
+// formatTimeCursor builds the cursor that follows the given row of a listing sorted by time
+func formatTimeCursor(t time.Time, serverName, version string) string {
+	return strconv.FormatInt(t.UnixNano(), 10) + ":" + serverName + ":" + version
+}
+
+// parseTimeCursor splits a cursor of a listing sorted by time into its parts
+func parseTimeCursor(cursor string) (time.Time, string, string, error) {
+	parts := strings.SplitN(cursor, ":", 3)
+	if len(parts) != 3 {
+		return time.Time{}, "", "", fmt.Errorf("%w: malformed cursor", ErrInvalidInput)
+	}
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, "", "", fmt.Errorf("%w: malformed cursor", ErrInvalidInput)
+	}
+	return time.Unix(0, nanos), parts[1], parts[2], nil
+}
+
+// compare orders two rows by the sort, matching the ORDER BY of the PostgreSQL implementation
+func (s ServerSort) compare(a, b memoryServer) int {
+	var c int
+	if s.byTime() {
+		c = cmp.Or(
+			s.sortTime(a.updatedAt, a.publishedAt).Compare(s.sortTime(b.updatedAt, b.publishedAt)),
+			strings.Compare(a.serverName, b.serverName),
+			strings.Compare(a.version, b.version),
+		)
+	} else {
+		c = cmp.Or(
+			strings.Compare(a.serverName, b.serverName),
+			strings.Compare(a.versionSortKey, b.versionSortKey),
+			strings.Compare(a.version, b.version),
+		)
+	}
+	if s.Descending {
+		return -c
+	}
+	return c
+}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+// VersionSortKey returns a string whose byte order matches service.CompareVersions: semantic
+// versions sort by precedence and above every non-semantic version, which sort by publish time.
+// It is stored in the version_sort_key column (collated "C") so that version ordering and cursor
+// comparisons can happen in SQL. Versions with equal precedence (e.g. differing only in build
+// metadata) get equal keys; callers break ties on the version string.
+//
+// Semantic versions encode as "1" followed by major, minor and patch, each prefixed with its digit
+// count, then "~" for a release or "-" and the prerelease identifiers separated by spaces.
+// Numeric identifiers encode as "0" plus their length-prefixed digits and alphanumeric ones as
+// "1" plus the identifier, so that numeric identifiers sort first, and a shorter list of
+// identifiers sorts before a longer one that it prefixes. Other versions encode as "0" followed
+// by the UTC publish time.
+func VersionSortKey(version string, publishedAt time.Time) string {
+	if !isSemanticVersion(version) {
+		// PostgreSQL stores microseconds, so finer precision would make keys depend on whether
+		// the time came from the caller or from the database
+		return "0" + publishedAt.UTC().Truncate(time.Microsecond).Format("2006-01-02T15:04:05.000000Z")
+	}
+
+	v := version
+	if !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	core := strings.TrimPrefix(semver.Canonical(v), "v")
+	if idx := strings.Index(core, "-"); idx != -1 {
+		core = core[:idx]
+	}
+
+	var key strings.Builder
+	key.WriteString("1")
+	for _, part := range strings.Split(core, ".") {
+		key.WriteString(numericSortKey(part))
+	}
+
+	prerelease := strings.TrimPrefix(semver.Prerelease(v), "-")
+	if prerelease == "" {
+		key.WriteString("~")
+		return key.String()
+	}
+
+	key.WriteString("-")
+	for i, identifier := range strings.Split(prerelease, ".") {
+		if i > 0 {
+			key.WriteString(" ")
+		}
+		if strings.Trim(identifier, "0123456789") == "" {
+			key.WriteString("0" + numericSortKey(identifier))
+		} else {
+			key.WriteString("1" + identifier)
+		}
+	}
+	return key.String()
+}
+
+// numericSortKey prefixes a decimal number without leading zeros with its length, so that
+// numbers of up to 99 digits compare correctly as strings
+func numericSortKey(digits string) string {
+	return fmt.Sprintf("%02d%s", len(digits), digits)
+}
+
+// isSemanticVersion mirrors service.IsSemanticVersion: a valid semantic version with exactly
+// major.minor.patch, optionally prefixed with "v"
+func isSemanticVersion(version string) bool {
+	v := version
+	if !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	if !semver.IsValid(v) {
+		return false
+	}
+	core := strings.TrimPrefix(v, "v")
+	if idx := strings.IndexAny(core, "-+"); idx != -1 {
+		core = core[:idx]
+	}
+	return strings.Count(core, ".") == 2
+}
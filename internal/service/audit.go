@@ -0,0 +1,269 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Audit actions recorded for registry mutations
+const (
+	AuditActionPublish    = "publish"
+	AuditActionEdit       = "edit"
+	AuditActionRestore    = "restore"
+	AuditActionPromote    = "promote"
+	AuditActionStatus     = "status"
+	AuditActionBulkStatus = "bulk_status"
+)
+
+// ActorMethodSystem is recorded as the actor of changes that were not made through an
+// authenticated request, such as seeding the database at startup
+const ActorMethodSystem = "system"
+
+// auditContextKey is the context key under which the audit details of a request are stored
+type auditContextKey struct{}
+
+// auditDetails describes who is making a change, from where and why
+type auditDetails struct {
+	actorMethod  string
+	actorSubject string
+	admin        bool // whether the actor has admin permissions, exempting them from publish limits
+	sourceIP     string
+	reason       string
+}
+
+// auditDetailsFromContext returns the audit details stored in ctx, defaulting to the system actor
+func auditDetailsFromContext(ctx context.Context) auditDetails {
+	if details, ok := ctx.Value(auditContextKey{}).(auditDetails); ok {
+		return details
+	}
+	return auditDetails{actorMethod: ActorMethodSystem}
+}
+
+// WithActor records the authenticated actor making changes with ctx, identified by the auth
+// method and subject of their registry token
+func WithActor(ctx context.Context, authMethod, subject string) context.Context {
+	details := auditDetailsFromContext(ctx)
+	details.actorMethod = authMethod
+	details.actorSubject = subject
+	return context.WithValue(ctx, auditContextKey{}, details)
+}
+
+// WithAdmin records that the actor making changes with ctx is an admin, whose publishes are not
+// subject to publish limits
+func WithAdmin(ctx context.Context) context.Context {
+	details := auditDetailsFromContext(ctx)
+	details.admin = true
+	return context.WithValue(ctx, auditContextKey{}, details)
+}
+
+// WithSourceIP records the client IP of the request making changes with ctx
+func WithSourceIP(ctx context.Context, ip string) context.Context {
+	details := auditDetailsFromContext(ctx)
+	details.sourceIP = ip
+	return context.WithValue(ctx, auditContextKey{}, details)
+}
+
+// WithAuditReason records why the changes made with ctx are being made
+func WithAuditReason(ctx context.Context, reason string) context.Context {
+	details := auditDetailsFromContext(ctx)
+	details.reason = reason
+	return context.WithValue(ctx, auditContextKey{}, details)
+}
+
+// recordAudit appends an audit event for a change to a server version within the mutation's transaction
+func (s *registryServiceImpl) recordAudit(ctx context.Context, tx database.Tx, action string, before, after *apiv0.ServerResponse) error {
+	details := auditDetailsFromContext(ctx)
+	event := &database.AuditEvent{
+		Action:       action,
+		ActorMethod:  details.actorMethod,
+		ActorSubject: details.actorSubject,
+		SourceIP:     details.sourceIP,
+		Reason:       details.reason,
+	}
+
+	// Both snapshots, when present, are of the same server version
+	for _, server := range []*apiv0.ServerResponse{before, after} {
+		if server != nil {
+			event.ServerName = server.Server.Name
+			event.Version = server.Server.Version
+		}
+	}
+
+	var err error
+	if event.Before, err = auditSnapshot(before); err != nil {
+		return err
+	}
+	if event.After, err = auditSnapshot(after); err != nil {
+		return err
+	}
+	if event.Before != nil && event.After != nil {
+		if event.Diff, err = auditDiff(event.Before, event.After); err != nil {
+			return err
+		}
+	}
+
+	if err := s.db.CreateAuditEvent(ctx, tx, event); err != nil {
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+	return nil
+}
+
+// auditSnapshot serializes a server version for the audit log, returning nil for no version
+func auditSnapshot(server *apiv0.ServerResponse) (json.RawMessage, error) {
+	if server == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit snapshot: %w", err)
+	}
+	return data, nil
+}
+
+// jsonPatchOperation is a single operation of an RFC 6902 JSON Patch
+type jsonPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// auditDiff computes an RFC 6902 JSON Patch that turns the before snapshot into the after snapshot
+func auditDiff(before, after json.RawMessage) (json.RawMessage, error) {
+	var beforeValue, afterValue any
+	if err := unmarshalAuditSnapshot(before, &beforeValue); err != nil {
+		return nil, err
+	}
+	if err := unmarshalAuditSnapshot(after, &afterValue); err != nil {
+		return nil, err
+	}
+
+	operations := []jsonPatchOperation{}
+	if err := diffJSONValues("", beforeValue, afterValue, &operations); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(operations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit diff: %w", err)
+	}
+	return data, nil
+}
+
+// unmarshalAuditSnapshot decodes a snapshot keeping numbers as written, so that they compare exactly
+func unmarshalAuditSnapshot(data json.RawMessage, value *any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(value); err != nil {
+		return fmt.Errorf("failed to decode audit snapshot: %w", err)
+	}
+	return nil
+}
+
+// diffJSONValues appends the operations turning before into after at path. Objects are compared
+// member by member and arrays element by element, with elements added or removed at the end;
+// any other change replaces the value.
+func diffJSONValues(path string, before, after any, operations *[]jsonPatchOperation) error {
+	switch beforeValue := before.(type) {
+	case map[string]any:
+		if afterValue, ok := after.(map[string]any); ok {
+			return diffJSONObjects(path, beforeValue, afterValue, operations)
+		}
+	case []any:
+		if afterValue, ok := after.([]any); ok {
+			return diffJSONArrays(path, beforeValue, afterValue, operations)
+		}
+	}
+
+	if reflect.DeepEqual(before, after) {
+		return nil
+	}
+	return appendJSONPatchOperation(operations, "replace", path, after)
+}
+
+func diffJSONObjects(path string, before, after map[string]any, operations *[]jsonPatchOperation) error {
+	keys := make([]string, 0, len(before)+len(after))
+	for key := range before {
+		keys = append(keys, key)
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		memberPath := path + "/" + escapeJSONPointer(key)
+		beforeMember, inBefore := before[key]
+		afterMember, inAfter := after[key]
+		var err error
+		switch {
+		case !inAfter:
+			err = appendJSONPatchOperation(operations, "remove", memberPath, nil)
+		case !inBefore:
+			err = appendJSONPatchOperation(operations, "add", memberPath, afterMember)
+		default:
+			err = diffJSONValues(memberPath, beforeMember, afterMember, operations)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func diffJSONArrays(path string, before, after []any, operations *[]jsonPatchOperation) error {
+	common := min(len(before), len(after))
+	for i := 0; i < common; i++ {
+		if err := diffJSONValues(path+"/"+strconv.Itoa(i), before[i], after[i], operations); err != nil {
+			return err
+		}
+	}
+	for i := common; i < len(after); i++ {
+		if err := appendJSONPatchOperation(operations, "add", path+"/"+strconv.Itoa(i), after[i]); err != nil {
+			return err
+		}
+	}
+	// Remove from the end so that earlier indices stay valid
+	for i := len(before) - 1; i >= common; i-- {
+		if err := appendJSONPatchOperation(operations, "remove", path+"/"+strconv.Itoa(i), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func appendJSONPatchOperation(operations *[]jsonPatchOperation, op, path string, value any) error {
+	operation := jsonPatchOperation{Op: op, Path: path}
+	if op != "remove" {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal audit diff value: %w", err)
+		}
+		operation.Value = data
+	}
+	*operations = append(*operations, operation)
+	return nil
+}
+
+// escapeJSONPointer escapes a member name for use as an RFC 6901 JSON Pointer reference token
+func escapeJSONPointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// ListAuditEvents returns audit log entries newest first with cursor-based pagination and optional filtering
+func (s *registryServiceImpl) ListAuditEvents(ctx context.Context, filter *database.AuditEventFilter, cursor string, limit int) ([]*database.AuditEvent, string, error) {
+	if limit <= 0 {
+		limit = 30
+	}
+
+	return s.db.ListAuditEvents(ctx, nil, filter, cursor, limit)
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// BulkStatusChange is a server version whose status is changed by BulkSetServerStatus
+type BulkStatusChange struct {
+	ServerName     string
+	Version        string
+	PreviousStatus model.Status
+	Status         model.Status
+}
+
+// BulkSetServerStatus sets the status of every version of a server, or of every server in a
+// namespace, in a single transaction, with a message explaining it to consumers. Drafts are left
+// alone, as are deleted versions unless status is deleted, since undeleting versions needs a
+// decision per version. Each changed version gets its own audit event. With dryRun, nothing is
+// written and the returned changes are those that would be made.
+func (s *registryServiceImpl) BulkSetServerStatus(ctx context.Context, serverName, namespace string, status model.Status, message string, dryRun bool) ([]BulkStatusChange, error) {
+	if (serverName == "") == (namespace == "") {
+		return nil, fmt.Errorf("%w: give either a server name or a namespace", database.ErrInvalidInput)
+	}
+	var details *database.StatusDetails
+	switch status {
+	case model.StatusActive:
+		if message != "" {
+			return nil, fmt.Errorf("%w: active versions cannot have a deprecation message", database.ErrInvalidInput)
+		}
+	case model.StatusDeprecated, model.StatusDeleted:
+		if message != "" {
+			details = &database.StatusDetails{Message: message}
+		}
+	default:
+		return nil, fmt.Errorf("%w: status must be %s, %s or %s", database.ErrInvalidInput, model.StatusActive, model.StatusDeprecated, model.StatusDeleted)
+	}
+
+	changes, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx database.Tx) ([]BulkStatusChange, error) {
+		serverNames := []string{serverName}
+		if serverName == "" {
+			var err error
+			if serverNames, err = s.db.ListServerNames(ctx, tx, namespace); err != nil {
+				return nil, err
+			}
+		}
+
+		// Take every publish lock before the first write, in name order, so that a bulk change
+		// never waits for a lock while holding locks or row changes that other writers wait for
+		if !dryRun {
+			slices.Sort(serverNames)
+			for _, name := range serverNames {
+				if err := s.db.AcquirePublishLock(ctx, tx, name); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		var changes []BulkStatusChange
+		for _, name := range serverNames {
+			serverChanges, err := s.bulkSetStatusOfServer(ctx, tx, name, status, details, dryRun)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, serverChanges...)
+		}
+		return changes, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !dryRun && len(changes) > 0 {
+		serverNames := make([]string, 0, len(changes))
+		for _, change := range changes {
+			serverNames = append(serverNames, change.ServerName)
+		}
+		s.cache.invalidate(slices.Compact(serverNames)...)
+		s.webhooks.notify()
+	}
+	return changes, nil
+}
+
+// bulkSetStatusOfServer sets the status of the versions of a server for BulkSetServerStatus. The
+// caller must hold the publish lock of the server unless dryRun is set.
+func (s *registryServiceImpl) bulkSetStatusOfServer(ctx context.Context, tx database.Tx, serverName string, status model.Status, details *database.StatusDetails, dryRun bool) ([]BulkStatusChange, error) {
+	versions, err := s.db.GetAllVersionsByServerName(ctx, tx, serverName)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []BulkStatusChange
+	before := make(map[string]*apiv0.ServerResponse)
+	for _, version := range versions {
+		currentStatus := version.Meta.Official.Status
+		switch {
+		case currentStatus == model.StatusDraft:
+			continue
+		case currentStatus == model.StatusDeleted && status != model.StatusDeleted:
+			continue
+		case currentStatus == status && hasStatusDetails(version, details):
+			continue
+		}
+		changes = append(changes, BulkStatusChange{
+			ServerName:     serverName,
+			Version:        version.Server.Version,
+			PreviousStatus: currentStatus,
+			Status:         status,
+		})
+		before[version.Server.Version] = version
+	}
+	if dryRun || len(changes) == 0 {
+		return changes, nil
+	}
+
+	for _, change := range changes {
+		if _, err := s.db.SetServerStatus(ctx, tx, serverName, change.Version, string(status), details); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := s.recomputeLatest(ctx, tx, serverName); err != nil {
+		return nil, err
+	}
+
+	// Read the versions back so that audit events and webhooks see their final latest flags
+	versions, err = s.db.GetAllVersionsByServerName(ctx, tx, serverName)
+	if err != nil {
+		return nil, err
+	}
+	for _, after := range versions {
+		previous, ok := before[after.Server.Version]
+		if !ok {
+			continue
+		}
+		if err := s.recordAudit(ctx, tx, AuditActionBulkStatus, previous, after); err != nil {
+			return nil, err
+		}
+		if statusChanged(previous, after) {
+			if err := s.enqueueWebhookEvent(ctx, tx, WebhookEventStatusChanged, after); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return changes, nil
+}
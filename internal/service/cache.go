@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// maxCachedReads bounds the number of entries of the read cache. Once it is reached the cache
+// starts over empty, which is cheap since published servers are read far more than written.
+const maxCachedReads = 10000
+
+// readCache is a read-through cache of server reads. Entries are dropped when the servers they
+// come from are written by this instance. Invalidation is local to the instance: other instances
+// keep serving their cached reads of a server for up to the TTL after it was written.
+//
+// Reads that fill the cache may go to a read replica, which can lag behind the writes that
+// invalidated it. Until the replica is sure to have caught up, fills for servers written by this
+// instance, and fills of listings, read from the primary instead. A nil readCache caches nothing.
+type readCache struct {
+	ttl           time.Duration
+	primaryWindow time.Duration // how long after an invalidation fills read from the primary
+
+	mu                 sync.Mutex
+	generation         uint64                           // incremented by every invalidation, see put
+	servers            map[string]map[string]cacheEntry // reads of a single server, by server name and read
+	lists              map[string]cacheEntry            // listings, which can contain any server, by read
+	size               int
+	serversInvalidated map[string]time.Time // when servers were last invalidated, within primaryWindow
+	listsInvalidated   time.Time            // when listings were last invalidated
+}
+
+// cacheEntry is a cached read result and when it stops being fresh
+type cacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+// newReadCache creates a read cache keeping entries for ttl, or nil if ttl is not positive.
+// Fills read from the primary for primaryWindow after an invalidation, which should be how far
+// reads may lag behind writes.
+func newReadCache(ttl, primaryWindow time.Duration) *readCache {
+	if ttl <= 0 {
+		return nil
+	}
+	c := &readCache{ttl: ttl, primaryWindow: primaryWindow, serversInvalidated: make(map[string]time.Time)}
+	c.reset()
+	return c
+}
+
+// replicaStaleness is how far reads may lag behind writes, which is only the case when reads
+// go to a read replica
+func replicaStaleness(cfg *config.Config) time.Duration {
+	if cfg.DatabaseReadURL == "" {
+		return 0
+	}
+	return database.MaxReplicaStaleness(cfg.DatabaseReadMaxLag)
+}
+
+// reset empties the cache. The caller must hold mu.
+func (c *readCache) reset() {
+	c.servers = make(map[string]map[string]cacheEntry)
+	c.lists = make(map[string]cacheEntry)
+	c.size = 0
+}
+
+// get returns a fresh cached read of a server, or of a listing when serverName is empty, along
+// with the generation to pass to put when it is not cached
+func (c *readCache) get(serverName, key string) (any, uint64, bool) {
+	if c == nil {
+		return nil, 0, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries := c.lists
+	if serverName != "" {
+		entries = c.servers[serverName]
+	}
+	entry, ok := entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, c.generation, false
+	}
+	return entry.value, c.generation, true
+}
+
+// put caches a read made after get returned generation. Reads that raced with an invalidation
+// may have seen the state from before the write, so they are not cached.
+func (c *readCache) put(serverName, key string, generation uint64, value any) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	if c.size >= maxCachedReads {
+		c.reset()
+	}
+
+	entries := c.lists
+	if serverName != "" {
+		if c.servers[serverName] == nil {
+			c.servers[serverName] = make(map[string]cacheEntry)
+		}
+		entries = c.servers[serverName]
+	}
+	if _, ok := entries[key]; !ok {
+		c.size++
+	}
+	entries[key] = cacheEntry{value: value, expires: time.Now().Add(c.ttl)}
+}
+
+// invalidate drops the cached reads of servers and every cached listing. It must be called
+// after the transaction that wrote the servers committed.
+func (c *readCache) invalidate(serverNames ...string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for _, serverName := range serverNames {
+		c.size -= len(c.servers[serverName])
+		delete(c.servers, serverName)
+	}
+	c.size -= len(c.lists)
+	c.lists = make(map[string]cacheEntry)
+
+	if c.primaryWindow <= 0 {
+		return
+	}
+	now := time.Now()
+	for serverName, invalidated := range c.serversInvalidated {
+		if now.Sub(invalidated) >= c.primaryWindow {
+			delete(c.serversInvalidated, serverName)
+		}
+	}
+	for _, serverName := range serverNames {
+		c.serversInvalidated[serverName] = now
+	}
+	c.listsInvalidated = now
+}
+
+// fillFromPrimary reports whether a read of a server, or of a listing when serverName is empty,
+// must be made on the primary because a replica may not have the writes that invalidated it yet
+func (c *readCache) fillFromPrimary(serverName string) bool {
+	if c == nil || c.primaryWindow <= 0 {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	invalidated := c.listsInvalidated
+	if serverName != "" {
+		invalidated = c.serversInvalidated[serverName]
+	}
+	return time.Since(invalidated) < c.primaryWindow
+}
+
+// serverPage is a cached page of a listing
+type serverPage struct {
+	servers      []*apiv0.ServerResponse
+	nextPosition string
+}
+
+// cachedRead returns the cached result of a read of a server, or of a listing when serverName is
+// empty, making and caching the read with the context it is passed if there is none. Errors are
+// not cached.
+func cachedRead[T any](ctx context.Context, c *readCache, serverName, key string, read func(ctx context.Context) (T, error)) (T, error) {
+	cached, generation, ok := c.get(serverName, key)
+	if ok {
+		return cached.(T), nil
+	}
+
+	if c.fillFromPrimary(serverName) {
+		ctx = database.WithPrimaryReads(ctx)
+	}
+	value, err := read(ctx)
+	if err != nil {
+		return value, err
+	}
+	c.put(serverName, key, generation, value)
+	return value, nil
+}
+
+// cachedSlice is cachedRead for reads returning slices, which are cloned so that callers can
+// filter them without changing the cached slice
+func cachedSlice[S ~[]E, E any](ctx context.Context, c *readCache, serverName, key string, read func(ctx context.Context) (S, error)) (S, error) {
+	value, err := cachedRead(ctx, c, serverName, key, read)
+	return slices.Clone(value), err
+}
+
+// listCacheKey identifies a page of a listing
+func listCacheKey(filter *database.ServerFilter, position string, limit int) (string, error) {
+	data, err := json.Marshal(filter)
+	if err != nil {
+		return "", fmt.Errorf("failed to build cache key: %w", err)
+	}
+	return string(data) + "|" + position + "|" + strconv.Itoa(limit), nil
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// ListServerChanges returns change feed entries with a sequence number after since, oldest first
+func (s *registryServiceImpl) ListServerChanges(ctx context.Context, since int64, limit int) ([]*database.ServerChange, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	return s.db.ListServerChanges(ctx, nil, since, limit)
+}
+
+// ExportServers runs fn with every server version as of a single snapshot, together with the
+// change feed position of the snapshot so consumers can continue from the change feed
+func (s *registryServiceImpl) ExportServers(ctx context.Context, fn func(export *database.ServerExport) error) error {
+	return s.db.ExportServers(ctx, fn)
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// Versions that ResolveServerVersion resolves to the version marked with the matching flag
+const (
+	VersionLatest       = "latest"
+	VersionLatestStable = "latest-stable"
+)
+
+// channelNameRegex restricts channel names to lowercase tags like npm dist-tags, e.g. "next" or "beta-2"
+var channelNameRegex = regexp.MustCompile(`^[a-z][a-z0-9._-]{0,63}$`)
+
+// validateChannelName checks that a channel name can not be mistaken for a version
+func validateChannelName(channel string) error {
+	if !channelNameRegex.MatchString(channel) {
+		return fmt.Errorf("%w: channel names must start with a lowercase letter and contain at most 64 lowercase letters, digits, '.', '_' or '-'", database.ErrInvalidInput)
+	}
+	if channel == VersionLatest || channel == VersionLatestStable {
+		return fmt.Errorf("%w: channel name %q is reserved", database.ErrInvalidInput, channel)
+	}
+	if IsSemanticVersion(channel) {
+		return fmt.Errorf("%w: channel names must not be versions", database.ErrInvalidInput)
+	}
+	return nil
+}
+
+// ResolveServerVersion retrieves the version of a server that ref refers to: "latest",
+// "latest-stable", an exact version, or else the name of a channel of the server
+func (s *registryServiceImpl) ResolveServerVersion(ctx context.Context, serverName, ref string) (*apiv0.ServerResponse, error) {
+	switch ref {
+	case VersionLatest:
+		return s.GetServerByName(ctx, serverName)
+	case VersionLatestStable:
+		server, err := cachedRead(ctx, s.cache, serverName, VersionLatestStable, func(ctx context.Context) (*apiv0.ServerResponse, error) {
+			return s.currentLatestStable(ctx, nil, serverName)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if server == nil {
+			return nil, database.ErrNotFound
+		}
+		return server, nil
+	}
+
+	// Versions take precedence, so that a channel can never hide a version of the same name
+	server, err := s.GetServerByNameAndVersion(ctx, serverName, ref)
+	if err == nil || !errors.Is(err, database.ErrNotFound) || validateChannelName(ref) != nil {
+		return server, err
+	}
+	channel, err := s.db.GetServerChannel(ctx, nil, serverName, ref)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetServerByNameAndVersion(ctx, serverName, channel.Version)
+}
+
+// ListServerChannels retrieves the channels of a server, returning ErrNotFound if the server does not exist
+func (s *registryServiceImpl) ListServerChannels(ctx context.Context, serverName string) ([]*database.ServerChannel, error) {
+	count, err := s.db.CountServerVersions(ctx, nil, serverName)
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, database.ErrNotFound
+	}
+	return s.db.ListServerChannels(ctx, nil, serverName)
+}
+
+// SetServerChannel points a channel of a server at one of its published versions on behalf of its
+// publisher, creating the channel if needed
+func (s *registryServiceImpl) SetServerChannel(ctx context.Context, serverName, channel, version string) (*database.ServerChannel, error) {
+	if err := validateChannelName(channel); err != nil {
+		return nil, err
+	}
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx database.Tx) (*database.ServerChannel, error) {
+		// Serialize with publishes and status changes of the server
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+
+		// Moving channels is publishing, so it is subject to the same ownership check
+		if err := s.checkNamespaceOwner(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+
+		server, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
+		if err != nil {
+			return nil, err
+		}
+		switch server.Meta.Official.Status {
+		case model.StatusDraft:
+			return nil, fmt.Errorf("%w: channels cannot point at drafts", database.ErrInvalidInput)
+		case model.StatusDeleted:
+			return nil, fmt.Errorf("%w: channels cannot point at deleted versions", database.ErrInvalidInput)
+		}
+
+		return s.db.SetServerChannel(ctx, tx, &database.ServerChannel{ServerName: serverName, Channel: channel, Version: version})
+	})
+}
+
+// DeleteServerChannel deletes a channel of a server on behalf of its publisher
+func (s *registryServiceImpl) DeleteServerChannel(ctx context.Context, serverName, channel string) error {
+	return s.db.InTransaction(ctx, func(ctx context.Context, tx database.Tx) error {
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return err
+		}
+		if err := s.checkNamespaceOwner(ctx, tx, serverName); err != nil {
+			return err
+		}
+		return s.db.DeleteServerChannel(ctx, tx, serverName, channel)
+	})
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// listCursor is the content of a server list cursor. Clients get it base64-encoded and signed,
+// so they can only hand back cursors the registry issued, and only for the listing they came from.
+type listCursor struct {
+	Position   string `json:"p"` // database cursor of the last row of the previous page
+	Sort       string `json:"s"` // sort of the listing, empty for the default order
+	FilterHash string `json:"f"` // filterHash of the listing's filter
+}
+
+// cursorSigningKey returns the key list cursors are signed with: the configured key, else one
+// derived from the JWT private key so that every instance of a deployment agrees, else a random
+// key, with which cursors only stay valid for the lifetime of the process
+func cursorSigningKey(cfg *config.Config) []byte {
+	if cfg.CursorSigningKey != "" {
+		return []byte(cfg.CursorSigningKey)
+	}
+	if cfg.JWTPrivateKey != "" {
+		mac := hmac.New(sha256.New, []byte(cfg.JWTPrivateKey))
+		mac.Write([]byte("mcp-registry list cursor"))
+		return mac.Sum(nil)
+	}
+
+	log.Println("No cursor signing key or JWT private key configured: list cursors are only valid until the registry restarts")
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("failed to generate cursor signing key: %v", err))
+	}
+	return key
+}
+
+// filterHash identifies the conditions of a filter, ignoring its sort
+func filterHash(filter *database.ServerFilter) (string, error) {
+	conditions := database.ServerFilter{}
+	if filter != nil {
+		conditions = *filter
+		conditions.Sort = nil
+	}
+	data, err := json.Marshal(conditions)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash filter: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// listingSort returns the sort of a filter as stored in cursors
+func listingSort(filter *database.ServerFilter) string {
+	if filter == nil || filter.Sort == nil {
+		return ""
+	}
+	return filter.Sort.String()
+}
+
+// encodeCursor wraps a database cursor into an opaque cursor of the listing with filter
+func (s *registryServiceImpl) encodeCursor(position string, filter *database.ServerFilter) (string, error) {
+	if position == "" {
+		return "", nil
+	}
+
+	hash, err := filterHash(filter)
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(listCursor{Position: position, Sort: listingSort(filter), FilterHash: hash})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, s.cursorKey)
+	mac.Write(payload)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// decodeCursor verifies an opaque cursor and returns its database cursor. Cursors that were
+// not issued by this registry, or were issued for a listing with another filter or sort, are
+// rejected as invalid input.
+func (s *registryServiceImpl) decodeCursor(cursor string, filter *database.ServerFilter) (string, error) {
+	if cursor == "" {
+		return "", nil
+	}
+
+	encodedPayload, encodedMAC, ok := strings.Cut(cursor, ".")
+	if !ok {
+		return "", fmt.Errorf("%w: malformed cursor", database.ErrInvalidInput)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", fmt.Errorf("%w: malformed cursor", database.ErrInvalidInput)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedMAC)
+	if err != nil {
+		return "", fmt.Errorf("%w: malformed cursor", database.ErrInvalidInput)
+	}
+
+	mac := hmac.New(sha256.New, s.cursorKey)
+	mac.Write(payload)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return "", fmt.Errorf("%w: cursor signature does not match", database.ErrInvalidInput)
+	}
+
+	var decoded listCursor
+	if err := json.Unmarshal(payload, &decoded); err != nil || decoded.Position == "" {
+		return "", fmt.Errorf("%w: malformed cursor", database.ErrInvalidInput)
+	}
+
+	hash, err := filterHash(filter)
+	if err != nil {
+		return "", err
+	}
+	if decoded.Sort != listingSort(filter) || decoded.FilterHash != hash {
+		return "", fmt.Errorf("%w: cursor belongs to a listing with a different filter or sort", database.ErrInvalidInput)
+	}
+
+	return decoded.Position, nil
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// ErrNotDraft is returned when promoting a server version that is not a draft
+var ErrNotDraft = errors.New("server version is not a draft")
+
+// draftAccessContextKey is the context key under which the servers whose drafts a reader may see are stored
+type draftAccessContextKey struct{}
+
+// WithDraftAccess lets reads made with ctx return the draft versions of servers for which
+// canView returns true. Other reads behave as if drafts did not exist.
+func WithDraftAccess(ctx context.Context, canView func(serverName string) bool) context.Context {
+	return context.WithValue(ctx, draftAccessContextKey{}, canView)
+}
+
+// isDraft reports whether a server version is a draft
+func isDraft(server *apiv0.ServerResponse) bool {
+	return server.Meta.Official != nil && server.Meta.Official.Status == model.StatusDraft
+}
+
+// visible reports whether the reader of ctx may see a server version
+func visible(ctx context.Context, server *apiv0.ServerResponse) bool {
+	if !isDraft(server) {
+		return true
+	}
+	canView, ok := ctx.Value(draftAccessContextKey{}).(func(string) bool)
+	return ok && canView(server.Server.Name)
+}
+
+// CreateDraftServer publishes a server version as a draft, which is validated and stored like
+// any other version but is not latest, not listed, and only visible to readers allowed by
+// WithDraftAccess until it is promoted
+func (s *registryServiceImpl) CreateDraftServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+	server, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx database.Tx) (*apiv0.ServerResponse, error) {
+		return s.createServerInTransaction(ctx, tx, req, true)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.invalidate(server.Server.Name)
+	return server, nil
+}
+
+// PromoteServer makes a draft version active as of now, and the latest version of its server
+// if it is newer than the current latest, or the current latest is deprecated, and likewise the
+// latest stable version if it is not a prerelease
+func (s *registryServiceImpl) PromoteServer(ctx context.Context, serverName, version string) (*apiv0.ServerResponse, error) {
+	server, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx database.Tx) (*apiv0.ServerResponse, error) {
+		// Serialize with publishes, so that the latest version is decided on a stable set of versions
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+
+		// Promotions are publishes, so they are subject to the same ownership check
+		if err := s.checkNamespaceOwner(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+
+		draft, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
+		if err != nil {
+			return nil, err
+		}
+		if !isDraft(draft) {
+			return nil, ErrNotDraft
+		}
+
+		currentLatest, err := s.db.GetCurrentLatestVersion(ctx, tx, serverName)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, err
+		}
+
+		isNewLatest := outranks(version, model.StatusActive, time.Now(), currentLatest)
+		if isNewLatest && currentLatest != nil {
+			if err := s.db.UnmarkAsLatest(ctx, tx, serverName); err != nil {
+				return nil, err
+			}
+		}
+
+		currentLatestStable, err := s.currentLatestStable(ctx, tx, serverName)
+		if err != nil {
+			return nil, err
+		}
+		isNewLatestStable := IsStableVersion(version) && outranks(version, model.StatusActive, time.Now(), currentLatestStable)
+		if isNewLatestStable && currentLatestStable != nil {
+			if err := s.db.UnmarkAsLatestStable(ctx, tx, serverName); err != nil {
+				return nil, err
+			}
+		}
+
+		promoted, err := s.db.PromoteServer(ctx, tx, serverName, version, isNewLatest, isNewLatestStable)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.recordAudit(ctx, tx, AuditActionPromote, draft, promoted); err != nil {
+			return nil, err
+		}
+
+		if err := s.enqueueWebhookEvent(ctx, tx, WebhookEventPublished, promoted); err != nil {
+			return nil, err
+		}
+
+		return promoted, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.invalidate(serverName)
+	s.webhooks.notify()
+	return server, nil
+}
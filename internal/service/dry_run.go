@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// PackageOutcomeFailed is the outcome of packages that failed registry validation in a dry run
+const PackageOutcomeFailed = "failed"
+
+// Codes of the problems that reject a publish because of the state of the registry, in addition
+// to the validation problems of the server.json
+const (
+	CodeNamespaceOwnedByOther = "namespace_owned_by_other"
+	CodeRemoteURLInUse        = "remote_url_in_use"
+	CodeMaxVersionsReached    = "max_versions_reached"
+	CodeVersionExists         = "version_exists"
+)
+
+// PublishDryRun is what publishing a server version would do, as found by DryRunPublish
+type PublishDryRun struct {
+	Problems       []*validators.Problem // why the publish would be rejected, empty if it would be accepted
+	IsLatest       bool                  // whether the version would become the latest version if accepted
+	IsLatestStable bool                  // whether the version would become the latest stable version if accepted
+	Packages       []PackageOutcome      // the registry validation of each package, in order
+}
+
+// Accepted reports whether the publish would be accepted
+func (r *PublishDryRun) Accepted() bool {
+	return len(r.Problems) == 0
+}
+
+// PackageOutcome is the result of the registry validation of a package in a dry run
+type PackageOutcome struct {
+	RegistryType string
+	Identifier   string
+	Check        string            // which check ran, empty if the package failed
+	Outcome      string            // registries.OutcomePassed, registries.OutcomeSkipped or PackageOutcomeFailed
+	Facts        map[string]string // facts from the upstream registry
+	Error        string            // why the package failed
+}
+
+// DryRunPublish runs the checks of CreateServer, or of CreateDraftServer when draft is set,
+// without writing anything, and reports every problem that would reject the publish rather than
+// only the first. Namespaces without an owner are reported as claimable, and publish limits are
+// neither checked nor counted.
+func (s *registryServiceImpl) DryRunPublish(ctx context.Context, req *apiv0.ServerJSON, draft bool) (*PublishDryRun, error) {
+	result := &PublishDryRun{}
+	reject := func(code, pointer string, err error) {
+		result.Problems = append(result.Problems, &validators.Problem{Code: code, Pointer: pointer, Err: err})
+	}
+
+	// Validate the request, checking every package even after one fails
+	err := validators.ValidatePublishRequestJSON(*req)
+	if problems := validators.ProblemsOf(err); problems != nil {
+		result.Problems = append(result.Problems, problems...)
+	} else if err != nil {
+		return nil, err
+	}
+	for i, check := range validators.CheckPackages(ctx, *req, s.cfg.EnableRegistryValidation) {
+		pkg := req.Packages[i]
+		outcome := PackageOutcome{RegistryType: pkg.RegistryType, Identifier: pkg.Identifier}
+		if check.Err != nil {
+			reject(validators.CodePackageValidationFailed, fmt.Sprintf("/packages/%d", i), check.Err)
+			outcome.Outcome = PackageOutcomeFailed
+			outcome.Error = check.Err.Error()
+		} else {
+			outcome.Check = check.Evidence.Check
+			outcome.Outcome = check.Evidence.Outcome
+			outcome.Facts = check.Evidence.Facts
+		}
+		result.Packages = append(result.Packages, outcome)
+	}
+
+	// Check the namespace, remote URLs and version against the current state, as the publish would
+	if err := s.checkNamespaceOwnerWithoutClaim(ctx, nil, req.Name); err != nil {
+		if !errors.Is(err, ErrNamespaceOwnedByOther) {
+			return nil, err
+		}
+		reject(CodeNamespaceOwnedByOther, "/name", err)
+	}
+
+	var conflictErr *remoteURLConflictError
+	if err := s.checkRemoteURLsAvailable(ctx, nil, *req); errors.As(err, &conflictErr) {
+		reject(CodeRemoteURLInUse, fmt.Sprintf("/remotes/%d/url", conflictErr.Index), err)
+	} else if err != nil {
+		return nil, err
+	}
+
+	versionCount, err := s.db.CountServerVersions(ctx, nil, req.Name)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return nil, err
+	}
+	if versionCount >= maxServerVersionsPerServer {
+		reject(CodeMaxVersionsReached, "/name", database.ErrMaxServersReached)
+	}
+
+	versionExists, err := s.db.CheckVersionExists(ctx, nil, req.Name, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	if versionExists {
+		reject(CodeVersionExists, "/version", database.ErrInvalidVersion)
+	}
+
+	// Work out whether the version would become latest, as if it was published now
+	currentLatest, err := s.db.GetCurrentLatestVersion(ctx, nil, req.Name)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return nil, err
+	}
+	currentLatestStable, err := s.currentLatestStable(ctx, nil, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	result.IsLatest = !draft && outranks(req.Version, model.StatusActive, now, currentLatest)
+	result.IsLatestStable = !draft && IsStableVersion(req.Version) && outranks(req.Version, model.StatusActive, now, currentLatestStable)
+
+	return result, nil
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// LatestChange is a server whose latest or latest stable version was moved by RecomputeLatest
+type LatestChange struct {
+	ServerName     string
+	Previous       string // version that was latest, empty if none was
+	Latest         string // version that is now latest, empty if no version can be
+	PreviousStable string // version that was latest stable, empty if none was
+	LatestStable   string // version that is now latest stable, empty if no version can be
+}
+
+// latestEligible reports whether versions with a status can be latest: the latest version is the
+// highest active version, and servers without active versions have none. Deprecated and deleted
+// versions and drafts are never latest.
+func latestEligible(status model.Status) bool {
+	return status == model.StatusActive
+}
+
+// outranks reports whether a version with the given status and publish time should be latest
+// rather than current
+func outranks(version string, status model.Status, publishedAt time.Time, current *apiv0.ServerResponse) bool {
+	if !latestEligible(status) {
+		return false
+	}
+	if current == nil || current.Meta.Official == nil || !latestEligible(current.Meta.Official.Status) {
+		return true
+	}
+	return CompareVersions(version, current.Server.Version, publishedAt, current.Meta.Official.PublishedAt) > 0
+}
+
+// latestCandidate returns the version of a server that should be latest, nil if none can be
+func latestCandidate(versions []*apiv0.ServerResponse) *apiv0.ServerResponse {
+	var candidate *apiv0.ServerResponse
+	for _, version := range versions {
+		official := version.Meta.Official
+		if official != nil && outranks(version.Server.Version, official.Status, official.PublishedAt, candidate) {
+			candidate = version
+		}
+	}
+	return candidate
+}
+
+// latestStableCandidate returns the version of a server that should be latest stable, nil if none can be
+func latestStableCandidate(versions []*apiv0.ServerResponse) *apiv0.ServerResponse {
+	return latestCandidate(slices.DeleteFunc(slices.Clone(versions), func(version *apiv0.ServerResponse) bool {
+		return !IsStableVersion(version.Server.Version)
+	}))
+}
+
+// currentLatestStable returns the version of a server that is marked as latest stable, nil if none is
+func (s *registryServiceImpl) currentLatestStable(ctx context.Context, tx database.Tx, serverName string) (*apiv0.ServerResponse, error) {
+	isLatestStable := true
+	versions, _, err := s.db.ListServers(ctx, tx, &database.ServerFilter{Name: &serverName, IsLatestStable: &isLatestStable}, "", 1)
+	if err != nil || len(versions) == 0 {
+		return nil, err
+	}
+	return versions[0], nil
+}
+
+// recomputeLatest moves the latest flag of a server to its latestCandidate and the latest stable
+// flag to its latestStableCandidate, returning nil if both were already there. The caller must
+// hold the publish lock of the server.
+func (s *registryServiceImpl) recomputeLatest(ctx context.Context, tx database.Tx, serverName string) (*LatestChange, error) {
+	versions, err := s.db.GetAllVersionsByServerName(ctx, tx, serverName)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &LatestChange{ServerName: serverName}
+	latestCount, latestStableCount := 0, 0
+	for _, version := range versions {
+		if version.Meta.Official != nil && version.Meta.Official.IsLatest {
+			change.Previous = version.Server.Version
+			latestCount++
+		}
+		if version.Meta.Official != nil && version.Meta.Official.IsLatestStable {
+			change.PreviousStable = version.Server.Version
+			latestStableCount++
+		}
+	}
+	if candidate := latestCandidate(versions); candidate != nil {
+		change.Latest = candidate.Server.Version
+	}
+	if candidate := latestStableCandidate(versions); candidate != nil {
+		change.LatestStable = candidate.Server.Version
+	}
+	latestMoved := change.Previous != change.Latest || latestCount > 1
+	latestStableMoved := change.PreviousStable != change.LatestStable || latestStableCount > 1
+	if !latestMoved && !latestStableMoved {
+		return nil, nil
+	}
+
+	if latestMoved {
+		if err := s.db.UnmarkAsLatest(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+		if change.Latest != "" {
+			if err := s.db.MarkAsLatest(ctx, tx, serverName, change.Latest); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if latestStableMoved {
+		if err := s.db.UnmarkAsLatestStable(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+		if change.LatestStable != "" {
+			if err := s.db.MarkAsLatestStable(ctx, tx, serverName, change.LatestStable); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return change, nil
+}
+
+// recomputeLatestAfterStatusChange recomputes the latest version of the server of a version
+// whose status changed, returning the version as it is afterwards
+func (s *registryServiceImpl) recomputeLatestAfterStatusChange(ctx context.Context, tx database.Tx, server *apiv0.ServerResponse) (*apiv0.ServerResponse, error) {
+	change, err := s.recomputeLatest(ctx, tx, server.Server.Name)
+	if err != nil || change == nil {
+		return server, err
+	}
+	return s.db.GetServerByNameAndVersion(ctx, tx, server.Server.Name, server.Server.Version)
+}
+
+// RecomputeLatest repairs the latest and latest stable flags of a server, of every server in a
+// namespace, or of every server when both are empty, returning the servers whose latest or latest
+// stable version moved and how many servers were checked. Each server is repaired in its own transaction.
+func (s *registryServiceImpl) RecomputeLatest(ctx context.Context, serverName, namespace string) ([]LatestChange, int, error) {
+	if serverName != "" && namespace != "" {
+		return nil, 0, fmt.Errorf("%w: give a server name or a namespace, not both", database.ErrInvalidInput)
+	}
+
+	serverNames := []string{serverName}
+	if serverName == "" {
+		var err error
+		serverNames, err = s.db.ListServerNames(ctx, nil, namespace)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	var changes []LatestChange
+	for _, name := range serverNames {
+		change, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx database.Tx) (*LatestChange, error) {
+			if err := s.db.AcquirePublishLock(ctx, tx, name); err != nil {
+				return nil, err
+			}
+			return s.recomputeLatest(ctx, tx, name)
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		if change != nil {
+			s.cache.invalidate(name)
+			changes = append(changes, *change)
+		}
+	}
+
+	return changes, len(serverNames), nil
+}
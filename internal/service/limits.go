@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// Windows over which publishes are counted against the publish limits
+const (
+	publishRateWindow    = time.Hour
+	newServerQuotaWindow = 24 * time.Hour
+)
+
+// publishEventPruneInterval is how often publishes older than the longest window are deleted
+const publishEventPruneInterval = 10 * time.Minute
+
+// ErrRateLimited is returned, wrapped in a RateLimitError, by publishes that exceed a publish limit
+var ErrRateLimited = errors.New("publish limit exceeded")
+
+// RateLimitError describes the publish limit that a publish exceeded
+type RateLimitError struct {
+	Limit      string        // which limit was exceeded, e.g. "20 new servers per day in namespace com.example"
+	RetryAfter time.Duration // how long until the publish would be within the limit
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%v: %s, retry in %s", ErrRateLimited, e.Limit, e.RetryAfter.Round(time.Second))
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
+
+// defaultPublishLimits returns the configured publish limits of namespaces that have none set
+func (s *registryServiceImpl) defaultPublishLimits(namespace string) *database.PublishLimits {
+	return &database.PublishLimits{
+		Namespace:        namespace,
+		PublishesPerHour: s.cfg.PublishesPerHour,
+		NewServersPerDay: s.cfg.NewServersPerDay,
+	}
+}
+
+// checkPublishLimits verifies that a publish to a server stays within the publish limits of its
+// namespace and of the publisher, and records it to count against later publishes. Changes made
+// by the system, such as seeding, and by admins are not limited.
+func (s *registryServiceImpl) checkPublishLimits(ctx context.Context, tx database.Tx, serverName string, newServer bool) error {
+	details := auditDetailsFromContext(ctx)
+	if details.actorMethod == ActorMethodSystem || details.admin {
+		return nil
+	}
+
+	// Concurrent publishes to the namespace or by the publisher wait for this one to commit, so
+	// that each counts the others
+	namespace := namespaceOf(serverName)
+	if err := s.db.AcquirePublishLimitLocks(ctx, tx, namespace, details.actorSubject); err != nil {
+		return err
+	}
+
+	limits, err := s.db.GetPublishLimits(ctx, tx, namespace)
+	if errors.Is(err, database.ErrNotFound) {
+		limits = s.defaultPublishLimits(namespace)
+	} else if err != nil {
+		return err
+	}
+
+	// Every publish counts against the hourly rate, and publishes of new servers against the daily quota
+	type limitCheck struct {
+		filter database.PublishEventFilter
+		limit  int
+		window time.Duration
+		name   string
+	}
+	checks := []limitCheck{{
+		database.PublishEventFilter{Namespace: &namespace}, limits.PublishesPerHour, publishRateWindow,
+		fmt.Sprintf("%d publishes per hour in namespace %s", limits.PublishesPerHour, namespace),
+	}}
+	if details.actorSubject != "" {
+		checks = append(checks, limitCheck{
+			database.PublishEventFilter{ActorSubject: &details.actorSubject}, s.cfg.PublisherPublishesPerHour, publishRateWindow,
+			fmt.Sprintf("%d publishes per hour by %s", s.cfg.PublisherPublishesPerHour, details.actorSubject),
+		})
+	}
+	if newServer {
+		checks = append(checks, limitCheck{
+			database.PublishEventFilter{Namespace: &namespace, NewServer: true}, limits.NewServersPerDay, newServerQuotaWindow,
+			fmt.Sprintf("%d new servers per day in namespace %s", limits.NewServersPerDay, namespace),
+		})
+		if details.actorSubject != "" {
+			checks = append(checks, limitCheck{
+				database.PublishEventFilter{ActorSubject: &details.actorSubject, NewServer: true}, s.cfg.PublisherNewServersPerDay, newServerQuotaWindow,
+				fmt.Sprintf("%d new servers per day by %s", s.cfg.PublisherNewServersPerDay, details.actorSubject),
+			})
+		}
+	}
+
+	now := time.Now()
+	for _, check := range checks {
+		// A limit of 0 means no limit
+		if check.limit <= 0 {
+			continue
+		}
+		check.filter.Since = now.Add(-check.window)
+		times, err := s.db.ListPublishEventTimes(ctx, tx, &check.filter, check.limit)
+		if err != nil {
+			return err
+		}
+		if len(times) >= check.limit {
+			// The publish is allowed again once the oldest of the last limit publishes leaves the window
+			return &RateLimitError{Limit: check.name, RetryAfter: max(times[check.limit-1].Add(check.window).Sub(now), time.Second)}
+		}
+	}
+
+	return s.db.RecordPublishEvent(ctx, tx, &database.PublishEvent{
+		ActorSubject: details.actorSubject,
+		Namespace:    namespace,
+		ServerName:   serverName,
+		NewServer:    newServer,
+	})
+}
+
+// RunPublishEventPruner deletes recorded publishes once they no longer count against any publish
+// limit, periodically until ctx is done
+func (s *registryServiceImpl) RunPublishEventPruner(ctx context.Context) {
+	ticker := time.NewTicker(publishEventPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.db.DeletePublishEventsBefore(ctx, nil, time.Now().Add(-newServerQuotaWindow)); err != nil && ctx.Err() == nil {
+			log.Printf("Failed to delete old publish events: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ListPublishLimits returns the configured default publish limits, and the limits set for namespaces
+func (s *registryServiceImpl) ListPublishLimits(ctx context.Context) (*database.PublishLimits, []*database.PublishLimits, error) {
+	limits, err := s.db.ListPublishLimits(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.defaultPublishLimits(""), limits, nil
+}
+
+// SetPublishLimits sets the publish limits of a namespace, replacing the defaults or any limits set before
+func (s *registryServiceImpl) SetPublishLimits(ctx context.Context, namespace string, publishesPerHour, newServersPerDay int) (*database.PublishLimits, error) {
+	return s.db.SetPublishLimits(ctx, nil, &database.PublishLimits{
+		Namespace:        namespace,
+		PublishesPerHour: publishesPerHour,
+		NewServersPerDay: newServersPerDay,
+	})
+}
+
+// DeletePublishLimits makes a namespace subject to the default publish limits again
+func (s *registryServiceImpl) DeletePublishLimits(ctx context.Context, namespace string) error {
+	return s.db.DeletePublishLimits(ctx, nil, namespace)
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// VerificationMethodAdmin is recorded as the verification method of namespaces assigned by an admin
+const VerificationMethodAdmin = "admin"
+
+// ErrNamespaceOwnedByOther is returned when publishing to a namespace recorded as owned by
+// an identity other than the ones the publisher's token was issued for
+var ErrNamespaceOwnedByOther = errors.New("namespace is owned by another identity")
+
+// NamespaceOwner is a verified identity that can own namespaces
+type NamespaceOwner struct {
+	Type string // "github" or "domain"
+	ID   string // GitHub account ID, or the domain name
+	Name string // GitHub login, for display
+}
+
+// namespaceOwnersContextKey is the context key under which the publisher's identities are stored
+type namespaceOwnersContextKey struct{}
+
+// publisherIdentities are the verified identities a publish is made on behalf of
+type publisherIdentities struct {
+	method string
+	owners []NamespaceOwner
+}
+
+// WithNamespaceOwners records the verified identities whose permissions allow the publishes
+// made with ctx, and the auth method that verified them. Publishes to a namespace that is
+// recorded as owned by someone else are then rejected, and publishes to an unowned namespace
+// claim it for the first of the identities. Publishes made without identities, e.g. with
+// admin tokens, are not checked.
+func WithNamespaceOwners(ctx context.Context, method string, owners []NamespaceOwner) context.Context {
+	return context.WithValue(ctx, namespaceOwnersContextKey{}, publisherIdentities{method: method, owners: owners})
+}
+
+// namespaceOf returns the namespace of a server name, the part before the "/"
+func namespaceOf(serverName string) string {
+	namespace, _, _ := strings.Cut(serverName, "/")
+	return namespace
+}
+
+// checkNamespaceOwner verifies that the publisher owns the namespace of a server, claiming it
+// if it has no owner yet
+func (s *registryServiceImpl) checkNamespaceOwner(ctx context.Context, tx database.Tx, serverName string) error {
+	publisher, ok := ctx.Value(namespaceOwnersContextKey{}).(publisherIdentities)
+	if !ok || len(publisher.owners) == 0 {
+		return nil
+	}
+
+	claimant := publisher.owners[0]
+	record, err := s.db.ClaimNamespace(ctx, tx, &database.Namespace{
+		Namespace:          namespaceOf(serverName),
+		OwnerType:          claimant.Type,
+		OwnerID:            claimant.ID,
+		OwnerName:          claimant.Name,
+		VerificationMethod: publisher.method,
+		VerifiedAt:         time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	return publisher.checkOwns(record)
+}
+
+// checkNamespaceOwnerWithoutClaim verifies that the publisher owns the namespace of a server, or
+// would claim it because it has no owner yet, without claiming it
+func (s *registryServiceImpl) checkNamespaceOwnerWithoutClaim(ctx context.Context, tx database.Tx, serverName string) error {
+	publisher, ok := ctx.Value(namespaceOwnersContextKey{}).(publisherIdentities)
+	if !ok || len(publisher.owners) == 0 {
+		return nil
+	}
+
+	record, err := s.db.GetNamespace(ctx, tx, namespaceOf(serverName))
+	if errors.Is(err, database.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return publisher.checkOwns(record)
+}
+
+// checkOwns returns ErrNamespaceOwnedByOther unless one of the publisher's identities owns a namespace
+func (p publisherIdentities) checkOwns(record *database.Namespace) error {
+	for _, owner := range p.owners {
+		if owner.Type == record.OwnerType && owner.ID == record.OwnerID {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s belongs to %s %s", ErrNamespaceOwnedByOther, record.Namespace, record.OwnerType, record.OwnerID)
+}
+
+// GetNamespace retrieves the owner record of a namespace
+func (s *registryServiceImpl) GetNamespace(ctx context.Context, namespace string) (*database.Namespace, error) {
+	return s.db.GetNamespace(ctx, nil, namespace)
+}
+
+// ListNamespaces returns namespace owner records ordered by namespace with cursor-based pagination and optional filtering
+func (s *registryServiceImpl) ListNamespaces(ctx context.Context, filter *database.NamespaceFilter, cursor string, limit int) ([]*database.Namespace, string, error) {
+	if limit <= 0 {
+		limit = 30
+	}
+
+	return s.db.ListNamespaces(ctx, nil, filter, cursor, limit)
+}
+
+// TransferNamespace makes owner the owner of a namespace, whether or not it had one
+func (s *registryServiceImpl) TransferNamespace(ctx context.Context, namespace string, owner NamespaceOwner) (*database.Namespace, error) {
+	return s.db.SetNamespaceOwner(ctx, nil, &database.Namespace{
+		Namespace:          namespace,
+		OwnerType:          owner.Type,
+		OwnerID:            owner.ID,
+		OwnerName:          owner.Name,
+		VerificationMethod: VerificationMethodAdmin,
+		VerifiedAt:         time.Now(),
+	})
+}
@@ -4,12 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/validators"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
@@ -18,27 +19,52 @@ const maxServerVersionsPerServer = 10000
 
 // registryServiceImpl implements the RegistryService interface using our Database
 type registryServiceImpl struct {
-	db  database.Database
-	cfg *config.Config
+	db        database.Database
+	cfg       *config.Config
+	cursorKey []byte // signs list cursors, see encodeCursor
+	webhooks  *webhookDispatcher
+	cache     *readCache // nil when caching is disabled
 }
 
 // NewRegistryService creates a new registry service with the provided database
 func NewRegistryService(db database.Database, cfg *config.Config) RegistryService {
 	return &registryServiceImpl{
-		db:  db,
-		cfg: cfg,
+		db:        db,
+		cfg:       cfg,
+		cursorKey: cursorSigningKey(cfg),
+		webhooks:  newWebhookDispatcher(db, cfg),
+		cache:     newReadCache(cfg.CacheTTL, replicaStaleness(cfg)),
 	}
 }
 
-// ListServers returns registry entries with cursor-based pagination and optional filtering
+// ListServers returns registry entries with cursor-based pagination and optional filtering.
+// Cursors are opaque and signed, and only valid for a listing with the same filter and sort.
 func (s *registryServiceImpl) ListServers(ctx context.Context, filter *database.ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error) {
 	// If limit is not set or negative, use a default limit
 	if limit <= 0 {
 		limit = 30
 	}
 
-	// Use the database's ListServers method with pagination and filtering
-	serverRecords, nextCursor, err := s.db.ListServers(ctx, nil, filter, cursor, limit)
+	position, err := s.decodeCursor(cursor, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Use the database's ListServers method with pagination and filtering, through the read cache
+	key, err := listCacheKey(filter, position, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	page, err := cachedRead(ctx, s.cache, "", key, func(ctx context.Context) (serverPage, error) {
+		servers, nextPosition, err := s.db.ListServers(ctx, nil, filter, position, limit)
+		return serverPage{servers, nextPosition}, err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	serverRecords, nextPosition := slices.Clone(page.servers), page.nextPosition
+
+	nextCursor, err := s.encodeCursor(nextPosition, filter)
 	if err != nil {
 		return nil, "", err
 	}
@@ -48,7 +74,9 @@ func (s *registryServiceImpl) ListServers(ctx context.Context, filter *database.
 
 // GetServerByName retrieves the latest version of a server by its server name
 func (s *registryServiceImpl) GetServerByName(ctx context.Context, serverName string) (*apiv0.ServerResponse, error) {
-	serverRecord, err := s.db.GetServerByName(ctx, nil, serverName)
+	serverRecord, err := cachedRead(ctx, s.cache, serverName, VersionLatest, func(ctx context.Context) (*apiv0.ServerResponse, error) {
+		return s.db.GetServerByName(ctx, nil, serverName)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -58,36 +86,58 @@ func (s *registryServiceImpl) GetServerByName(ctx context.Context, serverName st
 
 // GetServerByNameAndVersion retrieves a specific version of a server by server name and version
 func (s *registryServiceImpl) GetServerByNameAndVersion(ctx context.Context, serverName string, version string) (*apiv0.ServerResponse, error) {
-	serverRecord, err := s.db.GetServerByNameAndVersion(ctx, nil, serverName, version)
+	serverRecord, err := cachedRead(ctx, s.cache, serverName, "version:"+version, func(ctx context.Context) (*apiv0.ServerResponse, error) {
+		return s.db.GetServerByNameAndVersion(ctx, nil, serverName, version)
+	})
 	if err != nil {
 		return nil, err
 	}
+	if !visible(ctx, serverRecord) {
+		return nil, database.ErrNotFound
+	}
 
 	return serverRecord, nil
 }
 
 // GetAllVersionsByServerName retrieves all versions of a server by server name
 func (s *registryServiceImpl) GetAllVersionsByServerName(ctx context.Context, serverName string) ([]*apiv0.ServerResponse, error) {
-	serverRecords, err := s.db.GetAllVersionsByServerName(ctx, nil, serverName)
+	serverRecords, err := cachedSlice(ctx, s.cache, serverName, "versions", func(ctx context.Context) ([]*apiv0.ServerResponse, error) {
+		return s.db.GetAllVersionsByServerName(ctx, nil, serverName)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	serverRecords = slices.DeleteFunc(serverRecords, func(server *apiv0.ServerResponse) bool {
+		return !visible(ctx, server)
+	})
+	if len(serverRecords) == 0 {
+		return nil, database.ErrNotFound
+	}
+
 	return serverRecords, nil
 }
 
 // CreateServer creates a new server version
 func (s *registryServiceImpl) CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
 	// Wrap the entire operation in a transaction
-	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
-		return s.createServerInTransaction(ctx, tx, req)
+	server, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx database.Tx) (*apiv0.ServerResponse, error) {
+		return s.createServerInTransaction(ctx, tx, req, false)
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.invalidate(server.Server.Name)
+	s.webhooks.notify()
+	return server, nil
 }
 
-// createServerInTransaction contains the actual CreateServer logic within a transaction
-func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx pgx.Tx, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+// createServerInTransaction contains the actual CreateServer and CreateDraftServer logic within a transaction
+func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx database.Tx, req *apiv0.ServerJSON, draft bool) (*apiv0.ServerResponse, error) {
 	// Validate the request
-	if err := validators.ValidatePublishRequest(ctx, *req, s.cfg); err != nil {
+	evidence, err := validators.ValidatePublishRequest(ctx, *req, s.cfg)
+	if err != nil {
 		return nil, err
 	}
 
@@ -99,6 +149,11 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 		return nil, err
 	}
 
+	// Check the publisher owns the namespace, or claim it for them
+	if err := s.checkNamespaceOwner(ctx, tx, serverJSON.Name); err != nil {
+		return nil, err
+	}
+
 	// Check for duplicate remote URLs
 	if err := s.validateNoDuplicateRemoteURLs(ctx, tx, serverJSON); err != nil {
 		return nil, err
@@ -122,26 +177,19 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 		return nil, database.ErrInvalidVersion
 	}
 
+	// Check the publisher and the namespace are within their publish limits
+	if err := s.checkPublishLimits(ctx, tx, serverJSON.Name, versionCount == 0); err != nil {
+		return nil, err
+	}
+
 	// Get current latest version to determine if new version should be latest
 	currentLatest, err := s.db.GetCurrentLatestVersion(ctx, tx, serverJSON.Name)
 	if err != nil && !errors.Is(err, database.ErrNotFound) {
 		return nil, err
 	}
 
-	// Determine if this version should be marked as latest
-	isNewLatest := true
-	if currentLatest != nil {
-		var existingPublishedAt time.Time
-		if currentLatest.Meta.Official != nil {
-			existingPublishedAt = currentLatest.Meta.Official.PublishedAt
-		}
-		isNewLatest = CompareVersions(
-			serverJSON.Version,
-			currentLatest.Server.Version,
-			publishTime,
-			existingPublishedAt,
-		) > 0
-	}
+	// Determine if this version should be marked as latest. Drafts never are until promoted.
+	isNewLatest := !draft && outranks(serverJSON.Version, model.StatusActive, publishTime, currentLatest)
 
 	// Unmark old latest version if needed
 	if isNewLatest && currentLatest != nil {
@@ -150,51 +198,137 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 		}
 	}
 
+	// Prereleases can be latest, but only releases can be latest stable
+	currentLatestStable, err := s.currentLatestStable(ctx, tx, serverJSON.Name)
+	if err != nil {
+		return nil, err
+	}
+	isNewLatestStable := !draft && IsStableVersion(serverJSON.Version) && outranks(serverJSON.Version, model.StatusActive, publishTime, currentLatestStable)
+	if isNewLatestStable && currentLatestStable != nil {
+		if err := s.db.UnmarkAsLatestStable(ctx, tx, serverJSON.Name); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create metadata for the new server
+	status := model.StatusActive /* New versions are active by default */
+	if draft {
+		status = model.StatusDraft
+	}
 	officialMeta := &apiv0.RegistryExtensions{
-		Status:      model.StatusActive, /* New versions are active by default */
-		PublishedAt: publishTime,
-		UpdatedAt:   publishTime,
-		IsLatest:    isNewLatest,
+		Status:         status,
+		PublishedAt:    publishTime,
+		UpdatedAt:      publishTime,
+		IsLatest:       isNewLatest,
+		IsLatestStable: isNewLatestStable,
 	}
 
 	// Insert new server version
-	return s.db.CreateServer(ctx, tx, &serverJSON, officialMeta)
+	createdServer, err := s.db.CreateServer(ctx, tx, &serverJSON, officialMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.storePackageValidations(ctx, tx, serverJSON, evidence); err != nil {
+		return nil, err
+	}
+
+	if err := s.recordAudit(ctx, tx, AuditActionPublish, nil, createdServer); err != nil {
+		return nil, err
+	}
+
+	if err := s.enqueueWebhookEvent(ctx, tx, WebhookEventPublished, createdServer); err != nil {
+		return nil, err
+	}
+
+	return createdServer, nil
 }
 
-// validateNoDuplicateRemoteURLs checks that no other server is using the same remote URLs
-func (s *registryServiceImpl) validateNoDuplicateRemoteURLs(ctx context.Context, tx pgx.Tx, serverDetail apiv0.ServerJSON) error {
-	// Check each remote URL in the new server for conflicts
-	for _, remote := range serverDetail.Remotes {
-		// Use filter to find servers with this remote URL
-		filter := &database.ServerFilter{RemoteURL: &remote.URL}
+// remoteURLConflictError is returned when a remote URL of a server is already used by another server
+type remoteURLConflictError struct {
+	Index      int // position of the remote in the server's remotes
+	URL        string
+	ServerName string // the server already using the URL
+}
 
-		conflictingServers, _, err := s.db.ListServers(ctx, tx, filter, "", 1000)
-		if err != nil {
-			return fmt.Errorf("failed to check remote URL conflict: %w", err)
-		}
+func (e *remoteURLConflictError) Error() string {
+	return fmt.Sprintf("remote URL %s is already used by server %s", e.URL, e.ServerName)
+}
 
-		// Check if any conflicting server has a different name
-		for _, conflictingServer := range conflictingServers {
-			if conflictingServer.Server.Name != serverDetail.Name {
-				return fmt.Errorf("remote URL %s is already used by server %s", remote.URL, conflictingServer.Server.Name)
+// validateNoDuplicateRemoteURLs claims the remote URLs of a server for it, failing if another
+// server already owns one. Within a transaction the claim holds until it commits, so concurrent
+// publishes of different servers cannot both use the same URL.
+func (s *registryServiceImpl) validateNoDuplicateRemoteURLs(ctx context.Context, tx database.Tx, serverDetail apiv0.ServerJSON) error {
+	conflicts, err := s.db.ClaimRemoteURLs(ctx, tx, serverDetail.Name, remoteURLs(serverDetail))
+	if err != nil {
+		return fmt.Errorf("failed to check remote URL conflict: %w", err)
+	}
+	return remoteURLConflict(serverDetail, conflicts)
+}
+
+// checkRemoteURLsAvailable checks that no other server owns the remote URLs of a server, without claiming them
+func (s *registryServiceImpl) checkRemoteURLsAvailable(ctx context.Context, tx database.Tx, serverDetail apiv0.ServerJSON) error {
+	owners, err := s.db.ListRemoteURLOwners(ctx, tx, remoteURLs(serverDetail))
+	if err != nil {
+		return fmt.Errorf("failed to check remote URL conflict: %w", err)
+	}
+	return remoteURLConflict(serverDetail, owners)
+}
+
+// releaseUnusedRemoteURLs gives up the remote URLs that no version of a server lists anymore,
+// so that other servers can use them. Drafts and deleted versions hold on to their URLs.
+func (s *registryServiceImpl) releaseUnusedRemoteURLs(ctx context.Context, tx database.Tx, serverName string) error {
+	versions, err := s.db.GetAllVersionsByServerName(ctx, tx, serverName)
+	if err != nil {
+		return err
+	}
+
+	var urls []string
+	for _, version := range versions {
+		urls = append(urls, remoteURLs(version.Server)...)
+	}
+	return s.db.ReleaseRemoteURLs(ctx, tx, serverName, urls)
+}
+
+// remoteURLs returns the URLs of the remotes of a server
+func remoteURLs(serverDetail apiv0.ServerJSON) []string {
+	urls := make([]string, len(serverDetail.Remotes))
+	for i, remote := range serverDetail.Remotes {
+		urls[i] = remote.URL
+	}
+	return urls
+}
+
+// remoteURLConflict returns an error for the first remote of a server whose URL is owned by
+// another server, or nil if there is none
+func remoteURLConflict(serverDetail apiv0.ServerJSON, owners []*database.RemoteURLOwner) error {
+	for i, remote := range serverDetail.Remotes {
+		for _, owner := range owners {
+			if owner.URL == remote.URL && owner.ServerName != serverDetail.Name {
+				return &remoteURLConflictError{Index: i, URL: remote.URL, ServerName: owner.ServerName}
 			}
 		}
 	}
-
 	return nil
 }
 
 // UpdateServer updates an existing server with new details
-func (s *registryServiceImpl) UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (*apiv0.ServerResponse, error) {
+func (s *registryServiceImpl) UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string, statusDetails *database.StatusDetails) (*apiv0.ServerResponse, error) {
 	// Wrap the entire operation in a transaction
-	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*apiv0.ServerResponse, error) {
-		return s.updateServerInTransaction(ctx, tx, serverName, version, req, newStatus)
+	server, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx database.Tx) (*apiv0.ServerResponse, error) {
+		return s.updateServerInTransaction(ctx, tx, serverName, version, req, newStatus, statusDetails)
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.invalidate(serverName)
+	s.webhooks.notify()
+	return server, nil
 }
 
 // updateServerInTransaction contains the actual UpdateServer logic within a transaction
-func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx pgx.Tx, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (*apiv0.ServerResponse, error) {
+func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx database.Tx, serverName, version string, req *apiv0.ServerJSON, newStatus *string, statusDetails *database.StatusDetails) (*apiv0.ServerResponse, error) {
 	// Get current server to check if it's deleted or being deleted
 	currentServer, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
 	if err != nil {
@@ -204,12 +338,22 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 	// Skip registry validation if:
 	// 1. Server is currently deleted, OR
 	// 2. Server is being set to deleted status
+	// Drafts only become active by promotion, which decides whether they are latest
+	if newStatus != nil && (isDraft(currentServer) || *newStatus == string(model.StatusDraft)) {
+		return nil, fmt.Errorf("%w: the status of drafts can only be changed by promoting them", database.ErrInvalidInput)
+	}
+	// Status details explain a status, so they are replaced along with it
+	if newStatus == nil && statusDetails != nil {
+		return nil, fmt.Errorf("%w: a deprecation message or replacement can only be set along with a status", database.ErrInvalidInput)
+	}
+
 	currentlyDeleted := currentServer.Meta.Official != nil && currentServer.Meta.Official.Status == model.StatusDeleted
 	beingDeleted := newStatus != nil && *newStatus == string(model.StatusDeleted)
 	skipRegistryValidation := currentlyDeleted || beingDeleted
 
 	// Validate the request, potentially skipping registry validation for deleted servers
-	if err := s.validateUpdateRequest(ctx, *req, skipRegistryValidation); err != nil {
+	evidence, err := s.validateUpdateRequest(ctx, *req, skipRegistryValidation)
+	if err != nil {
 		return nil, err
 	}
 
@@ -232,36 +376,112 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 		return nil, err
 	}
 
+	// Free the remote URLs the edit removed, unless another version still lists them
+	if err := s.releaseUnusedRemoteURLs(ctx, tx, serverName); err != nil {
+		return nil, err
+	}
+
+	if err := s.storePackageValidations(ctx, tx, updatedServer, evidence); err != nil {
+		return nil, err
+	}
+
 	// Handle status change if provided
 	if newStatus != nil {
-		updatedWithStatus, err := s.db.SetServerStatus(ctx, tx, serverName, version, *newStatus)
+		if err := s.validateStatusDetails(ctx, tx, serverName, version, model.Status(*newStatus), statusDetails); err != nil {
+			return nil, err
+		}
+		updatedServerResponse, err = s.db.SetServerStatus(ctx, tx, serverName, version, *newStatus, statusDetails)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Deleting or deprecating the latest version, or reactivating another, can move latest
+	if statusChanged(currentServer, updatedServerResponse) {
+		updatedServerResponse, err = s.recomputeLatestAfterStatusChange(ctx, tx, updatedServerResponse)
 		if err != nil {
 			return nil, err
 		}
-		return updatedWithStatus, nil
+	}
+
+	if err := s.recordAudit(ctx, tx, AuditActionEdit, currentServer, updatedServerResponse); err != nil {
+		return nil, err
+	}
+
+	if err := s.enqueueWebhookEvent(ctx, tx, WebhookEventUpdated, updatedServerResponse); err != nil {
+		return nil, err
+	}
+	if statusChanged(currentServer, updatedServerResponse) {
+		if err := s.enqueueWebhookEvent(ctx, tx, WebhookEventStatusChanged, updatedServerResponse); err != nil {
+			return nil, err
+		}
 	}
 
 	return updatedServerResponse, nil
 }
 
-// validateUpdateRequest validates an update request with optional registry validation skipping
-func (s *registryServiceImpl) validateUpdateRequest(ctx context.Context, req apiv0.ServerJSON, skipRegistryValidation bool) error {
+// statusChanged reports whether a change to a server version changed its status
+func statusChanged(before, after *apiv0.ServerResponse) bool {
+	var beforeStatus, afterStatus model.Status
+	if before.Meta.Official != nil {
+		beforeStatus = before.Meta.Official.Status
+	}
+	if after.Meta.Official != nil {
+		afterStatus = after.Meta.Official.Status
+	}
+	return beforeStatus != afterStatus
+}
+
+// validateUpdateRequest validates an update request with optional registry validation skipping,
+// returning the evidence of the packages' registry validation, or nil if it was skipped
+func (s *registryServiceImpl) validateUpdateRequest(ctx context.Context, req apiv0.ServerJSON, skipRegistryValidation bool) ([]*registries.Evidence, error) {
 	// Always validate the server JSON structure
 	if err := validators.ValidateServerJSON(&req); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Skip registry validation if requested (for deleted servers)
-	if skipRegistryValidation || !s.cfg.EnableRegistryValidation {
-		return nil
+	if skipRegistryValidation {
+		return nil, nil
 	}
 
 	// Perform registry validation for all packages
-	for i, pkg := range req.Packages {
-		if err := validators.ValidatePackage(ctx, pkg, req.Name); err != nil {
-			return fmt.Errorf("registry validation failed for package %d (%s): %w", i, pkg.Identifier, err)
-		}
+	return validators.ValidatePackages(ctx, req, s.cfg.EnableRegistryValidation)
+}
+
+// storePackageValidations records the evidence of the registry validation of a server version's packages
+func (s *registryServiceImpl) storePackageValidations(ctx context.Context, tx database.Tx, serverJSON apiv0.ServerJSON, evidence []*registries.Evidence) error {
+	if len(evidence) == 0 {
+		return nil
 	}
 
+	validations := make([]*database.PackageValidation, 0, len(evidence))
+	for i, packageEvidence := range evidence {
+		pkg := serverJSON.Packages[i]
+		validations = append(validations, &database.PackageValidation{
+			ServerName:   serverJSON.Name,
+			Version:      serverJSON.Version,
+			PackageIndex: i,
+			RegistryType: pkg.RegistryType,
+			Identifier:   pkg.Identifier,
+			Check:        packageEvidence.Check,
+			Outcome:      packageEvidence.Outcome,
+			Facts:        packageEvidence.Facts,
+		})
+	}
+
+	if err := s.db.CreatePackageValidations(ctx, tx, validations); err != nil {
+		return fmt.Errorf("failed to store package validations: %w", err)
+	}
 	return nil
 }
+
+// ListPackageValidations retrieves the stored registry validation evidence of a server version
+func (s *registryServiceImpl) ListPackageValidations(ctx context.Context, serverName, version string) ([]*database.PackageValidation, error) {
+	// Distinguish an unknown server version from one without packages
+	if _, err := s.db.GetServerByNameAndVersion(ctx, nil, serverName, version); err != nil {
+		return nil, err
+	}
+
+	return s.db.ListPackageValidations(ctx, nil, serverName, version)
+}
@@ -3,13 +3,20 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/modelcontextprotocol/registry/internal/config"
 	"github.com/modelcontextprotocol/registry/internal/database"
+	"github.com/modelcontextprotocol/registry/internal/validators"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 	"github.com/stretchr/testify/assert"
@@ -96,6 +103,20 @@ func TestValidateNoDuplicateRemoteURLs(t *testing.T) {
 			expectError: true,
 			errorMsg:    "remote URL https://api.example.com/mcp is already used by server com.example/existing-server",
 		},
+		{
+			name: "duplicate remote URL differing in host case and trailing slash - should fail",
+			serverDetail: apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        "com.example/new-server-normalized",
+				Description: "A new server with a duplicate URL written differently",
+				Version:     "1.0.0",
+				Remotes: []model.Transport{
+					{Type: "streamable-http", URL: "https://API.example.com/mcp/"},
+				},
+			},
+			expectError: true,
+			errorMsg:    "remote URL https://API.example.com/mcp/ is already used by server com.example/existing-server",
+		},
 		{
 			name: "updating same server with same URLs - should pass",
 			serverDetail: apiv0.ServerJSON{
@@ -127,6 +148,51 @@ func TestValidateNoDuplicateRemoteURLs(t *testing.T) {
 	}
 }
 
+func TestRemoteURLsReleasedByEdits(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+
+	server := func(name, version string, urls ...string) *apiv0.ServerJSON {
+		server := &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "A server with remotes",
+			Version:     version,
+		}
+		for _, url := range urls {
+			server.Remotes = append(server.Remotes, model.Transport{Type: "streamable-http", URL: url})
+		}
+		return server
+	}
+
+	_, err := service.CreateServer(ctx, server("com.example/first", "1.0.0", "https://one.example.com/mcp", "https://two.example.com/mcp"))
+	require.NoError(t, err)
+	_, err = service.CreateServer(ctx, server("com.example/first", "1.1.0", "https://two.example.com/mcp"))
+	require.NoError(t, err)
+
+	// A dry run reports the conflict without claiming anything
+	dryRun, err := service.DryRunPublish(ctx, server("com.example/second", "1.0.0", "https://three.example.com/mcp", "https://one.example.com/mcp"), false)
+	require.NoError(t, err)
+	require.Len(t, dryRun.Problems, 1)
+	assert.Equal(t, "/remotes/1/url", dryRun.Problems[0].Pointer)
+
+	// Dropping a URL from one version keeps it owned while another version lists it
+	_, err = service.UpdateServer(ctx, "com.example/first", "1.0.0", server("com.example/first", "1.0.0", "https://one.example.com/mcp"), nil, nil)
+	require.NoError(t, err)
+	_, err = service.CreateServer(ctx, server("com.example/second", "1.0.0", "https://two.example.com/mcp"))
+	assert.ErrorContains(t, err, "is already used by server com.example/first")
+
+	// Once no version lists it, another server can use it
+	_, err = service.UpdateServer(ctx, "com.example/first", "1.0.0", server("com.example/first", "1.0.0"), nil, nil)
+	require.NoError(t, err)
+	_, err = service.CreateServer(ctx, server("com.example/second", "1.0.0", "https://one.example.com/mcp", "https://three.example.com/mcp"))
+	require.NoError(t, err)
+
+	_, err = service.CreateServer(ctx, server("com.example/first", "1.2.0", "https://one.example.com/mcp"))
+	assert.ErrorContains(t, err, "is already used by server com.example/second")
+}
+
 func TestGetServerByName(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
@@ -528,7 +594,7 @@ func TestUpdateServer(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := service.UpdateServer(ctx, tt.serverName, tt.version, tt.updatedServer, tt.newStatus)
+			result, err := service.UpdateServer(ctx, tt.serverName, tt.version, tt.updatedServer, tt.newStatus, nil)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -581,7 +647,7 @@ func TestUpdateServer_SkipValidationForDeletedServers(t *testing.T) {
 
 	// First, set server to deleted status
 	deletedStatus := string(model.StatusDeleted)
-	_, err = service.UpdateServer(ctx, serverName, version, invalidServer, &deletedStatus)
+	_, err = service.UpdateServer(ctx, serverName, version, invalidServer, &deletedStatus, nil)
 	require.NoError(t, err, "should be able to set server to deleted (validation should be skipped)")
 
 	// Verify server is now deleted
@@ -606,7 +672,7 @@ func TestUpdateServer_SkipValidationForDeletedServers(t *testing.T) {
 	}
 
 	// This should succeed despite invalid packages because server is deleted
-	result, err := service.UpdateServer(ctx, serverName, version, updatedInvalidServer, nil)
+	result, err := service.UpdateServer(ctx, serverName, version, updatedInvalidServer, nil, nil)
 	assert.NoError(t, err, "updating deleted server should skip registry validation")
 	assert.NotNil(t, result)
 	assert.Equal(t, "Updated description for deleted server", result.Server.Description)
@@ -636,7 +702,7 @@ func TestUpdateServer_SkipValidationForDeletedServers(t *testing.T) {
 
 	// Update server and set to deleted in same operation - should skip validation
 	newDeletedStatus := string(model.StatusDeleted)
-	result2, err := service.UpdateServer(ctx, "com.example/being-deleted-test", "1.0.0", activeServer, &newDeletedStatus)
+	result2, err := service.UpdateServer(ctx, "com.example/being-deleted-test", "1.0.0", activeServer, &newDeletedStatus, nil)
 	assert.NoError(t, err, "updating server being set to deleted should skip registry validation")
 	assert.NotNil(t, result2)
 	assert.Equal(t, model.StatusDeleted, result2.Meta.Official.Status)
@@ -705,12 +771,11 @@ func TestListServers(t *testing.T) {
 			expectedCount: 2,
 		},
 		{
-			name:   "cursor pagination",
-			filter: nil,
-			cursor: "com.example/server-alpha",
-			limit:  10,
-			// Should return servers after 'server-alpha' alphabetically
-			expectedCount: 2,
+			name:        "unsigned cursor",
+			filter:      nil,
+			cursor:      "com.example/server-alpha",
+			limit:       10,
+			expectError: true,
 		},
 	}
 
@@ -734,6 +799,60 @@ func TestListServers(t *testing.T) {
 	}
 }
 
+func TestListServersCursors(t *testing.T) {
+	ctx := context.Background()
+	service := NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false})
+
+	for _, name := range []string{"com.example/cursor-alpha", "com.example/cursor-beta", "com.example/cursor-gamma"} {
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Cursor test server",
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+
+	newest := &database.ServerSort{Field: database.SortByPublishedAt, Descending: true}
+	page, cursor, err := service.ListServers(ctx, &database.ServerFilter{Sort: newest}, "", 1)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "com.example/cursor-gamma", page[0].Server.Name)
+	require.NotEmpty(t, cursor)
+	assert.NotContains(t, cursor, "com.example", "cursors are opaque")
+
+	t.Run("continues the listing", func(t *testing.T) {
+		page, _, err := service.ListServers(ctx, &database.ServerFilter{Sort: newest}, cursor, 10)
+		require.NoError(t, err)
+		require.Len(t, page, 2)
+		assert.Equal(t, "com.example/cursor-beta", page[0].Server.Name)
+		assert.Equal(t, "com.example/cursor-alpha", page[1].Server.Name)
+	})
+
+	t.Run("rejects a cursor of another sort", func(t *testing.T) {
+		_, _, err := service.ListServers(ctx, nil, cursor, 10)
+		assert.ErrorIs(t, err, database.ErrInvalidInput)
+	})
+
+	t.Run("rejects a cursor of another filter", func(t *testing.T) {
+		version := "1.0.0"
+		_, _, err := service.ListServers(ctx, &database.ServerFilter{Sort: newest, Version: &version}, cursor, 10)
+		assert.ErrorIs(t, err, database.ErrInvalidInput)
+	})
+
+	t.Run("rejects a tampered cursor", func(t *testing.T) {
+		payload, signature, _ := strings.Cut(cursor, ".")
+		_, _, err := service.ListServers(ctx, &database.ServerFilter{Sort: newest}, payload+"x."+signature, 10)
+		assert.ErrorIs(t, err, database.ErrInvalidInput)
+	})
+
+	t.Run("rejects a cursor signed by another registry", func(t *testing.T) {
+		other := NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, CursorSigningKey: "other"})
+		_, _, err := other.ListServers(ctx, &database.ServerFilter{Sort: newest}, cursor, 10)
+		assert.ErrorIs(t, err, database.ErrInvalidInput)
+	})
+}
+
 func TestVersionComparison(t *testing.T) {
 	ctx := context.Background()
 	testDB := database.NewTestDB(t)
@@ -790,3 +909,1119 @@ func TestVersionComparison(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestCreateServerNamespaceOwnership(t *testing.T) {
+	ctx := context.Background()
+	service := NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false})
+
+	publish := func(ctx context.Context, name, version string) error {
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Namespace test server",
+			Version:     version,
+		})
+		return err
+	}
+	octocat := NamespaceOwner{Type: "github", ID: "583231", Name: "octocat"}
+	// Someone who registered the login after octocat renamed their account
+	impostor := NamespaceOwner{Type: "github", ID: "999999", Name: "octocat"}
+
+	// The first publish claims the namespace
+	require.NoError(t, publish(WithNamespaceOwners(ctx, "github-at", []NamespaceOwner{octocat}), "io.github.octocat/first", "1.0.0"))
+	namespace, err := service.GetNamespace(ctx, "io.github.octocat")
+	require.NoError(t, err)
+	assert.Equal(t, "583231", namespace.OwnerID)
+	assert.Equal(t, "github-at", namespace.VerificationMethod)
+
+	t.Run("owner can keep publishing", func(t *testing.T) {
+		require.NoError(t, publish(WithNamespaceOwners(ctx, "github-oidc", []NamespaceOwner{octocat}), "io.github.octocat/second", "1.0.0"))
+	})
+
+	t.Run("another identity with the same login cannot", func(t *testing.T) {
+		err := publish(WithNamespaceOwners(ctx, "github-at", []NamespaceOwner{impostor}), "io.github.octocat/third", "1.0.0")
+		assert.ErrorIs(t, err, ErrNamespaceOwnedByOther)
+	})
+
+	t.Run("publishes without identities are not checked", func(t *testing.T) {
+		require.NoError(t, publish(ctx, "io.github.octocat/first", "1.0.1"))
+	})
+
+	t.Run("transfer", func(t *testing.T) {
+		namespace, err := service.TransferNamespace(ctx, "io.github.octocat", impostor)
+		require.NoError(t, err)
+		assert.Equal(t, VerificationMethodAdmin, namespace.VerificationMethod)
+
+		require.NoError(t, publish(WithNamespaceOwners(ctx, "github-at", []NamespaceOwner{impostor}), "io.github.octocat/third", "1.0.0"))
+		err = publish(WithNamespaceOwners(ctx, "github-at", []NamespaceOwner{octocat}), "io.github.octocat/first", "1.0.2")
+		assert.ErrorIs(t, err, ErrNamespaceOwnedByOther)
+	})
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, WebhookMaxAttempts: 2}).(*registryServiceImpl)
+
+	type received struct {
+		event, delivery string
+		payload         apiv0.WebhookEvent
+	}
+	var (
+		mu       sync.Mutex
+		requests []received
+		status   = http.StatusInternalServerError
+	)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, WebhookSignature("test-secret", r.Header.Get(WebhookTimestampHeader), body), r.Header.Get(WebhookSignatureHeader))
+
+		var event apiv0.WebhookEvent
+		assert.NoError(t, json.Unmarshal(body, &event))
+
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, received{r.Header.Get(WebhookEventHeader), r.Header.Get(WebhookDeliveryHeader), event})
+		w.WriteHeader(status)
+	}))
+	defer receiver.Close()
+	setStatus := func(code int) {
+		mu.Lock()
+		defer mu.Unlock()
+		status = code
+	}
+
+	_, err := service.CreateWebhook(ctx, "ftp://example.com/hook", []string{WebhookEventPublished}, "")
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+	_, err = service.CreateWebhook(ctx, receiver.URL, []string{"server.deleted"}, "")
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+
+	webhook, err := service.CreateWebhook(ctx, receiver.URL, WebhookEvents, "test-secret")
+	require.NoError(t, err)
+
+	serverName := "com.example/webhook-test-server"
+	_, err = service.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        serverName,
+		Description: "Webhook test server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	deliveries, _, err := service.ListWebhookDeliveries(ctx, webhook.ID, "", 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	delivery := deliveries[0]
+	assert.Equal(t, WebhookEventPublished, delivery.EventType)
+	assert.Equal(t, database.WebhookDeliveryPending, delivery.Status)
+
+	// A failed attempt is retried later
+	service.webhooks.deliverDue(ctx)
+	delivery, err = testDB.GetWebhookDelivery(ctx, nil, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, database.WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusInternalServerError, delivery.ResponseStatus)
+	assert.NotEmpty(t, delivery.LastError)
+	assert.True(t, delivery.NextAttemptAt.After(time.Now()))
+
+	service.webhooks.deliverDue(ctx)
+	require.Len(t, requests, 1, "retry should wait for the backoff")
+
+	// The last allowed attempt failing fails the delivery
+	delivery.NextAttemptAt = time.Now()
+	require.NoError(t, testDB.UpdateWebhookDelivery(ctx, nil, delivery))
+	service.webhooks.deliverDue(ctx)
+	delivery, err = testDB.GetWebhookDelivery(ctx, nil, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, database.WebhookDeliveryFailed, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+
+	// Redelivery sends it again with a fresh set of attempts
+	setStatus(http.StatusNoContent)
+	_, err = service.RedeliverWebhookDelivery(ctx, webhook.ID+1, delivery.ID)
+	require.ErrorIs(t, err, database.ErrNotFound)
+	_, err = service.RedeliverWebhookDelivery(ctx, webhook.ID, delivery.ID)
+	require.NoError(t, err)
+	service.webhooks.deliverDue(ctx)
+	delivery, err = testDB.GetWebhookDelivery(ctx, nil, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, database.WebhookDeliverySucceeded, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusNoContent, delivery.ResponseStatus)
+
+	require.Len(t, requests, 3)
+	for _, request := range requests {
+		assert.Equal(t, WebhookEventPublished, request.event)
+		assert.Equal(t, strconv.FormatInt(delivery.ID, 10), request.delivery)
+		assert.Equal(t, serverName, request.payload.Server.Server.Name)
+	}
+
+	// Status changes are announced along with the update
+	deprecated := string(model.StatusDeprecated)
+	_, err = service.UpdateServer(ctx, serverName, "1.0.0", &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        serverName,
+		Description: "Webhook test server",
+		Version:     "1.0.0",
+	}, &deprecated, nil)
+	require.NoError(t, err)
+	service.webhooks.deliverDue(ctx)
+
+	require.Len(t, requests, 5)
+	events := []string{requests[3].event, requests[4].event}
+	assert.ElementsMatch(t, []string{WebhookEventUpdated, WebhookEventStatusChanged}, events)
+	assert.Equal(t, model.StatusDeprecated, requests[4].payload.Server.Meta.Official.Status)
+}
+
+func TestDraftServers(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+
+	serverName := "com.example/draft-test-server"
+	serverJSON := func(version string) *apiv0.ServerJSON {
+		return &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        serverName,
+			Description: "Draft test server",
+			Version:     version,
+		}
+	}
+	publisherCtx := WithDraftAccess(ctx, func(name string) bool { return name == serverName })
+
+	_, err := service.CreateServer(ctx, serverJSON("1.0.0"))
+	require.NoError(t, err)
+	draft, err := service.CreateDraftServer(ctx, serverJSON("2.0.0"))
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusDraft, draft.Meta.Official.Status)
+	assert.False(t, draft.Meta.Official.IsLatest)
+
+	t.Run("drafts are only visible with access", func(t *testing.T) {
+		latest, err := service.GetServerByName(ctx, serverName)
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", latest.Server.Version)
+
+		_, err = service.GetServerByNameAndVersion(ctx, serverName, "2.0.0")
+		require.ErrorIs(t, err, database.ErrNotFound)
+		otherCtx := WithDraftAccess(ctx, func(string) bool { return false })
+		_, err = service.GetServerByNameAndVersion(otherCtx, serverName, "2.0.0")
+		require.ErrorIs(t, err, database.ErrNotFound)
+		found, err := service.GetServerByNameAndVersion(publisherCtx, serverName, "2.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusDraft, found.Meta.Official.Status)
+
+		versions, err := service.GetAllVersionsByServerName(ctx, serverName)
+		require.NoError(t, err)
+		assert.Len(t, versions, 1)
+		versions, err = service.GetAllVersionsByServerName(publisherCtx, serverName)
+		require.NoError(t, err)
+		assert.Len(t, versions, 2)
+
+		servers, _, err := service.ListServers(ctx, &database.ServerFilter{Name: &serverName}, "", 10)
+		require.NoError(t, err)
+		assert.Len(t, servers, 1)
+	})
+
+	t.Run("draft versions cannot be republished", func(t *testing.T) {
+		_, err := service.CreateServer(ctx, serverJSON("2.0.0"))
+		require.ErrorIs(t, err, database.ErrInvalidVersion)
+	})
+
+	t.Run("status of drafts only changes by promotion", func(t *testing.T) {
+		active := string(model.StatusActive)
+		_, err := service.UpdateServer(ctx, serverName, "2.0.0", serverJSON("2.0.0"), &active, nil)
+		require.ErrorIs(t, err, database.ErrInvalidInput)
+		draftStatus := string(model.StatusDraft)
+		_, err = service.UpdateServer(ctx, serverName, "1.0.0", serverJSON("1.0.0"), &draftStatus, nil)
+		require.ErrorIs(t, err, database.ErrInvalidInput)
+	})
+
+	t.Run("promotion makes a newer draft latest", func(t *testing.T) {
+		promoted, err := service.PromoteServer(ctx, serverName, "2.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusActive, promoted.Meta.Official.Status)
+		assert.True(t, promoted.Meta.Official.IsLatest)
+
+		latest, err := service.GetServerByName(ctx, serverName)
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0", latest.Server.Version)
+
+		_, err = service.PromoteServer(ctx, serverName, "2.0.0")
+		require.ErrorIs(t, err, ErrNotDraft)
+		_, err = service.PromoteServer(ctx, serverName, "9.9.9")
+		require.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("promotion keeps a newer latest", func(t *testing.T) {
+		_, err := service.CreateDraftServer(ctx, serverJSON("1.5.0"))
+		require.NoError(t, err)
+		promoted, err := service.PromoteServer(ctx, serverName, "1.5.0")
+		require.NoError(t, err)
+		assert.False(t, promoted.Meta.Official.IsLatest)
+
+		latest, err := service.GetServerByName(ctx, serverName)
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0", latest.Server.Version)
+	})
+}
+
+func TestServerStatusDetails(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+
+	serverJSON := func(name, version string) *apiv0.ServerJSON {
+		return &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Status details test server",
+			Version:     version,
+		}
+	}
+	for _, server := range []*apiv0.ServerJSON{
+		serverJSON("com.example/old-server", "1.0.0"),
+		serverJSON("com.example/new-server", "1.0.0"),
+		serverJSON("com.example/new-server", "2.0.0"),
+		serverJSON("com.example/gone-server", "1.0.0"),
+	} {
+		_, err := service.CreateServer(ctx, server)
+		require.NoError(t, err)
+	}
+	deleted := string(model.StatusDeleted)
+	_, err := service.UpdateServer(ctx, "com.example/new-server", "1.0.0", serverJSON("com.example/new-server", "1.0.0"), &deleted, nil)
+	require.NoError(t, err)
+	_, err = service.UpdateServer(ctx, "com.example/gone-server", "1.0.0", serverJSON("com.example/gone-server", "1.0.0"), &deleted, nil)
+	require.NoError(t, err)
+
+	t.Run("deprecation keeps the message and replacement", func(t *testing.T) {
+		server, err := service.SetServerStatus(ctx, "com.example/old-server", "1.0.0", model.StatusDeprecated, database.StatusDetails{
+			Message:    "Use com.example/new-server",
+			ReplacedBy: &apiv0.ReplacedBy{Name: "com.example/new-server", Version: "2.0.0"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusDeprecated, server.Meta.Official.Status)
+		assert.Equal(t, "Use com.example/new-server", server.Meta.Official.DeprecationMessage)
+		assert.Equal(t, &apiv0.ReplacedBy{Name: "com.example/new-server", Version: "2.0.0"}, server.Meta.Official.ReplacedBy)
+
+		// Changing only the details of a deprecated version updates them
+		server, err = service.SetServerStatus(ctx, "com.example/old-server", "1.0.0", model.StatusDeprecated, database.StatusDetails{
+			ReplacedBy: &apiv0.ReplacedBy{Name: "com.example/new-server"},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, server.Meta.Official.DeprecationMessage)
+		assert.Equal(t, &apiv0.ReplacedBy{Name: "com.example/new-server"}, server.Meta.Official.ReplacedBy)
+	})
+
+	t.Run("replacement must exist and not be deleted", func(t *testing.T) {
+		for _, replacement := range []apiv0.ReplacedBy{
+			{Name: "com.example/missing-server"},
+			{Name: "com.example/gone-server"},
+			{Name: "com.example/new-server", Version: "1.0.0"},
+			{Name: "com.example/new-server", Version: "9.9.9"},
+			{Name: "com.example/old-server", Version: "1.0.0"},
+		} {
+			_, err := service.SetServerStatus(ctx, "com.example/old-server", "1.0.0", model.StatusDeprecated, database.StatusDetails{
+				ReplacedBy: &replacement,
+			})
+			assert.ErrorIs(t, err, database.ErrInvalidInput, "%+v", replacement)
+		}
+	})
+
+	t.Run("undeprecation clears the details", func(t *testing.T) {
+		_, err := service.SetServerStatus(ctx, "com.example/old-server", "1.0.0", model.StatusActive, database.StatusDetails{
+			ReplacedBy: &apiv0.ReplacedBy{Name: "com.example/new-server"},
+		})
+		require.ErrorIs(t, err, database.ErrInvalidInput)
+
+		server, err := service.SetServerStatus(ctx, "com.example/old-server", "1.0.0", model.StatusActive, database.StatusDetails{Message: "Back again"})
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusActive, server.Meta.Official.Status)
+		assert.Empty(t, server.Meta.Official.DeprecationMessage)
+		assert.Nil(t, server.Meta.Official.ReplacedBy)
+	})
+
+	t.Run("edits set details along with a status", func(t *testing.T) {
+		details := &database.StatusDetails{Message: "Retired", ReplacedBy: &apiv0.ReplacedBy{Name: "com.example/new-server"}}
+		_, err := service.UpdateServer(ctx, "com.example/old-server", "1.0.0", serverJSON("com.example/old-server", "1.0.0"), nil, details)
+		require.ErrorIs(t, err, database.ErrInvalidInput)
+
+		server, err := service.UpdateServer(ctx, "com.example/old-server", "1.0.0", serverJSON("com.example/old-server", "1.0.0"), &deleted, details)
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusDeleted, server.Meta.Official.Status)
+		assert.Equal(t, "Retired", server.Meta.Official.DeprecationMessage)
+		assert.Equal(t, &apiv0.ReplacedBy{Name: "com.example/new-server"}, server.Meta.Official.ReplacedBy)
+	})
+}
+
+func TestLatestVersionFollowsStatus(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+
+	serverName := "com.example/latest-test-server"
+	serverJSON := func(version string) *apiv0.ServerJSON {
+		return &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        serverName,
+			Description: "Latest test server",
+			Version:     version,
+		}
+	}
+	for _, version := range []string{"1.0.0", "1.1.0", "2.0.0"} {
+		_, err := service.CreateServer(ctx, serverJSON(version))
+		require.NoError(t, err)
+	}
+	latestVersion := func() string {
+		t.Helper()
+		latest, err := service.GetServerByName(ctx, serverName)
+		require.NoError(t, err)
+		return latest.Server.Version
+	}
+	setStatus := func(version string, status model.Status) *apiv0.ServerResponse {
+		t.Helper()
+		newStatus := string(status)
+		server, err := service.UpdateServer(ctx, serverName, version, serverJSON(version), &newStatus, nil)
+		require.NoError(t, err)
+		return server
+	}
+
+	t.Run("deleting the latest version moves latest to the next active version", func(t *testing.T) {
+		deleted := setStatus("2.0.0", model.StatusDeleted)
+		assert.False(t, deleted.Meta.Official.IsLatest)
+		assert.Equal(t, "1.1.0", latestVersion())
+	})
+
+	t.Run("deprecating the latest version prefers active versions", func(t *testing.T) {
+		_, err := service.SetServerStatus(ctx, serverName, "1.1.0", model.StatusDeprecated, database.StatusDetails{})
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", latestVersion())
+
+		reactivated, err := service.SetServerStatus(ctx, serverName, "1.1.0", model.StatusActive, database.StatusDetails{})
+		require.NoError(t, err)
+		assert.True(t, reactivated.Meta.Official.IsLatest)
+		assert.Equal(t, "1.1.0", latestVersion())
+	})
+
+	t.Run("deprecating the only active version clears latest", func(t *testing.T) {
+		setStatus("1.0.0", model.StatusDeprecated)
+		assert.Equal(t, "1.1.0", latestVersion())
+
+		deprecated := setStatus("1.1.0", model.StatusDeprecated)
+		assert.False(t, deprecated.Meta.Official.IsLatest)
+		assert.False(t, deprecated.Meta.Official.IsLatestStable)
+		_, err := service.GetServerByName(ctx, serverName)
+		require.ErrorIs(t, err, database.ErrNotFound)
+
+		// A newly published active version becomes latest, even though it is lower
+		_, err = service.CreateServer(ctx, serverJSON("0.9.0"))
+		require.NoError(t, err)
+		assert.Equal(t, "0.9.0", latestVersion())
+	})
+
+	t.Run("servers without remaining versions have no latest version", func(t *testing.T) {
+		for _, version := range []string{"0.9.0", "1.0.0", "1.1.0"} {
+			setStatus(version, model.StatusDeleted)
+		}
+		_, err := service.GetServerByName(ctx, serverName)
+		require.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("recompute repairs existing data", func(t *testing.T) {
+		brokenName := "com.example/broken-latest-server"
+		for _, version := range []string{"1.0.0", "2.0.0"} {
+			_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+				Schema:      model.CurrentSchemaURL,
+				Name:        brokenName,
+				Description: "Latest test server",
+				Version:     version,
+			})
+			require.NoError(t, err)
+		}
+		// Deleted before status changes recomputed latest
+		_, err := testDB.SetServerStatus(ctx, nil, brokenName, "2.0.0", string(model.StatusDeleted), nil)
+		require.NoError(t, err)
+
+		_, _, err = service.RecomputeLatest(ctx, brokenName, "com.example")
+		require.ErrorIs(t, err, database.ErrInvalidInput)
+
+		changes, checked, err := service.RecomputeLatest(ctx, "", "com.example")
+		require.NoError(t, err)
+		assert.Equal(t, 2, checked)
+		assert.Equal(t, []LatestChange{{ServerName: brokenName, Previous: "2.0.0", Latest: "1.0.0", PreviousStable: "2.0.0", LatestStable: "1.0.0"}}, changes)
+
+		latest, err := service.GetServerByName(ctx, brokenName)
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", latest.Server.Version)
+
+		// Repaired data is left alone
+		changes, checked, err = service.RecomputeLatest(ctx, "", "")
+		require.NoError(t, err)
+		assert.Equal(t, 2, checked)
+		assert.Empty(t, changes)
+
+		_, _, err = service.RecomputeLatest(ctx, "com.example/missing-server", "")
+		require.ErrorIs(t, err, database.ErrNotFound)
+	})
+}
+
+func TestLatestStableVersionAndChannels(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+
+	serverName := "com.example/stable-test-server"
+	publish := func(version string) {
+		t.Helper()
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        serverName,
+			Description: "Stable test server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+	resolve := func(ref string) string {
+		t.Helper()
+		server, err := service.ResolveServerVersion(ctx, serverName, ref)
+		require.NoError(t, err)
+		return server.Server.Version
+	}
+
+	t.Run("prereleases are latest but not latest stable", func(t *testing.T) {
+		publish("1.0.0")
+		publish("2.0.0-beta.1")
+		assert.Equal(t, "2.0.0-beta.1", resolve("latest"))
+		assert.Equal(t, "1.0.0", resolve("latest-stable"))
+
+		publish("1.1.0")
+		assert.Equal(t, "2.0.0-beta.1", resolve("latest"))
+		assert.Equal(t, "1.1.0", resolve("latest-stable"))
+	})
+
+	t.Run("latest stable follows status", func(t *testing.T) {
+		_, err := service.SetServerStatus(ctx, serverName, "1.1.0", model.StatusDeprecated, database.StatusDetails{})
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", resolve("latest-stable"))
+
+		_, err = service.SetServerStatus(ctx, serverName, "1.1.0", model.StatusActive, database.StatusDetails{})
+		require.NoError(t, err)
+		assert.Equal(t, "1.1.0", resolve("latest-stable"))
+	})
+
+	t.Run("promoted releases become latest stable", func(t *testing.T) {
+		_, err := service.CreateDraftServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        serverName,
+			Description: "Stable test server",
+			Version:     "1.2.0",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "1.1.0", resolve("latest-stable"))
+
+		_, err = service.PromoteServer(ctx, serverName, "1.2.0")
+		require.NoError(t, err)
+		assert.Equal(t, "1.2.0", resolve("latest-stable"))
+		assert.Equal(t, "2.0.0-beta.1", resolve("latest"))
+	})
+
+	t.Run("channels resolve to their version", func(t *testing.T) {
+		channel, err := service.SetServerChannel(ctx, serverName, "next", "2.0.0-beta.1")
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0-beta.1", channel.Version)
+		assert.Equal(t, "2.0.0-beta.1", resolve("next"))
+
+		_, err = service.SetServerChannel(ctx, serverName, "next", "1.2.0")
+		require.NoError(t, err)
+		assert.Equal(t, "1.2.0", resolve("next"))
+
+		channels, err := service.ListServerChannels(ctx, serverName)
+		require.NoError(t, err)
+		require.Len(t, channels, 1)
+		assert.Equal(t, "next", channels[0].Channel)
+
+		require.NoError(t, service.DeleteServerChannel(ctx, serverName, "next"))
+		_, err = service.ResolveServerVersion(ctx, serverName, "next")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+		assert.ErrorIs(t, service.DeleteServerChannel(ctx, serverName, "next"), database.ErrNotFound)
+
+		_, err = service.ListServerChannels(ctx, "com.example/missing-server")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("invalid channels are rejected", func(t *testing.T) {
+		for _, channel := range []string{"latest", "latest-stable", "1.0.0", "Beta", "-next", ""} {
+			_, err := service.SetServerChannel(ctx, serverName, channel, "1.0.0")
+			assert.ErrorIs(t, err, database.ErrInvalidInput, channel)
+		}
+		_, err := service.SetServerChannel(ctx, serverName, "next", "9.9.9")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+
+		_, err = service.UpdateServer(ctx, serverName, "1.0.0", &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        serverName,
+			Description: "Stable test server",
+			Version:     "1.0.0",
+		}, stringPtr(string(model.StatusDeleted)), nil)
+		require.NoError(t, err)
+		_, err = service.SetServerChannel(ctx, serverName, "old", "1.0.0")
+		assert.ErrorIs(t, err, database.ErrInvalidInput)
+	})
+}
+
+func TestBulkSetServerStatus(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+
+	publish := func(name, version string) {
+		t.Helper()
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Bulk test server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+	publish("com.example/bulk-one", "1.0.0")
+	publish("com.example/bulk-one", "2.0.0")
+	publish("com.example/bulk-two", "1.0.0")
+	publish("org.example/bulk-other", "1.0.0")
+	_, err := service.CreateDraftServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "com.example/bulk-one",
+		Description: "Bulk test server",
+		Version:     "3.0.0",
+	})
+	require.NoError(t, err)
+
+	statuses := func(name string) map[string]model.Status {
+		t.Helper()
+		versions, err := testDB.GetAllVersionsByServerName(ctx, nil, name)
+		require.NoError(t, err)
+		result := make(map[string]model.Status)
+		for _, version := range versions {
+			result[version.Server.Version] = version.Meta.Official.Status
+		}
+		return result
+	}
+	auditCount := func() int {
+		t.Helper()
+		events, _, err := service.ListAuditEvents(ctx, nil, "", 100)
+		require.NoError(t, err)
+		return len(events)
+	}
+
+	t.Run("requires a server name or a namespace", func(t *testing.T) {
+		_, err := service.BulkSetServerStatus(ctx, "", "", model.StatusDeleted, "", false)
+		require.ErrorIs(t, err, database.ErrInvalidInput)
+		_, err = service.BulkSetServerStatus(ctx, "com.example/bulk-one", "com.example", model.StatusDeleted, "", false)
+		require.ErrorIs(t, err, database.ErrInvalidInput)
+		_, err = service.BulkSetServerStatus(ctx, "com.example/bulk-one", "", model.StatusDraft, "", false)
+		require.ErrorIs(t, err, database.ErrInvalidInput)
+		_, err = service.BulkSetServerStatus(ctx, "com.example/missing", "", model.StatusDeleted, "", false)
+		require.ErrorIs(t, err, database.ErrNotFound)
+	})
+
+	t.Run("dry run reports without writing", func(t *testing.T) {
+		before := auditCount()
+		changes, err := service.BulkSetServerStatus(ctx, "", "com.example", model.StatusDeleted, "", true)
+		require.NoError(t, err)
+		assert.Equal(t, []BulkStatusChange{
+			{ServerName: "com.example/bulk-one", Version: "2.0.0", PreviousStatus: model.StatusActive, Status: model.StatusDeleted},
+			{ServerName: "com.example/bulk-one", Version: "1.0.0", PreviousStatus: model.StatusActive, Status: model.StatusDeleted},
+			{ServerName: "com.example/bulk-two", Version: "1.0.0", PreviousStatus: model.StatusActive, Status: model.StatusDeleted},
+		}, changes)
+		assert.Equal(t, model.StatusActive, statuses("com.example/bulk-one")["2.0.0"])
+		assert.Equal(t, before, auditCount())
+	})
+
+	t.Run("deprecating a server changes every published version", func(t *testing.T) {
+		before := auditCount()
+		changes, err := service.BulkSetServerStatus(ctx, "com.example/bulk-one", "", model.StatusDeprecated, "Moved", false)
+		require.NoError(t, err)
+		assert.Len(t, changes, 2)
+		assert.Equal(t, map[string]model.Status{"1.0.0": model.StatusDeprecated, "2.0.0": model.StatusDeprecated, "3.0.0": model.StatusDraft}, statuses("com.example/bulk-one"))
+		assert.Equal(t, before+2, auditCount())
+
+		deprecated, err := service.GetServerByNameAndVersion(ctx, "com.example/bulk-one", "2.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, "Moved", deprecated.Meta.Official.DeprecationMessage)
+		assert.False(t, deprecated.Meta.Official.IsLatest)
+
+		// Without active versions the server has no latest version
+		_, err = service.GetServerByName(ctx, "com.example/bulk-one")
+		require.ErrorIs(t, err, database.ErrNotFound)
+
+		// Repeating the change changes nothing
+		changes, err = service.BulkSetServerStatus(ctx, "com.example/bulk-one", "", model.StatusDeprecated, "Moved", false)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("deleting a namespace changes every server in it", func(t *testing.T) {
+		before := auditCount()
+		changes, err := service.BulkSetServerStatus(ctx, "", "com.example", model.StatusDeleted, "", false)
+		require.NoError(t, err)
+		assert.Len(t, changes, 3)
+		assert.Equal(t, before+3, auditCount())
+		assert.Equal(t, model.StatusDeleted, statuses("com.example/bulk-two")["1.0.0"])
+		assert.Equal(t, model.StatusActive, statuses("org.example/bulk-other")["1.0.0"])
+
+		_, err = service.GetServerByName(ctx, "com.example/bulk-one")
+		require.ErrorIs(t, err, database.ErrNotFound)
+
+		events, _, err := service.ListAuditEvents(ctx, nil, "", 1)
+		require.NoError(t, err)
+		assert.Equal(t, AuditActionBulkStatus, events[0].Action)
+	})
+
+	t.Run("reactivating leaves deleted versions alone", func(t *testing.T) {
+		changes, err := service.BulkSetServerStatus(ctx, "com.example/bulk-two", "", model.StatusActive, "", false)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+		assert.Equal(t, model.StatusDeleted, statuses("com.example/bulk-two")["1.0.0"])
+	})
+}
+
+func TestBulkSetServerStatus_ConcurrentPublish(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false})
+
+	const servers = 5
+	publish := func(index, version int) error {
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        fmt.Sprintf("com.example/bulk-race-%d", index),
+			Description: "Bulk race server",
+			Version:     fmt.Sprintf("1.0.%d", version),
+		})
+		return err
+	}
+	for i := 0; i < servers; i++ {
+		require.NoError(t, publish(i, 0))
+	}
+
+	// Each round deprecates the namespace while every server in it publishes a new version, so
+	// the bulk change and the publishes contend for the same publish locks
+	for round := 1; round <= 3; round++ {
+		var wg sync.WaitGroup
+		errs := make([]error, servers+1)
+		wg.Add(servers + 1)
+		go func() {
+			defer wg.Done()
+			_, errs[servers] = service.BulkSetServerStatus(ctx, "", "com.example", model.StatusDeprecated, "", false)
+		}()
+		for i := 0; i < servers; i++ {
+			go func(index int) {
+				defer wg.Done()
+				errs[index] = publish(index, round)
+			}(i)
+		}
+		wg.Wait()
+
+		for i, err := range errs {
+			require.NoError(t, err, "round %d, goroutine %d failed", round, i)
+		}
+	}
+
+	for i := 0; i < servers; i++ {
+		versions, err := testDB.GetAllVersionsByServerName(ctx, nil, fmt.Sprintf("com.example/bulk-race-%d", i))
+		require.NoError(t, err)
+		assert.Len(t, versions, 4)
+	}
+}
+
+func TestReadCache(t *testing.T) {
+	ctx := context.Background()
+	testDB := database.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false, CacheTTL: time.Hour})
+
+	publish := func(version string) {
+		t.Helper()
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        "com.example/cached",
+			Description: "Cached server",
+			Version:     version,
+		})
+		require.NoError(t, err)
+	}
+	publish("1.0.0")
+
+	latest, err := service.GetServerByName(ctx, "com.example/cached")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", latest.Server.Version)
+	versions, err := service.GetAllVersionsByServerName(ctx, "com.example/cached")
+	require.NoError(t, err)
+	assert.Len(t, versions, 1)
+
+	// Writes that bypass the service are only seen once the cache expires
+	_, err = testDB.SetServerStatus(ctx, nil, "com.example/cached", "1.0.0", string(model.StatusDeprecated), nil)
+	require.NoError(t, err)
+	server, err := service.GetServerByNameAndVersion(ctx, "com.example/cached", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusDeprecated, server.Meta.Official.Status, "first read is not cached yet")
+	_, err = testDB.SetServerStatus(ctx, nil, "com.example/cached", "1.0.0", string(model.StatusActive), nil)
+	require.NoError(t, err)
+	server, err = service.GetServerByNameAndVersion(ctx, "com.example/cached", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, model.StatusDeprecated, server.Meta.Official.Status, "second read is cached")
+
+	t.Run("publishes invalidate the server and listings", func(t *testing.T) {
+		servers, _, err := service.ListServers(ctx, nil, "", 10)
+		require.NoError(t, err)
+		require.Len(t, servers, 1)
+
+		publish("2.0.0")
+
+		latest, err := service.GetServerByName(ctx, "com.example/cached")
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0", latest.Server.Version)
+		versions, err := service.GetAllVersionsByServerName(ctx, "com.example/cached")
+		require.NoError(t, err)
+		assert.Len(t, versions, 2)
+		servers, _, err = service.ListServers(ctx, nil, "", 10)
+		require.NoError(t, err)
+		assert.Len(t, servers, 2)
+		server, err := service.GetServerByNameAndVersion(ctx, "com.example/cached", "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusActive, server.Meta.Official.Status)
+	})
+
+	t.Run("status changes invalidate the server", func(t *testing.T) {
+		_, err := service.SetServerStatus(ctx, "com.example/cached", "2.0.0", model.StatusDeprecated, database.StatusDetails{})
+		require.NoError(t, err)
+
+		server, err := service.GetServerByNameAndVersion(ctx, "com.example/cached", "2.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusDeprecated, server.Meta.Official.Status)
+		latest, err := service.GetServerByName(ctx, "com.example/cached")
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", latest.Server.Version)
+	})
+
+	t.Run("callers can not change cached slices", func(t *testing.T) {
+		versions, err := service.GetAllVersionsByServerName(ctx, "com.example/cached")
+		require.NoError(t, err)
+		versions[0] = nil
+		versions, err = service.GetAllVersionsByServerName(ctx, "com.example/cached")
+		require.NoError(t, err)
+		assert.NotNil(t, versions[0])
+	})
+}
+
+func TestReadCacheFillFromPrimary(t *testing.T) {
+	cache := newReadCache(time.Hour, time.Hour)
+	assert.False(t, cache.fillFromPrimary("com.example/written"))
+	assert.False(t, cache.fillFromPrimary(""))
+
+	// After a write, a replica may not have it yet, so fills of the server and of listings go to the primary
+	cache.invalidate("com.example/written")
+	assert.True(t, cache.fillFromPrimary("com.example/written"))
+	assert.True(t, cache.fillFromPrimary(""))
+	assert.False(t, cache.fillFromPrimary("com.example/other"))
+
+	// Once a replica must have caught up, fills go back to it
+	cache = newReadCache(time.Hour, time.Nanosecond)
+	cache.invalidate("com.example/written")
+	time.Sleep(time.Millisecond)
+	assert.False(t, cache.fillFromPrimary("com.example/written"))
+	assert.False(t, cache.fillFromPrimary(""))
+
+	// Without a replica, reads never lag behind writes
+	cache = newReadCache(time.Hour, 0)
+	cache.invalidate("com.example/written")
+	assert.False(t, cache.fillFromPrimary("com.example/written"))
+	assert.False(t, cache.fillFromPrimary(""))
+}
+
+func TestPublishLimits(t *testing.T) {
+	ctx := context.Background()
+	service := NewRegistryService(database.NewTestDB(t), &config.Config{
+		EnableRegistryValidation:  false,
+		PublishesPerHour:          3,
+		NewServersPerDay:          2,
+		PublisherPublishesPerHour: 3,
+		PublisherNewServersPerDay: 2,
+	})
+
+	publish := func(ctx context.Context, name, version string) error {
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Rate limited server",
+			Version:     version,
+		})
+		return err
+	}
+	alice := WithActor(ctx, "github-at", "alice")
+	bob := WithActor(ctx, "github-at", "bob")
+
+	require.NoError(t, publish(alice, "com.example/one", "1.0.0"))
+	require.NoError(t, publish(alice, "com.example/two", "1.0.0"))
+
+	t.Run("new servers per day", func(t *testing.T) {
+		err := publish(alice, "com.example/three", "1.0.0")
+		require.ErrorIs(t, err, ErrRateLimited)
+		var rateLimitErr *RateLimitError
+		require.ErrorAs(t, err, &rateLimitErr)
+		assert.Contains(t, rateLimitErr.Limit, "new servers per day")
+		assert.InDelta(t, (24 * time.Hour).Seconds(), rateLimitErr.RetryAfter.Seconds(), 60)
+	})
+
+	t.Run("publishes per hour", func(t *testing.T) {
+		require.NoError(t, publish(alice, "com.example/one", "1.1.0"))
+		err := publish(alice, "com.example/one", "1.2.0")
+		var rateLimitErr *RateLimitError
+		require.ErrorAs(t, err, &rateLimitErr)
+		assert.Contains(t, rateLimitErr.Limit, "publishes per hour")
+		assert.InDelta(t, time.Hour.Seconds(), rateLimitErr.RetryAfter.Seconds(), 60)
+	})
+
+	t.Run("limits count per namespace and per publisher", func(t *testing.T) {
+		assert.ErrorIs(t, publish(bob, "com.example/one", "1.2.0"), ErrRateLimited)
+		require.NoError(t, publish(bob, "org.example/bob", "1.0.0"))
+		assert.ErrorIs(t, publish(alice, "org.example/alice", "1.0.0"), ErrRateLimited)
+	})
+
+	t.Run("system changes are not limited", func(t *testing.T) {
+		require.NoError(t, publish(ctx, "com.example/seeded", "1.0.0"))
+	})
+
+	t.Run("admins are not limited", func(t *testing.T) {
+		require.NoError(t, publish(WithAdmin(alice), "com.example/admin", "1.0.0"))
+		require.NoError(t, publish(WithAdmin(alice), "com.example/admin", "1.1.0"))
+	})
+
+	t.Run("admins can raise the limits of a namespace", func(t *testing.T) {
+		limits, err := service.SetPublishLimits(ctx, "com.example", 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, "com.example", limits.Namespace)
+
+		carol := WithActor(ctx, "github-at", "carol")
+		require.NoError(t, publish(carol, "com.example/one", "1.2.0"))
+		require.NoError(t, publish(carol, "com.example/three", "1.0.0"))
+
+		defaults, namespaces, err := service.ListPublishLimits(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 3, defaults.PublishesPerHour)
+		require.Len(t, namespaces, 1)
+		assert.Equal(t, 10, namespaces[0].PublishesPerHour)
+
+		require.NoError(t, service.DeletePublishLimits(ctx, "com.example"))
+		assert.ErrorIs(t, publish(carol, "com.example/one", "1.3.0"), ErrRateLimited)
+	})
+}
+
+func TestPublishLimits_PerPublisher(t *testing.T) {
+	ctx := context.Background()
+	service := NewRegistryService(database.NewTestDB(t), &config.Config{
+		EnableRegistryValidation:  false,
+		PublishesPerHour:          10,
+		PublisherPublishesPerHour: 2,
+	})
+
+	publish := func(ctx context.Context, name string) error {
+		_, err := service.CreateServer(ctx, &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Rate limited server",
+			Version:     "1.0.0",
+		})
+		return err
+	}
+	alice := WithActor(ctx, "github-at", "alice")
+
+	// The publisher's limit applies across namespaces, and raising a namespace's limits does not change it
+	_, err := service.SetPublishLimits(ctx, "org.example", 100, 100)
+	require.NoError(t, err)
+	require.NoError(t, publish(alice, "com.example/one"))
+	require.NoError(t, publish(alice, "net.example/two"))
+	err = publish(alice, "org.example/three")
+	var rateLimitErr *RateLimitError
+	require.ErrorAs(t, err, &rateLimitErr)
+	assert.Equal(t, "2 publishes per hour by alice", rateLimitErr.Limit)
+
+	require.NoError(t, publish(WithActor(ctx, "github-at", "bob"), "org.example/three"))
+}
+
+func TestPublishLimits_ConcurrentPublishesToNamespace(t *testing.T) {
+	ctx := context.Background()
+	service := NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: false, PublishesPerHour: 1})
+
+	// Publishes of two servers in one namespace by different publishers must count each other, so
+	// only one of them fits within a limit of one publish per hour
+	for round := 0; round < 5; round++ {
+		errs := make([]error, 2)
+		var wg sync.WaitGroup
+		wg.Add(len(errs))
+		for i := range errs {
+			go func() {
+				defer wg.Done()
+				_, errs[i] = service.CreateServer(WithActor(ctx, "github-at", fmt.Sprintf("publisher-%d", i)), &apiv0.ServerJSON{
+					Schema:      model.CurrentSchemaURL,
+					Name:        fmt.Sprintf("com.round%d/server-%d", round, i),
+					Description: "Concurrently limited server",
+					Version:     "1.0.0",
+				})
+			}()
+		}
+		wg.Wait()
+
+		limited := 0
+		for _, err := range errs {
+			if err != nil {
+				require.ErrorIs(t, err, ErrRateLimited)
+				limited++
+			}
+		}
+		assert.Equal(t, 1, limited, "round %d", round)
+	}
+}
+
+func TestDryRunPublish(t *testing.T) {
+	ctx := context.Background()
+	service := NewRegistryService(database.NewTestDB(t), &config.Config{EnableRegistryValidation: true})
+	octocat := NamespaceOwner{Type: "github", ID: "583231", Name: "octocat"}
+
+	_, err := service.CreateServer(WithNamespaceOwners(ctx, "github-at", []NamespaceOwner{octocat}), &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        "io.github.octocat/existing",
+		Description: "Existing server",
+		Version:     "1.0.0",
+		Remotes:     []model.Transport{{Type: "streamable-http", URL: "https://api.example.com/mcp"}},
+	})
+	require.NoError(t, err)
+
+	server := func(name, version string) *apiv0.ServerJSON {
+		return &apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Dry run server",
+			Version:     version,
+		}
+	}
+
+	t.Run("accepted versions report whether they become latest", func(t *testing.T) {
+		result, err := service.DryRunPublish(ctx, server("io.github.octocat/existing", "2.0.0"), false)
+		require.NoError(t, err)
+		assert.True(t, result.Accepted(), result.Problems)
+		assert.True(t, result.IsLatest)
+		assert.True(t, result.IsLatestStable)
+
+		result, err = service.DryRunPublish(ctx, server("io.github.octocat/existing", "2.0.0-beta.1"), false)
+		require.NoError(t, err)
+		assert.True(t, result.IsLatest)
+		assert.False(t, result.IsLatestStable)
+
+		result, err = service.DryRunPublish(ctx, server("io.github.octocat/existing", "0.9.0"), false)
+		require.NoError(t, err)
+		assert.True(t, result.Accepted())
+		assert.False(t, result.IsLatest)
+
+		result, err = service.DryRunPublish(ctx, server("io.github.octocat/existing", "3.0.0"), true)
+		require.NoError(t, err)
+		assert.False(t, result.IsLatest, "drafts are never latest")
+	})
+
+	t.Run("every problem is reported", func(t *testing.T) {
+		req := server("io.github.octocat/other", "1.0.0")
+		req.Remotes = []model.Transport{{Type: "streamable-http", URL: "https://api.example.com/mcp"}}
+		req.Packages = []model.Package{
+			{RegistryType: "unknown", Identifier: "first", Version: "1.0.0", Transport: model.Transport{Type: "stdio"}},
+			{RegistryType: "unknown", Identifier: "second", Version: "1.0.0", Transport: model.Transport{Type: "stdio"}},
+		}
+		impostor := NamespaceOwner{Type: "github", ID: "999999", Name: "octocat"}
+
+		result, err := service.DryRunPublish(WithNamespaceOwners(ctx, "github-at", []NamespaceOwner{impostor}), req, false)
+		require.NoError(t, err)
+		assert.False(t, result.Accepted())
+		codes := map[string]string{}
+		for _, problem := range result.Problems {
+			codes[problem.Pointer] = problem.Code
+		}
+		assert.Equal(t, map[string]string{
+			"/name":          CodeNamespaceOwnedByOther,
+			"/remotes/0/url": CodeRemoteURLInUse,
+			"/packages/0":    validators.CodePackageValidationFailed,
+			"/packages/1":    validators.CodePackageValidationFailed,
+		}, codes)
+		require.Len(t, result.Packages, 2)
+		for _, pkg := range result.Packages {
+			assert.Equal(t, PackageOutcomeFailed, pkg.Outcome)
+			assert.Contains(t, pkg.Error, "unsupported registry type")
+		}
+	})
+
+	t.Run("existing versions are rejected", func(t *testing.T) {
+		result, err := service.DryRunPublish(ctx, server("io.github.octocat/existing", "1.0.0"), false)
+		require.NoError(t, err)
+		require.Len(t, result.Problems, 1)
+		assert.Equal(t, CodeVersionExists, result.Problems[0].Code)
+		assert.ErrorIs(t, result.Problems[0], database.ErrInvalidVersion)
+	})
+
+	t.Run("nothing is written", func(t *testing.T) {
+		versions, err := service.GetAllVersionsByServerName(ctx, "io.github.octocat/existing")
+		require.NoError(t, err)
+		assert.Len(t, versions, 1)
+		_, err = service.GetServerByName(ctx, "io.github.octocat/other")
+		assert.ErrorIs(t, err, database.ErrNotFound)
+	})
+}
+
+func TestAuditDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   string
+	}{
+		{
+			name:   "unchanged",
+			before: `{"version": "1.0.0", "remotes": [{"url": "https://a.example.com"}]}`,
+			after:  `{"version": "1.0.0", "remotes": [{"url": "https://a.example.com"}]}`,
+			want:   `[]`,
+		},
+		{
+			name:   "members replaced, added and removed",
+			before: `{"status": "active", "statusMessage": "ok", "meta": {"count": 1}}`,
+			after:  `{"status": "deleted", "meta": {"count": 1.0, "a/b~c": false}}`,
+			want: `[
+				{"op": "add", "path": "/meta/a~1b~0c", "value": false},
+				{"op": "replace", "path": "/meta/count", "value": 1.0},
+				{"op": "replace", "path": "/status", "value": "deleted"},
+				{"op": "remove", "path": "/statusMessage"}
+			]`,
+		},
+		{
+			name:   "array elements changed, appended and truncated",
+			before: `{"a": [1, 2, 3], "b": ["x"]}`,
+			after:  `{"a": [1, 5], "b": ["x", "y", null]}`,
+			want: `[
+				{"op": "replace", "path": "/a/1", "value": 5},
+				{"op": "remove", "path": "/a/2"},
+				{"op": "add", "path": "/b/1", "value": "y"},
+				{"op": "add", "path": "/b/2", "value": null}
+			]`,
+		},
+		{
+			name:   "type change replaces the value",
+			before: `{"packages": [{"identifier": "a"}]}`,
+			after:  `{"packages": {"identifier": "a"}}`,
+			want:   `[{"op": "replace", "path": "/packages", "value": {"identifier": "a"}}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := auditDiff([]byte(tt.before), []byte(tt.after))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(diff))
+		})
+	}
+}
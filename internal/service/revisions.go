@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// ListServerRevisions returns all revisions of a server version's server.json, oldest first
+func (s *registryServiceImpl) ListServerRevisions(ctx context.Context, serverName, version string) ([]*database.ServerRevision, error) {
+	return s.db.ListServerRevisions(ctx, nil, serverName, version)
+}
+
+// GetServerRevision returns a single revision of a server version's server.json
+func (s *registryServiceImpl) GetServerRevision(ctx context.Context, serverName, version string, revision int) (*database.ServerRevision, error) {
+	return s.db.GetServerRevision(ctx, nil, serverName, version, revision)
+}
+
+// RestoreServerRevision makes an earlier revision the current server.json of a server version.
+// The restored content is appended as a new revision, so the history itself is never rewritten.
+func (s *registryServiceImpl) RestoreServerRevision(ctx context.Context, serverName, version string, revision int) (*apiv0.ServerResponse, error) {
+	server, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx database.Tx) (*apiv0.ServerResponse, error) {
+		// Acquire advisory lock to prevent concurrent edits of servers with same name
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+
+		currentServer, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
+		if err != nil {
+			return nil, err
+		}
+
+		restored, err := s.db.GetServerRevision(ctx, tx, serverName, version, revision)
+		if err != nil {
+			return nil, err
+		}
+
+		// The restored remotes may have been claimed by another server since
+		if err := s.validateNoDuplicateRemoteURLs(ctx, tx, restored.Server); err != nil {
+			return nil, err
+		}
+
+		updatedServer, err := s.db.UpdateServer(ctx, tx, serverName, version, &restored.Server)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.releaseUnusedRemoteURLs(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+
+		if err := s.recordAudit(ctx, tx, AuditActionRestore, currentServer, updatedServer); err != nil {
+			return nil, err
+		}
+
+		if err := s.enqueueWebhookEvent(ctx, tx, WebhookEventUpdated, updatedServer); err != nil {
+			return nil, err
+		}
+
+		return updatedServer, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.invalidate(serverName)
+	s.webhooks.notify()
+	return server, nil
+}
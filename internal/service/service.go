@@ -5,6 +5,7 @@ import (
 
 	"github.com/modelcontextprotocol/registry/internal/database"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
 // RegistryService defines the interface for registry operations
@@ -15,10 +16,74 @@ type RegistryService interface {
 	GetServerByName(ctx context.Context, serverName string) (*apiv0.ServerResponse, error)
 	// GetServerByNameAndVersion retrieve specific version of a server by server name and version
 	GetServerByNameAndVersion(ctx context.Context, serverName string, version string) (*apiv0.ServerResponse, error)
+	// ResolveServerVersion retrieve the version of a server that "latest", "latest-stable", an exact version or a channel name refers to
+	ResolveServerVersion(ctx context.Context, serverName, ref string) (*apiv0.ServerResponse, error)
 	// GetAllVersionsByServerName retrieve all versions of a server by server name
 	GetAllVersionsByServerName(ctx context.Context, serverName string) ([]*apiv0.ServerResponse, error)
 	// CreateServer creates a new server version
 	CreateServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error)
-	// UpdateServer updates an existing server and optionally its status
-	UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (*apiv0.ServerResponse, error)
+	// CreateDraftServer creates a new server version as a draft, only visible to readers allowed by WithDraftAccess
+	CreateDraftServer(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error)
+	// DryRunPublish reports whether a server version would be accepted by CreateServer or CreateDraftServer, without writing anything
+	DryRunPublish(ctx context.Context, req *apiv0.ServerJSON, draft bool) (*PublishDryRun, error)
+	// PromoteServer makes a draft version active, recomputing the latest version of its server
+	PromoteServer(ctx context.Context, serverName, version string) (*apiv0.ServerResponse, error)
+	// SetServerStatus moves a server version between active and deprecated on behalf of its publisher
+	SetServerStatus(ctx context.Context, serverName, version string, status model.Status, details database.StatusDetails) (*apiv0.ServerResponse, error)
+	// UpdateServer updates an existing server and optionally its status, with the details that explain it
+	UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string, statusDetails *database.StatusDetails) (*apiv0.ServerResponse, error)
+	// BulkSetServerStatus sets the status of every version of a server or of every server in a namespace in one transaction
+	BulkSetServerStatus(ctx context.Context, serverName, namespace string, status model.Status, message string, dryRun bool) ([]BulkStatusChange, error)
+	// RecomputeLatest repairs which version is latest for a server, the servers of a namespace, or every server
+	RecomputeLatest(ctx context.Context, serverName, namespace string) ([]LatestChange, int, error)
+	// ListServerChannels retrieve the channels of a server ordered by name
+	ListServerChannels(ctx context.Context, serverName string) ([]*database.ServerChannel, error)
+	// SetServerChannel points a channel of a server at a version on behalf of its publisher
+	SetServerChannel(ctx context.Context, serverName, channel, version string) (*database.ServerChannel, error)
+	// DeleteServerChannel deletes a channel of a server on behalf of its publisher
+	DeleteServerChannel(ctx context.Context, serverName, channel string) error
+	// ListServerRevisions retrieve all revisions of a server version, oldest first
+	ListServerRevisions(ctx context.Context, serverName, version string) ([]*database.ServerRevision, error)
+	// GetServerRevision retrieve a single revision of a server version
+	GetServerRevision(ctx context.Context, serverName, version string, revision int) (*database.ServerRevision, error)
+	// RestoreServerRevision makes an earlier revision the current content of a server version
+	RestoreServerRevision(ctx context.Context, serverName, version string, revision int) (*apiv0.ServerResponse, error)
+	// ListServerChanges retrieve change feed entries after a sequence number, oldest first
+	ListServerChanges(ctx context.Context, since int64, limit int) ([]*database.ServerChange, error)
+	// GetNamespace retrieves the owner record of a namespace
+	GetNamespace(ctx context.Context, namespace string) (*database.Namespace, error)
+	// ListNamespaces retrieves namespace owner records with cursor-based pagination and optional filtering
+	ListNamespaces(ctx context.Context, filter *database.NamespaceFilter, cursor string, limit int) ([]*database.Namespace, string, error)
+	// TransferNamespace makes owner the owner of a namespace
+	TransferNamespace(ctx context.Context, namespace string, owner NamespaceOwner) (*database.Namespace, error)
+	// ListPackageValidations retrieves the stored registry validation evidence of a server version
+	ListPackageValidations(ctx context.Context, serverName, version string) ([]*database.PackageValidation, error)
+	// ExportServers runs fn with a consistent snapshot of every server version
+	ExportServers(ctx context.Context, fn func(export *database.ServerExport) error) error
+	// ListPublishLimits retrieves the default publish limits and the limits set for namespaces
+	ListPublishLimits(ctx context.Context) (*database.PublishLimits, []*database.PublishLimits, error)
+	// SetPublishLimits sets the publish limits of a namespace
+	SetPublishLimits(ctx context.Context, namespace string, publishesPerHour, newServersPerDay int) (*database.PublishLimits, error)
+	// DeletePublishLimits makes a namespace subject to the default publish limits again
+	DeletePublishLimits(ctx context.Context, namespace string) error
+	// CreateWebhook subscribes a URL to events, generating a signing secret if none is given
+	CreateWebhook(ctx context.Context, webhookURL string, events []string, secret string) (*database.Webhook, error)
+	// GetWebhook retrieves a webhook by ID
+	GetWebhook(ctx context.Context, id int64) (*database.Webhook, error)
+	// ListWebhooks retrieves all webhooks
+	ListWebhooks(ctx context.Context) ([]*database.Webhook, error)
+	// UpdateWebhook changes the URL, events and active flag of a webhook
+	UpdateWebhook(ctx context.Context, id int64, webhookURL string, events []string, active bool) (*database.Webhook, error)
+	// DeleteWebhook deletes a webhook and its delivery log
+	DeleteWebhook(ctx context.Context, id int64) error
+	// ListWebhookDeliveries retrieves the delivery log of a webhook newest first
+	ListWebhookDeliveries(ctx context.Context, webhookID int64, cursor string, limit int) ([]*database.WebhookDelivery, string, error)
+	// RedeliverWebhookDelivery schedules a delivery to be sent again
+	RedeliverWebhookDelivery(ctx context.Context, webhookID, deliveryID int64) (*database.WebhookDelivery, error)
+	// RunWebhookDispatcher sends webhook deliveries until ctx is done
+	RunWebhookDispatcher(ctx context.Context)
+	// RunPublishEventPruner deletes recorded publishes that no longer count against any publish limit, until ctx is done
+	RunPublishEventPruner(ctx context.Context)
+	// ListAuditEvents retrieve audit log entries newest first with optional filtering
+	ListAuditEvents(ctx context.Context, filter *database.AuditEventFilter, cursor string, limit int) ([]*database.AuditEvent, string, error)
 }
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// SetServerStatus moves a published server version between active and deprecated on behalf of
+// its publisher. The message of details is recorded as the reason in the audit log, and kept as
+// the deprecation message when deprecating. Deleting versions, and changing the status of deleted
+// versions or drafts, is not possible this way.
+func (s *registryServiceImpl) SetServerStatus(ctx context.Context, serverName, version string, status model.Status, details database.StatusDetails) (*apiv0.ServerResponse, error) {
+	if status != model.StatusActive && status != model.StatusDeprecated {
+		return nil, fmt.Errorf("%w: status must be %s or %s", database.ErrInvalidInput, model.StatusActive, model.StatusDeprecated)
+	}
+	if details.Message != "" {
+		ctx = WithAuditReason(ctx, details.Message)
+	}
+	// Active versions need no explanation, so the message of an undeprecation is only audited
+	newDetails := &details
+	if status == model.StatusActive {
+		if details.ReplacedBy != nil {
+			return nil, fmt.Errorf("%w: only deprecated versions can be replaced", database.ErrInvalidInput)
+		}
+		newDetails = nil
+	}
+
+	server, err := database.InTransactionT(ctx, s.db, func(ctx context.Context, tx database.Tx) (*apiv0.ServerResponse, error) {
+		// Serialize with publishes and edits of the server
+		if err := s.db.AcquirePublishLock(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+
+		// Status changes by publishers are subject to the same ownership check as publishes
+		if err := s.checkNamespaceOwner(ctx, tx, serverName); err != nil {
+			return nil, err
+		}
+
+		currentServer, err := s.db.GetServerByNameAndVersion(ctx, tx, serverName, version)
+		if err != nil {
+			return nil, err
+		}
+		currentStatus := currentServer.Meta.Official.Status
+		switch {
+		case currentStatus == model.StatusDraft:
+			return nil, fmt.Errorf("%w: drafts become active by promotion", database.ErrInvalidInput)
+		case currentStatus == model.StatusDeleted:
+			return nil, fmt.Errorf("%w: deleted servers cannot be undeleted", database.ErrInvalidInput)
+		case currentStatus == status && hasStatusDetails(currentServer, newDetails):
+			return currentServer, nil
+		}
+
+		if err := s.validateStatusDetails(ctx, tx, serverName, version, status, newDetails); err != nil {
+			return nil, err
+		}
+
+		updatedServer, err := s.db.SetServerStatus(ctx, tx, serverName, version, string(status), newDetails)
+		if err != nil {
+			return nil, err
+		}
+		if currentStatus != status {
+			if updatedServer, err = s.recomputeLatestAfterStatusChange(ctx, tx, updatedServer); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := s.recordAudit(ctx, tx, AuditActionStatus, currentServer, updatedServer); err != nil {
+			return nil, err
+		}
+
+		if err := s.enqueueWebhookEvent(ctx, tx, WebhookEventStatusChanged, updatedServer); err != nil {
+			return nil, err
+		}
+
+		return updatedServer, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache.invalidate(serverName)
+	s.webhooks.notify()
+	return server, nil
+}
+
+// validateStatusDetails checks the details a server version is about to get along with status:
+// only versions that are not active are explained, and a replacement must be a published server
+// that is not deleted, or a version of it that is neither deleted nor a draft
+func (s *registryServiceImpl) validateStatusDetails(ctx context.Context, tx database.Tx, serverName, version string, status model.Status, details *database.StatusDetails) error {
+	if details == nil || (details.Message == "" && details.ReplacedBy == nil) {
+		return nil
+	}
+	if status == model.StatusActive {
+		return fmt.Errorf("%w: active versions cannot have a deprecation message or replacement", database.ErrInvalidInput)
+	}
+
+	replacement := details.ReplacedBy
+	if replacement == nil {
+		return nil
+	}
+	if replacement.Name == "" {
+		return fmt.Errorf("%w: the replacement must have a server name", database.ErrInvalidInput)
+	}
+	if replacement.Name == serverName && replacement.Version == version {
+		return fmt.Errorf("%w: a version cannot be replaced by itself", database.ErrInvalidInput)
+	}
+
+	var candidates []*apiv0.ServerResponse
+	if replacement.Version != "" {
+		candidate, err := s.db.GetServerByNameAndVersion(ctx, tx, replacement.Name, replacement.Version)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return err
+		}
+		if candidate != nil {
+			candidates = append(candidates, candidate)
+		}
+	} else {
+		var err error
+		candidates, err = s.db.GetAllVersionsByServerName(ctx, tx, replacement.Name)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return err
+		}
+	}
+	for _, candidate := range candidates {
+		if candidateStatus := candidate.Meta.Official.Status; candidateStatus != model.StatusDeleted && candidateStatus != model.StatusDraft {
+			return nil
+		}
+	}
+
+	if replacement.Version != "" {
+		return fmt.Errorf("%w: replacement %s version %s does not exist or is deleted", database.ErrInvalidInput, replacement.Name, replacement.Version)
+	}
+	return fmt.Errorf("%w: replacement %s does not exist or is deleted", database.ErrInvalidInput, replacement.Name)
+}
+
+// hasStatusDetails reports whether a server version already has exactly the given details
+func hasStatusDetails(server *apiv0.ServerResponse, details *database.StatusDetails) bool {
+	var want database.StatusDetails
+	if details != nil {
+		want = *details
+	}
+	official := server.Meta.Official
+	if official.DeprecationMessage != want.Message {
+		return false
+	}
+	if official.ReplacedBy == nil || want.ReplacedBy == nil {
+		return official.ReplacedBy == nil && want.ReplacedBy == nil
+	}
+	return *official.ReplacedBy == *want.ReplacedBy
+}
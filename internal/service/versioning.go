@@ -84,3 +84,9 @@ func CompareVersions(version1 string, version2 string, timestamp1 time.Time, tim
 	}
 	return -1
 }
+
+// IsStableVersion reports whether a version is a release rather than a prerelease. Versions that
+// are not semantic versions have no notion of prerelease, so they are all stable.
+func IsStableVersion(version string) bool {
+	return !IsSemanticVersion(version) || semver.Prerelease(ensureVPrefix(version)) == ""
+}
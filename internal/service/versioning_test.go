@@ -1,9 +1,11 @@
 package service_test
 
 import (
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/modelcontextprotocol/registry/internal/database"
 	"github.com/modelcontextprotocol/registry/internal/service"
 )
 
@@ -162,3 +164,92 @@ func TestCompareVersions(t *testing.T) {
 		})
 	}
 }
+
+// TestVersionSortKeyMatchesCompareVersions checks that ordering by the stored version sort key
+// agrees with CompareVersions for every pair of versions
+func TestVersionSortKeyMatchesCompareVersions(t *testing.T) {
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	versions := []struct {
+		version     string
+		publishedAt time.Time
+	}{
+		{"1.9.0", base},
+		{"1.10.0", base},
+		{"1.10.0-rc.1", base},
+		{"1.10.0-rc.2", base},
+		{"1.10.0-rc.10", base},
+		{"1.10.0-rc", base},
+		{"1.10.0-alpha", base},
+		{"1.10.0-alpha.1", base},
+		{"1.10.0-alpha.beta", base},
+		{"1.10.0-alpha-x", base},
+		{"1.10.0-1", base},
+		{"1.10.0-11", base},
+		{"1.10.0-1a", base},
+		{"1.10.0+build.5", base},
+		{"v2.0.0", base},
+		{"10.0.0", base},
+		{"2021.11.15", base},
+		{"0.0.1", base},
+		{"123456789012345678901234567890.0.0", base},
+		{"snapshot", base.Add(-time.Hour)},
+		{"latest", base},
+		{"2021.03.05", base.Add(time.Hour)},
+		{"", base.Add(time.Minute)},
+	}
+
+	sign := func(n int) int {
+		switch {
+		case n < 0:
+			return -1
+		case n > 0:
+			return 1
+		}
+		return 0
+	}
+
+	for _, a := range versions {
+		for _, b := range versions {
+			want := service.CompareVersions(a.version, b.version, a.publishedAt, b.publishedAt)
+			got := strings.Compare(
+				database.VersionSortKey(a.version, a.publishedAt),
+				database.VersionSortKey(b.version, b.publishedAt),
+			)
+			if sign(got) != want {
+				t.Errorf("sort keys order %q vs %q as %d, CompareVersions says %d", a.version, b.version, sign(got), want)
+			}
+		}
+	}
+}
+
+// TestIsStableVersion also checks that stable versions are those whose version sort key starts
+// with "0" or ends with "~", which is how the database tells them apart
+func TestIsStableVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"1.0.0", true},
+		{"v2.1.3", true},
+		{"1.0.0+build.5", true},
+		{"2021.11.15", true},
+		{"snapshot", true},
+		{"2021.03.05", true},
+		{"1.0.0-beta.1", false},
+		{"2.0.0-rc", false},
+		{"1.0.0-1", false},
+		{"1.0.0-alpha+build", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := service.IsStableVersion(tt.version); got != tt.want {
+				t.Errorf("IsStableVersion(%q) = %v, want %v", tt.version, got, tt.want)
+			}
+			key := database.VersionSortKey(tt.version, time.Now())
+			if stableKey := strings.HasPrefix(key, "0") || strings.HasSuffix(key, "~"); stableKey != tt.want {
+				t.Errorf("sort key %q of %q says stable is %v, want %v", key, tt.version, stableKey, tt.want)
+			}
+		})
+	}
+}
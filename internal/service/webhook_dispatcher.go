@@ -0,0 +1,205 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/database"
+)
+
+// Headers of webhook deliveries
+const (
+	WebhookEventHeader     = "X-Registry-Event"     // event type
+	WebhookDeliveryHeader  = "X-Registry-Delivery"  // delivery ID, the same across retries and redeliveries
+	WebhookTimestampHeader = "X-Registry-Timestamp" // Unix time of the attempt, covered by the signature
+	WebhookSignatureHeader = "X-Registry-Signature" // "sha256=" and the hex HMAC-SHA256 of "<timestamp>.<body>"
+)
+
+const (
+	// webhookBatchSize is how many deliveries are claimed, and sent concurrently, at a time
+	webhookBatchSize = 20
+	// webhookLease is how long a claimed delivery is hidden from other dispatchers; it must
+	// exceed the time an attempt can take
+	webhookLease = time.Minute
+	// webhookTimeout bounds a single delivery attempt
+	webhookTimeout = 10 * time.Second
+	// webhookRetryBase and webhookRetryMax bound the exponential backoff between attempts
+	webhookRetryBase = 30 * time.Second
+	webhookRetryMax  = time.Hour
+)
+
+// WebhookSignature returns the value of the signature header of a delivery with the given
+// timestamp header and body, which receivers should compare against in constant time
+func WebhookSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookRetryDelay returns how long to wait after the given failed attempt, starting at 1
+func webhookRetryDelay(attempt int) time.Duration {
+	delay := webhookRetryBase
+	for i := 1; i < attempt && delay < webhookRetryMax; i++ {
+		delay *= 2
+	}
+	return min(delay, webhookRetryMax)
+}
+
+// webhookDispatcher sends pending webhook deliveries. Deliveries are stored with the change they
+// announce, so they survive restarts, and any number of instances can dispatch them concurrently.
+type webhookDispatcher struct {
+	db           database.Database
+	client       *http.Client
+	wake         chan struct{}
+	pollInterval time.Duration
+	maxAttempts  int
+}
+
+func newWebhookDispatcher(db database.Database, cfg *config.Config) *webhookDispatcher {
+	d := &webhookDispatcher{
+		db:           db,
+		client:       &http.Client{Timeout: webhookTimeout},
+		wake:         make(chan struct{}, 1),
+		pollInterval: cfg.WebhookPollInterval,
+		maxAttempts:  cfg.WebhookMaxAttempts,
+	}
+	if d.pollInterval <= 0 {
+		d.pollInterval = 5 * time.Second
+	}
+	if d.maxAttempts <= 0 {
+		d.maxAttempts = 8
+	}
+	return d
+}
+
+// notify wakes the dispatcher to send deliveries that were just committed
+func (d *webhookDispatcher) notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run sends deliveries as they become due until ctx is done
+func (d *webhookDispatcher) run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.deliverDue(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// deliverDue sends every delivery that is due
+func (d *webhookDispatcher) deliverDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		deliveries, err := d.db.ClaimWebhookDeliveries(ctx, webhookBatchSize, webhookLease)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Failed to claim webhook deliveries: %v", err)
+			}
+			return
+		}
+		if len(deliveries) == 0 {
+			return
+		}
+
+		var wg sync.WaitGroup
+		for _, delivery := range deliveries {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				d.deliver(ctx, delivery)
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+// deliver makes an attempt to send a delivery and records its outcome
+func (d *webhookDispatcher) deliver(ctx context.Context, delivery *database.WebhookDelivery) {
+	webhook, err := d.db.GetWebhook(ctx, nil, delivery.WebhookID)
+	if err != nil {
+		// Deleting a webhook deletes its deliveries, so it can only be missing if that just happened
+		if !errors.Is(err, database.ErrNotFound) {
+			log.Printf("Failed to get webhook %d: %v", delivery.WebhookID, err)
+		}
+		return
+	}
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	if webhook.Active {
+		delivery.ResponseStatus, err = d.send(ctx, webhook, delivery)
+	} else {
+		delivery.ResponseStatus, err = 0, errors.New("webhook is inactive")
+	}
+
+	switch {
+	case err == nil:
+		delivery.Status = database.WebhookDeliverySucceeded
+		delivery.LastError = ""
+	case ctx.Err() != nil:
+		// Shutting down: the attempt does not count, and the lease expiring makes it due again
+		return
+	case !webhook.Active || delivery.Attempts >= d.maxAttempts:
+		delivery.Status = database.WebhookDeliveryFailed
+		delivery.LastError = err.Error()
+	default:
+		delivery.NextAttemptAt = now.Add(webhookRetryDelay(delivery.Attempts))
+		delivery.LastError = err.Error()
+	}
+
+	if err := d.db.UpdateWebhookDelivery(ctx, nil, delivery); err != nil && !errors.Is(err, database.ErrNotFound) {
+		log.Printf("Failed to record webhook delivery %d: %v", delivery.ID, err)
+	}
+}
+
+// send posts a delivery to its webhook, returning the response status
+func (d *webhookDispatcher) send(ctx context.Context, webhook *database.Webhook, delivery *database.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "mcp-registry-webhooks")
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, WebhookSignature(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/modelcontextprotocol/registry/internal/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Webhook event types
+const (
+	WebhookEventPublished     = "server.published"      // a version was published
+	WebhookEventUpdated       = "server.updated"        // a version's server.json was edited or restored
+	WebhookEventStatusChanged = "server.status_changed" // a version's status changed
+)
+
+// WebhookEvents lists every webhook event type
+var WebhookEvents = []string{WebhookEventPublished, WebhookEventUpdated, WebhookEventStatusChanged}
+
+// enqueueWebhookEvent schedules the delivery of an event to subscribed webhooks within the
+// transaction of the change, so that it is only sent if the change commits
+func (s *registryServiceImpl) enqueueWebhookEvent(ctx context.Context, tx database.Tx, eventType string, server *apiv0.ServerResponse) error {
+	// Drafts are announced when they are promoted
+	if isDraft(server) {
+		return nil
+	}
+
+	payload, err := json.Marshal(apiv0.WebhookEvent{
+		Event:      eventType,
+		OccurredAt: time.Now(),
+		Server:     *server,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook event: %w", err)
+	}
+
+	if err := s.db.EnqueueWebhookDeliveries(ctx, tx, eventType, server.Server.Name, server.Server.Version, payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RunWebhookDispatcher sends webhook deliveries until ctx is done
+func (s *registryServiceImpl) RunWebhookDispatcher(ctx context.Context) {
+	s.webhooks.run(ctx)
+}
+
+// validateWebhook checks the URL and events of a webhook
+func validateWebhook(webhookURL string, events []string) error {
+	parsed, err := url.Parse(webhookURL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return fmt.Errorf("%w: webhook URL must be an absolute http or https URL", database.ErrInvalidInput)
+	}
+	if len(events) == 0 {
+		return fmt.Errorf("%w: webhook must subscribe to at least one event", database.ErrInvalidInput)
+	}
+	for _, event := range events {
+		if !slices.Contains(WebhookEvents, event) {
+			return fmt.Errorf("%w: unknown webhook event %q", database.ErrInvalidInput, event)
+		}
+	}
+	return nil
+}
+
+// CreateWebhook subscribes a URL to events. Deliveries are signed with secret, or with a
+// generated secret if it is empty.
+func (s *registryServiceImpl) CreateWebhook(ctx context.Context, webhookURL string, events []string, secret string) (*database.Webhook, error) {
+	if err := validateWebhook(webhookURL, events); err != nil {
+		return nil, err
+	}
+
+	if secret == "" {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+		}
+		secret = hex.EncodeToString(key)
+	}
+
+	webhook := &database.Webhook{
+		URL:    webhookURL,
+		Secret: secret,
+		Events: slices.Compact(slices.Sorted(slices.Values(events))),
+		Active: true,
+	}
+	if err := s.db.CreateWebhook(ctx, nil, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// GetWebhook retrieves a webhook by ID
+func (s *registryServiceImpl) GetWebhook(ctx context.Context, id int64) (*database.Webhook, error) {
+	return s.db.GetWebhook(ctx, nil, id)
+}
+
+// ListWebhooks retrieves all webhooks
+func (s *registryServiceImpl) ListWebhooks(ctx context.Context) ([]*database.Webhook, error) {
+	return s.db.ListWebhooks(ctx, nil)
+}
+
+// UpdateWebhook changes the URL, events and active flag of a webhook. Deliveries that are
+// already pending are sent to the new URL.
+func (s *registryServiceImpl) UpdateWebhook(ctx context.Context, id int64, webhookURL string, events []string, active bool) (*database.Webhook, error) {
+	if err := validateWebhook(webhookURL, events); err != nil {
+		return nil, err
+	}
+
+	webhook := &database.Webhook{
+		ID:     id,
+		URL:    webhookURL,
+		Events: slices.Compact(slices.Sorted(slices.Values(events))),
+		Active: active,
+	}
+	if err := s.db.UpdateWebhook(ctx, nil, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// DeleteWebhook deletes a webhook and its delivery log
+func (s *registryServiceImpl) DeleteWebhook(ctx context.Context, id int64) error {
+	return s.db.DeleteWebhook(ctx, nil, id)
+}
+
+// ListWebhookDeliveries returns the delivery log of a webhook newest first with cursor-based pagination
+func (s *registryServiceImpl) ListWebhookDeliveries(ctx context.Context, webhookID int64, cursor string, limit int) ([]*database.WebhookDelivery, string, error) {
+	if limit <= 0 {
+		limit = 30
+	}
+
+	// Distinguish an unknown webhook from one without deliveries
+	if _, err := s.db.GetWebhook(ctx, nil, webhookID); err != nil {
+		return nil, "", err
+	}
+
+	return s.db.ListWebhookDeliveries(ctx, nil, webhookID, cursor, limit)
+}
+
+// RedeliverWebhookDelivery schedules a delivery to be sent again right away, with a fresh set
+// of attempts, whatever its outcome so far
+func (s *registryServiceImpl) RedeliverWebhookDelivery(ctx context.Context, webhookID, deliveryID int64) (*database.WebhookDelivery, error) {
+	delivery, err := s.db.GetWebhookDelivery(ctx, nil, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.WebhookID != webhookID {
+		return nil, database.ErrNotFound
+	}
+
+	delivery.Status = database.WebhookDeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	if err := s.db.UpdateWebhookDelivery(ctx, nil, delivery); err != nil {
+		return nil, err
+	}
+
+	s.webhooks.notify()
+	return delivery, nil
+}
@@ -5,13 +5,17 @@ import (
 	"fmt"
 
 	"github.com/modelcontextprotocol/registry/internal/validators/registries"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
 
+// CheckDisabled is the check recorded for packages accepted while registry validation is disabled
+const CheckDisabled = "disabled"
+
 // ValidatePackage validates that the package referenced in the server configuration is:
 // 1. allowed on the official registry (based on registry base url); and
 // 2. owned by the publisher, by checking for a matching server name in the package metadata
-func ValidatePackage(ctx context.Context, pkg model.Package, serverName string) error {
+func ValidatePackage(ctx context.Context, pkg model.Package, serverName string) (*registries.Evidence, error) {
 	switch pkg.RegistryType {
 	case model.RegistryTypeNPM:
 		return registries.ValidateNPM(ctx, pkg, serverName)
@@ -24,6 +28,52 @@ func ValidatePackage(ctx context.Context, pkg model.Package, serverName string)
 	case model.RegistryTypeMCPB:
 		return registries.ValidateMCPB(ctx, pkg, serverName)
 	default:
-		return fmt.Errorf("unsupported registry type: %s", pkg.RegistryType)
+		return nil, fmt.Errorf("unsupported registry type: %s", pkg.RegistryType)
+	}
+}
+
+// ValidatePackages validates every package of a server, returning the evidence for each package
+// in order, or a ValidationError with a problem for each package that failed. When registry
+// validation is disabled nothing is checked, and every package is recorded as skipped.
+func ValidatePackages(ctx context.Context, req apiv0.ServerJSON, enabled bool) ([]*registries.Evidence, error) {
+	var p problems
+	evidence := make([]*registries.Evidence, 0, len(req.Packages))
+	for i, check := range CheckPackages(ctx, req, enabled) {
+		p.add(CodePackageValidationFailed, fmt.Sprintf("/packages/%d", i), check.Err)
+		evidence = append(evidence, check.Evidence)
 	}
+	if err := p.err(); err != nil {
+		return nil, err
+	}
+	return evidence, nil
+}
+
+// PackageCheck is the result of the registry validation of a single package
+type PackageCheck struct {
+	Evidence *registries.Evidence // nil if the package failed validation
+	Err      error                // why the package failed validation
+}
+
+// CheckPackages validates every package of a server, returning the result for each package in order
+func CheckPackages(ctx context.Context, req apiv0.ServerJSON, enabled bool) []PackageCheck {
+	checks := make([]PackageCheck, 0, len(req.Packages))
+	for i, pkg := range req.Packages {
+		if !enabled {
+			checks = append(checks, PackageCheck{Evidence: &registries.Evidence{Check: CheckDisabled, Outcome: registries.OutcomeSkipped}})
+			continue
+		}
+
+		packageEvidence, err := ValidatePackage(ctx, pkg, req.Name)
+		if err != nil {
+			checks = append(checks, PackageCheck{Err: packageValidationError(i, pkg, err)})
+			continue
+		}
+		checks = append(checks, PackageCheck{Evidence: packageEvidence})
+	}
+	return checks
+}
+
+// packageValidationError identifies the package that failed registry validation
+func packageValidationError(index int, pkg model.Package, err error) error {
+	return fmt.Errorf("registry validation failed for package %d (%s): %w", index, pkg.Identifier, err)
 }
@@ -0,0 +1,93 @@
+package validators
+
+import (
+	"errors"
+	"strings"
+)
+
+// Codes of the problems found by validation. They are part of the API, so they must not change.
+const (
+	CodeMissingSchema                 = "missing_schema"
+	CodeUnsupportedSchema             = "unsupported_schema"
+	CodeInvalidServerName             = "invalid_server_name"
+	CodeReservedVersion               = "reserved_version"
+	CodeVersionRange                  = "version_range"
+	CodeInvalidRepositoryURL          = "invalid_repository_url"
+	CodeInvalidSubfolder              = "invalid_subfolder"
+	CodeInvalidWebsiteURL             = "invalid_website_url"
+	CodeInvalidTitle                  = "invalid_title"
+	CodeInvalidIcon                   = "invalid_icon"
+	CodeInvalidPackageIdentifier      = "invalid_package_identifier"
+	CodeInvalidNamedArgument          = "invalid_named_argument"
+	CodeArgumentValueStartsWithName   = "argument_value_starts_with_name"
+	CodeArgumentDefaultStartsWithName = "argument_default_starts_with_name"
+	CodeUnsupportedTransportType      = "unsupported_transport_type"
+	CodeInvalidTransportURL           = "invalid_transport_url"
+	CodePublisherExtensionTooLarge    = "publisher_extension_too_large"
+	CodePackageValidationFailed       = "package_validation_failed"
+)
+
+// Problem is a single problem found by validation
+type Problem struct {
+	Code    string // stable identifier of the kind of problem, e.g. "invalid_named_argument"
+	Pointer string // JSON pointer to the offending value, e.g. "/packages/0/packageArguments/2/name"
+	Err     error  // what is wrong
+}
+
+func (p *Problem) Error() string {
+	return p.Err.Error()
+}
+
+func (p *Problem) Unwrap() error {
+	return p.Err
+}
+
+// ValidationError is returned by validation that found problems, and holds every one of them in
+// the order they were found
+type ValidationError struct {
+	Problems []*Problem
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		messages[i] = problem.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Problems))
+	for i, problem := range e.Problems {
+		errs[i] = problem
+	}
+	return errs
+}
+
+// ProblemsOf returns the problems of a validation error, or nil if err is not one
+func ProblemsOf(err error) []*Problem {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Problems
+	}
+	return nil
+}
+
+// problems collects the problems found while validating a server.json
+type problems []*Problem
+
+// add records a problem with the value at pointer, ignoring nil errors so that the result of a
+// check can be passed directly
+func (p *problems) add(code, pointer string, err error) {
+	if err != nil {
+		*p = append(*p, &Problem{Code: code, Pointer: pointer, Err: err})
+	}
+}
+
+// err returns a ValidationError with the collected problems, or nil if there are none
+func (p problems) err() error {
+	if len(p) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: p}
+}
@@ -0,0 +1,15 @@
+package registries
+
+// Outcomes of a package validation that allowed a publish
+const (
+	OutcomePassed  = "passed"  // the package was checked and belongs to the server
+	OutcomeSkipped = "skipped" // the package was accepted without being checked
+)
+
+// Evidence records what a package validation checked and what the upstream registry reported,
+// so moderators can later see why a package was accepted
+type Evidence struct {
+	Check   string            // which check ran, e.g. "npm-mcp-name"
+	Outcome string            // OutcomePassed or OutcomeSkipped
+	Facts   map[string]string // facts from the upstream response, e.g. the resolved digest of an image
+}
@@ -17,63 +17,76 @@ var (
 	ErrMissingFileSHA256ForMCPB = fmt.Errorf("must include a fileSha256 hash for integrity verification")
 )
 
-func ValidateMCPB(ctx context.Context, pkg model.Package, _ string) error {
+// CheckMCPBDownload is the evidence check of ValidateMCPB
+const CheckMCPBDownload = "mcpb-download"
+
+// ValidateMCPB validates that an MCPB package is a publicly downloadable release asset
+func ValidateMCPB(ctx context.Context, pkg model.Package, _ string) (*Evidence, error) {
 	// MCPB packages must include a file hash for integrity verification
 	if pkg.FileSHA256 == "" {
-		return ErrMissingFileSHA256ForMCPB
+		return nil, ErrMissingFileSHA256ForMCPB
 	}
 
 	if pkg.Identifier == "" {
-		return ErrMissingIdentifierForMCPB
+		return nil, ErrMissingIdentifierForMCPB
 	}
 
 	// Validate that registryBaseUrl is not present
 	// MCPB packages use full download URLs in identifier
 	if pkg.RegistryBaseURL != "" {
-		return fmt.Errorf("MCPB packages must not have 'registryBaseUrl' field - use the full download URL in 'identifier' instead")
+		return nil, fmt.Errorf("MCPB packages must not have 'registryBaseUrl' field - use the full download URL in 'identifier' instead")
 	}
 	// Note: version field is optional for MCPB packages
 	// It can be included for clarity or omitted if the version is embedded in the download URL
 
 	err := validateMCPBUrl(pkg.Identifier)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Parse the URL to validate format
 	url, err := url.Parse(pkg.Identifier)
 	if err != nil {
-		return fmt.Errorf("invalid MCPB package URL: %w", err)
+		return nil, fmt.Errorf("invalid MCPB package URL: %w", err)
 	}
 	if url.Scheme != "https" {
-		return fmt.Errorf("invalid MCPB package URL, must use HTTPS: %s", pkg.Identifier)
+		return nil, fmt.Errorf("invalid MCPB package URL, must use HTTPS: %s", pkg.Identifier)
 	}
 
 	// Check that the URL contains 'mcp' somewhere (case-insensitive)
 	if !strings.Contains(strings.ToLower(pkg.Identifier), "mcp") {
-		return fmt.Errorf("MCPB package URL must contain 'mcp': %s", pkg.Identifier)
+		return nil, fmt.Errorf("MCPB package URL must contain 'mcp': %s", pkg.Identifier)
 	}
 
 	// Verify the file exists and is publicly accessible
 	client := &http.Client{Timeout: 10 * time.Second}
 	req, err := http.NewRequestWithContext(ctx, http.MethodHead, pkg.Identifier, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to verify MCPB package accessibility: %w", err)
+		return nil, fmt.Errorf("failed to verify MCPB package accessibility: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("MCPB package '%s' is not publicly accessible (status: %d)", pkg.Identifier, resp.StatusCode)
-	}
-
-	return nil
+		return nil, fmt.Errorf("MCPB package '%s' is not publicly accessible (status: %d)", pkg.Identifier, resp.StatusCode)
+	}
+
+	return &Evidence{
+		Check:   CheckMCPBDownload,
+		Outcome: OutcomePassed,
+		Facts: map[string]string{
+			"url":           pkg.Identifier,
+			"fileSha256":    pkg.FileSHA256,
+			"contentLength": resp.Header.Get("Content-Length"),
+			"etag":          resp.Header.Get("ETag"),
+		},
+	}, nil
 }
 
 func validateMCPBUrl(fullURL string) error {
@@ -108,7 +108,7 @@ func TestValidateMCPB(t *testing.T) {
 				FileSHA256:   tt.fileSHA256,
 			}
 
-			err := registries.ValidateMCPB(ctx, pkg, tt.serverName)
+			_, err := registries.ValidateMCPB(ctx, pkg, tt.serverName)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -175,7 +175,7 @@ func TestValidateMCPB_OptionalFields(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := registries.ValidateMCPB(ctx, tt.pkg, "io.github.domdomegg/airtable-mcp-server")
+			_, err := registries.ValidateMCPB(ctx, tt.pkg, "io.github.domdomegg/airtable-mcp-server")
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -7,6 +7,7 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/validators/registries"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValidateNPM_RealPackages(t *testing.T) {
@@ -124,13 +125,17 @@ func TestValidateNPM_RealPackages(t *testing.T) {
 				Version:      tt.version,
 			}
 
-			err := registries.ValidateNPM(ctx, pkg, tt.serverName)
+			evidence, err := registries.ValidateNPM(ctx, pkg, tt.serverName)
 
 			if tt.expectError {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.errorMessage)
 			} else {
-				assert.NoError(t, err)
+				require.NoError(t, err)
+				assert.Equal(t, registries.CheckNPMMCPName, evidence.Check)
+				assert.Equal(t, registries.OutcomePassed, evidence.Outcome)
+				assert.Equal(t, tt.serverName, evidence.Facts["mcpName"])
+				assert.NotEmpty(t, evidence.Facts["shasum"])
 			}
 		})
 	}
@@ -101,7 +101,7 @@ func TestValidateNuGet_RealPackages(t *testing.T) {
 				Version:      tt.version,
 			}
 
-			err := registries.ValidateNuGet(ctx, pkg, tt.serverName)
+			_, err := registries.ValidateNuGet(ctx, pkg, tt.serverName)
 
 			if tt.expectError {
 				assert.Error(t, err)
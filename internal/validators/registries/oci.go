@@ -21,6 +21,9 @@ var (
 	ErrUnsupportedRegistry     = errors.New("unsupported OCI registry")
 )
 
+// CheckOCILabel is the evidence check of ValidateOCI
+const CheckOCILabel = "oci-label"
+
 // ErrRateLimited is returned when a registry rate limits our requests
 var ErrRateLimited = errors.New("rate limited by registry")
 
@@ -51,33 +54,33 @@ var allowedOCIRegistries = map[string]bool{
 //   - GitHub Container Registry (ghcr.io)
 //   - Google Artifact Registry (*.pkg.dev)
 //   - Microsoft Container Registry (mcr.microsoft.com)
-func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) error {
+func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) (*Evidence, error) {
 	if pkg.Identifier == "" {
-		return ErrMissingIdentifierForOCI
+		return nil, ErrMissingIdentifierForOCI
 	}
 
 	// Validate that old format fields are not present
 	if pkg.RegistryBaseURL != "" {
-		return fmt.Errorf("OCI packages must not have 'registryBaseUrl' field - use canonical reference in 'identifier' instead (e.g., 'docker.io/owner/image:1.0.0')")
+		return nil, fmt.Errorf("OCI packages must not have 'registryBaseUrl' field - use canonical reference in 'identifier' instead (e.g., 'docker.io/owner/image:1.0.0')")
 	}
 	if pkg.Version != "" {
-		return fmt.Errorf("OCI packages must not have 'version' field - include version in 'identifier' instead (e.g., 'docker.io/owner/image:1.0.0')")
+		return nil, fmt.Errorf("OCI packages must not have 'version' field - include version in 'identifier' instead (e.g., 'docker.io/owner/image:1.0.0')")
 	}
 	if pkg.FileSHA256 != "" {
-		return fmt.Errorf("OCI packages must not have 'fileSha256' field")
+		return nil, fmt.Errorf("OCI packages must not have 'fileSha256' field")
 	}
 
 	// Parse the OCI reference using go-containerregistry's name package
 	// This handles all the complexity of reference parsing including defaults
 	ref, err := name.ParseReference(pkg.Identifier)
 	if err != nil {
-		return fmt.Errorf("invalid OCI reference: %w", err)
+		return nil, fmt.Errorf("invalid OCI reference: %w", err)
 	}
 
 	// Validate that the registry is in the allowlist
 	registry := ref.Context().RegistryStr()
 	if !isAllowedRegistry(registry) {
-		return fmt.Errorf("%w: %s", ErrUnsupportedRegistry, registry)
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedRegistry, registry)
 	}
 
 	// Add explicit timeout to prevent hanging on slow registries
@@ -95,7 +98,7 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 	if err != nil {
 		// Check if this is a timeout error
 		if errors.Is(err, context.DeadlineExceeded) {
-			return fmt.Errorf("OCI image validation timed out after 30 seconds for '%s'. The registry may be slow or unreachable", pkg.Identifier)
+			return nil, fmt.Errorf("OCI image validation timed out after 30 seconds for '%s'. The registry may be slow or unreachable", pkg.Identifier)
 		}
 
 		// Check for specific HTTP status codes
@@ -106,37 +109,57 @@ func ValidateOCI(ctx context.Context, pkg model.Package, serverName string) erro
 				// Rate limited - skip validation to avoid blocking publishers
 				// This is intentional: we prioritize UX over strict validation during high traffic
 				log.Printf("Skipping OCI validation for %s due to rate limiting", pkg.Identifier)
-				return nil
+				return &Evidence{
+					Check:   CheckOCILabel,
+					Outcome: OutcomeSkipped,
+					Facts: map[string]string{
+						"reference": ref.Name(),
+						"reason":    "rate limited by registry",
+					},
+				}, nil
 			case http.StatusNotFound:
-				return fmt.Errorf("OCI image '%s' does not exist in the registry", pkg.Identifier)
+				return nil, fmt.Errorf("OCI image '%s' does not exist in the registry", pkg.Identifier)
 			case http.StatusUnauthorized, http.StatusForbidden:
-				return fmt.Errorf("OCI image '%s' is private or requires authentication. Only public images are supported", pkg.Identifier)
+				return nil, fmt.Errorf("OCI image '%s' is private or requires authentication. Only public images are supported", pkg.Identifier)
 			}
 		}
-		return fmt.Errorf("failed to fetch OCI image: %w", err)
+		return nil, fmt.Errorf("failed to fetch OCI image: %w", err)
 	}
 
 	// Get the image config which contains labels
 	configFile, err := img.ConfigFile()
 	if err != nil {
-		return fmt.Errorf("failed to get image config: %w", err)
+		return nil, fmt.Errorf("failed to get image config: %w", err)
 	}
 
 	// Validate the MCP server name label
 	if configFile.Config.Labels == nil {
-		return fmt.Errorf("OCI image '%s' is missing required annotation. Add this to your Dockerfile: LABEL io.modelcontextprotocol.server.name=\"%s\"", pkg.Identifier, serverName)
+		return nil, fmt.Errorf("OCI image '%s' is missing required annotation. Add this to your Dockerfile: LABEL io.modelcontextprotocol.server.name=\"%s\"", pkg.Identifier, serverName)
 	}
 
 	mcpName, exists := configFile.Config.Labels["io.modelcontextprotocol.server.name"]
 	if !exists {
-		return fmt.Errorf("OCI image '%s' is missing required annotation. Add this to your Dockerfile: LABEL io.modelcontextprotocol.server.name=\"%s\"", pkg.Identifier, serverName)
+		return nil, fmt.Errorf("OCI image '%s' is missing required annotation. Add this to your Dockerfile: LABEL io.modelcontextprotocol.server.name=\"%s\"", pkg.Identifier, serverName)
 	}
 
 	if mcpName != serverName {
-		return fmt.Errorf("OCI image ownership validation failed. Expected annotation 'io.modelcontextprotocol.server.name' = '%s', got '%s'", serverName, mcpName)
+		return nil, fmt.Errorf("OCI image ownership validation failed. Expected annotation 'io.modelcontextprotocol.server.name' = '%s', got '%s'", serverName, mcpName)
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image digest: %w", err)
 	}
 
-	return nil
+	return &Evidence{
+		Check:   CheckOCILabel,
+		Outcome: OutcomePassed,
+		Facts: map[string]string{
+			"reference": ref.Name(),
+			"digest":    digest.String(),
+			"label":     mcpName,
+		},
+	}, nil
 }
 
 // isAllowedRegistry checks if the given registry is in the allowlist.
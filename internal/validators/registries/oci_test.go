@@ -7,6 +7,7 @@ import (
 	"github.com/modelcontextprotocol/registry/internal/validators/registries"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValidateOCI_RegistryAllowlist(t *testing.T) {
@@ -110,7 +111,7 @@ func TestValidateOCI_RegistryAllowlist(t *testing.T) {
 				Identifier:   tt.identifier,
 			}
 
-			err := registries.ValidateOCI(ctx, pkg, "com.example/test")
+			_, err := registries.ValidateOCI(ctx, pkg, "com.example/test")
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -162,7 +163,7 @@ func TestValidateOCI_RejectsOldFormat(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := registries.ValidateOCI(ctx, tt.pkg, "com.example/test")
+			_, err := registries.ValidateOCI(ctx, tt.pkg, "com.example/test")
 
 			assert.Error(t, err)
 			assert.Contains(t, err.Error(), tt.errorMessage)
@@ -194,7 +195,7 @@ func TestValidateOCI_InvalidReferences(t *testing.T) {
 				Identifier:   tt.identifier,
 			}
 
-			err := registries.ValidateOCI(ctx, pkg, "com.example/test")
+			_, err := registries.ValidateOCI(ctx, pkg, "com.example/test")
 			assert.Error(t, err)
 			assert.Contains(t, err.Error(), "invalid OCI reference")
 		})
@@ -209,7 +210,7 @@ func TestValidateOCI_EmptyIdentifier(t *testing.T) {
 		Identifier:   "",
 	}
 
-	err := registries.ValidateOCI(ctx, pkg, "com.example/test")
+	_, err := registries.ValidateOCI(ctx, pkg, "com.example/test")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "package identifier is required")
 }
@@ -223,8 +224,13 @@ func TestValidateOCI_SuccessfulValidation(t *testing.T) {
 		Identifier:   "ghcr.io/github/github-mcp-server:latest",
 	}
 
-	err := registries.ValidateOCI(ctx, pkg, "io.github.github/github-mcp-server")
-	assert.NoError(t, err)
+	evidence, err := registries.ValidateOCI(ctx, pkg, "io.github.github/github-mcp-server")
+	require.NoError(t, err)
+	assert.Equal(t, registries.CheckOCILabel, evidence.Check)
+	if evidence.Outcome == registries.OutcomePassed {
+		assert.Contains(t, evidence.Facts["digest"], "sha256:")
+		assert.Equal(t, "io.github.github/github-mcp-server", evidence.Facts["label"])
+	}
 }
 
 func TestValidateOCI_LabelMismatch(t *testing.T) {
@@ -237,7 +243,7 @@ func TestValidateOCI_LabelMismatch(t *testing.T) {
 		Identifier:   "ghcr.io/github/github-mcp-server:latest",
 	}
 
-	err := registries.ValidateOCI(ctx, pkg, "io.github.github/github-mcp-server-mismatch")
+	_, err := registries.ValidateOCI(ctx, pkg, "io.github.github/github-mcp-server-mismatch")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "ownership validation failed")
 	assert.Contains(t, err.Error(), "Expected annotation")
@@ -17,36 +17,46 @@ var (
 	ErrMissingVersionForPyPi    = errors.New("package version is required for PyPI packages")
 )
 
+// CheckPyPIReadme is the evidence check of ValidatePyPI
+const CheckPyPIReadme = "pypi-readme-mcp-name"
+
 // PyPIPackageResponse represents the structure returned by the PyPI JSON API
 type PyPIPackageResponse struct {
 	Info struct {
 		Description string `json:"description"`
+		Version     string `json:"version"`
 	} `json:"info"`
+	URLs []struct {
+		Filename string `json:"filename"`
+		Digests  struct {
+			SHA256 string `json:"sha256"`
+		} `json:"digests"`
+	} `json:"urls"`
 }
 
 // ValidatePyPI validates that a PyPI package contains the correct MCP server name
-func ValidatePyPI(ctx context.Context, pkg model.Package, serverName string) error {
+func ValidatePyPI(ctx context.Context, pkg model.Package, serverName string) (*Evidence, error) {
 	// Set default registry base URL if empty
 	if pkg.RegistryBaseURL == "" {
 		pkg.RegistryBaseURL = model.RegistryURLPyPI
 	}
 
 	if pkg.Identifier == "" {
-		return ErrMissingIdentifierForPyPI
+		return nil, ErrMissingIdentifierForPyPI
 	}
 
 	if pkg.Version == "" {
-		return ErrMissingVersionForPyPi
+		return nil, ErrMissingVersionForPyPi
 	}
 
 	// Validate that MCPB-specific fields are not present
 	if pkg.FileSHA256 != "" {
-		return fmt.Errorf("PyPI packages must not have 'fileSha256' field - this is only for MCPB packages")
+		return nil, fmt.Errorf("PyPI packages must not have 'fileSha256' field - this is only for MCPB packages")
 	}
 
 	// Validate that the registry base URL matches PyPI exactly
 	if pkg.RegistryBaseURL != model.RegistryURLPyPI {
-		return fmt.Errorf("registry type and base URL do not match: '%s' is not valid for registry type '%s'. Expected: %s",
+		return nil, fmt.Errorf("registry type and base URL do not match: '%s' is not valid for registry type '%s'. Expected: %s",
 			pkg.RegistryBaseURL, model.RegistryTypePyPI, model.RegistryURLPyPI)
 	}
 
@@ -55,7 +65,7 @@ func ValidatePyPI(ctx context.Context, pkg model.Package, serverName string) err
 	url := fmt.Sprintf("%s/pypi/%s/%s/json", pkg.RegistryBaseURL, pkg.Identifier, pkg.Version)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("User-Agent", "MCP-Registry-Validator/1.0")
@@ -63,17 +73,17 @@ func ValidatePyPI(ctx context.Context, pkg model.Package, serverName string) err
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to fetch package metadata from PyPI: %w", err)
+		return nil, fmt.Errorf("failed to fetch package metadata from PyPI: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("PyPI package '%s' not found (status: %d)", pkg.Identifier, resp.StatusCode)
+		return nil, fmt.Errorf("PyPI package '%s' not found (status: %d)", pkg.Identifier, resp.StatusCode)
 	}
 
 	var pypiResp PyPIPackageResponse
 	if err := json.NewDecoder(resp.Body).Decode(&pypiResp); err != nil {
-		return fmt.Errorf("failed to parse PyPI package metadata: %w", err)
+		return nil, fmt.Errorf("failed to parse PyPI package metadata: %w", err)
 	}
 
 	// Check description (README) content
@@ -82,8 +92,16 @@ func ValidatePyPI(ctx context.Context, pkg model.Package, serverName string) err
 	// Check for mcp-name: format (more specific)
 	mcpNamePattern := "mcp-name: " + serverName
 	if strings.Contains(description, mcpNamePattern) {
-		return nil // Found as mcp-name: format
+		// Found as mcp-name: format
+		facts := map[string]string{
+			"url":     url,
+			"version": pypiResp.Info.Version,
+		}
+		for _, file := range pypiResp.URLs {
+			facts["sha256:"+file.Filename] = file.Digests.SHA256
+		}
+		return &Evidence{Check: CheckPyPIReadme, Outcome: OutcomePassed, Facts: facts}, nil
 	}
 
-	return fmt.Errorf("PyPI package '%s' ownership validation failed. The server name '%s' must appear as 'mcp-name: %s' in the package README", pkg.Identifier, serverName, serverName)
+	return nil, fmt.Errorf("PyPI package '%s' ownership validation failed. The server name '%s' must appear as 'mcp-name: %s' in the package README", pkg.Identifier, serverName, serverName)
 }
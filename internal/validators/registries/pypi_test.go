@@ -77,7 +77,7 @@ func TestValidatePyPI_RealPackages(t *testing.T) {
 				Version:      tt.version,
 			}
 
-			err := registries.ValidatePyPI(ctx, pkg, tt.serverName)
+			_, err := registries.ValidatePyPI(ctx, pkg, tt.serverName)
 
 			if tt.expectError {
 				assert.Error(t, err)
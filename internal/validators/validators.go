@@ -3,12 +3,14 @@ package validators
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"regexp"
 	"strings"
 
 	"github.com/modelcontextprotocol/registry/internal/config"
+	"github.com/modelcontextprotocol/registry/internal/validators/registries"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
@@ -51,83 +53,84 @@ var (
 	dottedVersionLikeRe = regexp.MustCompile(`^\s*(?:v?\d+|x|X|\*)(?:\.(?:\d+|x|X|\*)){1,2}(?:-[0-9A-Za-z.-]+)?\s*$`)
 )
 
+// ValidateServerJSON validates a server.json, returning a ValidationError with every problem found
 func ValidateServerJSON(serverJSON *apiv0.ServerJSON) error {
+	var p problems
+	validateServerJSON(&p, serverJSON)
+	return p.err()
+}
+
+// validateServerJSON collects the problems of a server.json
+func validateServerJSON(p *problems, serverJSON *apiv0.ServerJSON) {
 	// Validate schema version is provided and supported
 	// Note: Schema field is also marked as required in the ServerJSON struct definition
 	// for API-level validation and documentation
 	if serverJSON.Schema == "" {
-		return fmt.Errorf("$schema field is required")
-	}
-	if !strings.Contains(serverJSON.Schema, model.CurrentSchemaVersion) {
-		return fmt.Errorf("schema version %s is not supported. Please use schema version %s", serverJSON.Schema, model.CurrentSchemaVersion)
+		p.add(CodeMissingSchema, "/$schema", fmt.Errorf("$schema field is required"))
+	} else if !strings.Contains(serverJSON.Schema, model.CurrentSchemaVersion) {
+		p.add(CodeUnsupportedSchema, "/$schema", fmt.Errorf("schema version %s is not supported. Please use schema version %s", serverJSON.Schema, model.CurrentSchemaVersion))
 	}
 
 	// Validate server name exists and format
-	if _, err := parseServerName(*serverJSON); err != nil {
-		return err
-	}
+	_, err := parseServerName(*serverJSON)
+	p.add(CodeInvalidServerName, "/name", err)
 
 	// Validate top-level server version is a specific version (not a range) & not "latest"
-	if err := validateVersion(serverJSON.Version); err != nil {
-		return err
-	}
+	err = validateVersion(serverJSON.Version)
+	p.add(versionProblemCode(err), "/version", err)
 
 	// Validate repository
-	if err := validateRepository(serverJSON.Repository); err != nil {
-		return err
-	}
+	validateRepository(p, serverJSON.Repository)
 
 	// Validate website URL if provided
-	if err := validateWebsiteURL(serverJSON.WebsiteURL); err != nil {
-		return err
-	}
+	p.add(CodeInvalidWebsiteURL, "/websiteUrl", validateWebsiteURL(serverJSON.WebsiteURL))
 
 	// Validate title if provided
-	if err := validateTitle(serverJSON.Title); err != nil {
-		return err
-	}
+	p.add(CodeInvalidTitle, "/title", validateTitle(serverJSON.Title))
 
 	// Validate icons if provided
-	if err := validateIcons(serverJSON.Icons); err != nil {
-		return err
+	for i, icon := range serverJSON.Icons {
+		if err := validateIcon(&icon); err != nil {
+			p.add(CodeInvalidIcon, fmt.Sprintf("/icons/%d/src", i), fmt.Errorf("invalid icon at index %d: %w", i, err))
+		}
 	}
 
 	// Validate all packages (basic field validation)
 	// Detailed package validation (including registry checks) is done during publish
-	for _, pkg := range serverJSON.Packages {
-		if err := validatePackageField(&pkg); err != nil {
-			return err
-		}
+	for i, pkg := range serverJSON.Packages {
+		validatePackageField(p, fmt.Sprintf("/packages/%d", i), &pkg)
 	}
 
 	// Validate all remotes
-	for _, remote := range serverJSON.Remotes {
-		if err := validateRemoteTransport(&remote); err != nil {
-			return err
-		}
+	for i, remote := range serverJSON.Remotes {
+		validateRemoteTransport(p, fmt.Sprintf("/remotes/%d", i), &remote)
 	}
+}
 
-	return nil
+// versionProblemCode returns the code of the problem of an invalid version
+func versionProblemCode(err error) string {
+	if errors.Is(err, ErrReservedVersionString) {
+		return CodeReservedVersion
+	}
+	return CodeVersionRange
 }
 
-func validateRepository(obj *model.Repository) error {
+func validateRepository(p *problems, obj *model.Repository) {
 	// Skip validation if repository is nil or empty (optional field)
 	if obj == nil || (obj.URL == "" && obj.Source == "") {
-		return nil
+		return
 	}
 
 	// validate the repository source
 	repoSource := RepositorySource(obj.Source)
 	if !IsValidRepositoryURL(repoSource, obj.URL) {
-		return fmt.Errorf("%w: %s", ErrInvalidRepositoryURL, obj.URL)
+		p.add(CodeInvalidRepositoryURL, "/repository/url", fmt.Errorf("%w: %s", ErrInvalidRepositoryURL, obj.URL))
 	}
 
 	// validate subfolder if present
 	if obj.Subfolder != "" && !IsValidSubfolderPath(obj.Subfolder) {
-		return fmt.Errorf("%w: %s", ErrInvalidSubfolderPath, obj.Subfolder)
+		p.add(CodeInvalidSubfolder, "/repository/subfolder", fmt.Errorf("%w: %s", ErrInvalidSubfolderPath, obj.Subfolder))
 	}
-
-	return nil
 }
 
 func validateWebsiteURL(websiteURL string) error {
@@ -169,22 +172,6 @@ func validateTitle(title string) error {
 	return nil
 }
 
-func validateIcons(icons []model.Icon) error {
-	// Skip validation if no icons are provided (optional field)
-	if len(icons) == 0 {
-		return nil
-	}
-
-	// Validate each icon
-	for i, icon := range icons {
-		if err := validateIcon(&icon); err != nil {
-			return fmt.Errorf("invalid icon at index %d: %w", i, err)
-		}
-	}
-
-	return nil
-}
-
 func validateIcon(icon *model.Icon) error {
 	// Parse the URL to ensure it's valid
 	parsedURL, err := url.Parse(icon.Src)
@@ -205,37 +192,29 @@ func validateIcon(icon *model.Icon) error {
 	return nil
 }
 
-func validatePackageField(obj *model.Package) error {
+// validatePackageField collects the problems of the package at pointer
+func validatePackageField(p *problems, pointer string, obj *model.Package) {
 	if !HasNoSpaces(obj.Identifier) {
-		return ErrPackageNameHasSpaces
+		p.add(CodeInvalidPackageIdentifier, pointer+"/identifier", ErrPackageNameHasSpaces)
 	}
 
 	// Validate version string
-	if err := validateVersion(obj.Version); err != nil {
-		return err
-	}
+	err := validateVersion(obj.Version)
+	p.add(versionProblemCode(err), pointer+"/version", err)
 
 	// Validate runtime arguments
-	for _, arg := range obj.RuntimeArguments {
-		if err := validateArgument(&arg); err != nil {
-			return fmt.Errorf("invalid runtime argument: %w", err)
-		}
+	for i, arg := range obj.RuntimeArguments {
+		validateArgument(p, fmt.Sprintf("%s/runtimeArguments/%d", pointer, i), "invalid runtime argument", &arg)
 	}
 
 	// Validate package arguments
-	for _, arg := range obj.PackageArguments {
-		if err := validateArgument(&arg); err != nil {
-			return fmt.Errorf("invalid package argument: %w", err)
-		}
+	for i, arg := range obj.PackageArguments {
+		validateArgument(p, fmt.Sprintf("%s/packageArguments/%d", pointer, i), "invalid package argument", &arg)
 	}
 
 	// Validate transport with template variable support
 	availableVariables := collectAvailableVariables(obj)
-	if err := validatePackageTransport(&obj.Transport, availableVariables); err != nil {
-		return fmt.Errorf("invalid transport: %w", err)
-	}
-
-	return nil
+	validatePackageTransport(p, pointer+"/transport", &obj.Transport, availableVariables)
 }
 
 // validateVersion validates the version string.
@@ -285,20 +264,27 @@ func looksLikeVersionRange(version string) bool {
 	return false
 }
 
-// validateArgument validates argument details
-func validateArgument(obj *model.Argument) error {
-	if obj.Type == model.ArgumentTypeNamed {
-		// Validate named argument name format
-		if err := validateNamedArgumentName(obj.Name); err != nil {
-			return err
+// validateArgument collects the problems of the argument at pointer, prefixing their messages
+// with context, e.g. "invalid runtime argument"
+func validateArgument(p *problems, pointer, context string, obj *model.Argument) {
+	if obj.Type != model.ArgumentTypeNamed {
+		return
+	}
+	add := func(code, field string, err error) {
+		if err != nil {
+			p.add(code, pointer+"/"+field, fmt.Errorf("%s: %w", context, err))
 		}
+	}
 
-		// Validate value and default don't start with the name
-		if err := validateArgumentValueFields(obj.Name, obj.Value, obj.Default); err != nil {
-			return err
-		}
+	// Validate named argument name format
+	if err := validateNamedArgumentName(obj.Name); err != nil {
+		add(CodeInvalidNamedArgument, "name", err)
+		return
 	}
-	return nil
+
+	// Validate value and default don't start with the name
+	add(CodeArgumentValueStartsWithName, "value", validateArgumentValueField(obj.Name, obj.Value, ErrArgumentValueStartsWithName, "value"))
+	add(CodeArgumentDefaultStartsWithName, "default", validateArgumentValueField(obj.Name, obj.Default, ErrArgumentDefaultStartsWithName, "default"))
 }
 
 func validateNamedArgumentName(name string) error {
@@ -318,16 +304,13 @@ func validateNamedArgumentName(name string) error {
 	return nil
 }
 
-func validateArgumentValueFields(name, value, defaultValue string) error {
+// validateArgumentValueField checks that the value or default of a named argument does not
+// start with its name, returning sentinel if it does
+func validateArgumentValueField(name, value string, sentinel error, field string) error {
 	// Check if value starts with the argument name (using startsWith, not contains)
 	if value != "" && strings.HasPrefix(value, name) {
-		return fmt.Errorf("%w: value starts with argument name '%s': %s", ErrArgumentValueStartsWithName, name, value)
-	}
-
-	if defaultValue != "" && strings.HasPrefix(defaultValue, name) {
-		return fmt.Errorf("%w: default starts with argument name '%s': %s", ErrArgumentDefaultStartsWithName, name, defaultValue)
+		return fmt.Errorf("%w: %s starts with argument name '%s': %s", sentinel, field, name, value)
 	}
-
 	return nil
 }
 
@@ -363,78 +346,83 @@ func collectAvailableVariables(pkg *model.Package) []string {
 	return variables
 }
 
-// validatePackageTransport validates a package's transport with templating support
-func validatePackageTransport(transport *model.Transport, availableVariables []string) error {
+// validatePackageTransport collects the problems of the package transport at pointer, with
+// templating support
+func validatePackageTransport(p *problems, pointer string, transport *model.Transport, availableVariables []string) {
+	invalid := func(code, field string, err error) {
+		p.add(code, pointer+"/"+field, fmt.Errorf("invalid transport: %w", err))
+	}
+
 	// Validate transport type is supported
 	switch transport.Type {
 	case model.TransportTypeStdio:
 		// Validate that URL is empty for stdio transport
 		if transport.URL != "" {
-			return fmt.Errorf("url must be empty for %s transport type, got: %s", transport.Type, transport.URL)
+			invalid(CodeInvalidTransportURL, "url", fmt.Errorf("url must be empty for %s transport type, got: %s", transport.Type, transport.URL))
 		}
-		return nil
 	case model.TransportTypeStreamableHTTP, model.TransportTypeSSE:
 		// URL is required for streamable-http and sse
 		if transport.URL == "" {
-			return fmt.Errorf("url is required for %s transport type", transport.Type)
+			invalid(CodeInvalidTransportURL, "url", fmt.Errorf("url is required for %s transport type", transport.Type))
+			return
 		}
 		// Validate URL format with template variable support
 		if !IsValidTemplatedURL(transport.URL, availableVariables, true) {
 			// Check if it's a template variable issue or basic URL issue
 			templateVars := extractTemplateVariables(transport.URL)
 			if len(templateVars) > 0 {
-				return fmt.Errorf("%w: template variables in URL %s reference undefined variables. Available variables: %v",
-					ErrInvalidRemoteURL, transport.URL, availableVariables)
+				invalid(CodeInvalidTransportURL, "url", fmt.Errorf("%w: template variables in URL %s reference undefined variables. Available variables: %v",
+					ErrInvalidRemoteURL, transport.URL, availableVariables))
+				return
 			}
-			return fmt.Errorf("%w: %s", ErrInvalidRemoteURL, transport.URL)
+			invalid(CodeInvalidTransportURL, "url", fmt.Errorf("%w: %s", ErrInvalidRemoteURL, transport.URL))
 		}
-		return nil
 	default:
-		return fmt.Errorf("unsupported transport type: %s", transport.Type)
+		invalid(CodeUnsupportedTransportType, "type", fmt.Errorf("unsupported transport type: %s", transport.Type))
 	}
 }
 
-// validateRemoteTransport validates a remote transport (no templating allowed)
-func validateRemoteTransport(obj *model.Transport) error {
+// validateRemoteTransport collects the problems of the remote transport at pointer (no templating allowed)
+func validateRemoteTransport(p *problems, pointer string, obj *model.Transport) {
 	// Validate transport type is supported - remotes only support streamable-http and sse
 	switch obj.Type {
 	case model.TransportTypeStreamableHTTP, model.TransportTypeSSE:
 		// URL is required for streamable-http and sse
 		if obj.URL == "" {
-			return fmt.Errorf("url is required for %s transport type", obj.Type)
+			p.add(CodeInvalidTransportURL, pointer+"/url", fmt.Errorf("url is required for %s transport type", obj.Type))
+			return
 		}
 		// Validate URL format (no templates allowed for remotes, no localhost)
 		if !IsValidRemoteURL(obj.URL) {
-			return fmt.Errorf("%w: %s", ErrInvalidRemoteURL, obj.URL)
+			p.add(CodeInvalidTransportURL, pointer+"/url", fmt.Errorf("%w: %s", ErrInvalidRemoteURL, obj.URL))
 		}
-		return nil
 	default:
-		return fmt.Errorf("unsupported transport type for remotes: %s (only streamable-http and sse are supported)", obj.Type)
+		p.add(CodeUnsupportedTransportType, pointer+"/type", fmt.Errorf("unsupported transport type for remotes: %s (only streamable-http and sse are supported)", obj.Type))
 	}
 }
 
-// ValidatePublishRequest validates a complete publish request including extensions
-func ValidatePublishRequest(ctx context.Context, req apiv0.ServerJSON, cfg *config.Config) error {
-	// Validate publisher extensions in _meta
-	if err := validatePublisherExtensions(req); err != nil {
-		return err
-	}
-
-	// Validate the server detail (includes all nested validation)
-	if err := ValidateServerJSON(&req); err != nil {
-		return err
+// ValidatePublishRequest validates a complete publish request including extensions, returning
+// the evidence of the registry validation of each package
+func ValidatePublishRequest(ctx context.Context, req apiv0.ServerJSON, cfg *config.Config) ([]*registries.Evidence, error) {
+	if err := ValidatePublishRequestJSON(req); err != nil {
+		return nil, err
 	}
 
 	// Validate registry ownership for all packages if validation is enabled
-	if cfg.EnableRegistryValidation {
-		for i, pkg := range req.Packages {
-			if err := ValidatePackage(ctx, pkg, req.Name); err != nil {
-				return fmt.Errorf("registry validation failed for package %d (%s): %w", i, pkg.Identifier, err)
-			}
-		}
-	}
+	return ValidatePackages(ctx, req, cfg.EnableRegistryValidation)
+}
 
-	return nil
+// ValidatePublishRequestJSON validates a publish request including extensions, without the
+// registry validation of its packages, returning a ValidationError with every problem found
+func ValidatePublishRequestJSON(req apiv0.ServerJSON) error {
+	var p problems
+
+	// Validate publisher extensions in _meta
+	p.add(CodePublisherExtensionTooLarge, "/_meta/io.modelcontextprotocol.registry~1publisher-provided", validatePublisherExtensions(req))
+
+	// Validate the server detail (includes all nested validation)
+	validateServerJSON(&p, &req)
+	return p.err()
 }
 
 func validatePublisherExtensions(req apiv0.ServerJSON) error {
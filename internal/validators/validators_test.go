@@ -1679,7 +1679,7 @@ func TestValidate_RegistryTypesAndUrls(t *testing.T) {
 				},
 			}
 
-			err := validators.ValidatePublishRequest(context.Background(), serverJSON, &config.Config{
+			_, err := validators.ValidatePublishRequest(context.Background(), serverJSON, &config.Config{
 				EnableRegistryValidation: true,
 			})
 			if tc.expectError {
@@ -1691,6 +1691,39 @@ func TestValidate_RegistryTypesAndUrls(t *testing.T) {
 	}
 }
 
+func TestValidate_CollectsAllProblems(t *testing.T) {
+	server := createValidServerWithArgument(model.Argument{
+		InputWithVariables: model.InputWithVariables{Input: model.Input{Value: "--port 8080", Default: "--port 80"}},
+		Type:               model.ArgumentTypeNamed,
+		Name:               "--port",
+	})
+	server.Version = "latest"
+	server.Title = "   "
+	server.Icons = []model.Icon{{Src: "https://example.com/icon.png"}, {Src: "http://example.com/icon.png"}}
+	server.Packages[0].PackageArguments = []model.Argument{{Type: model.ArgumentTypeNamed, Name: "--file <path>"}}
+	server.Remotes = append(server.Remotes, model.Transport{Type: "streamable-http"})
+
+	err := validators.ValidateServerJSON(&server)
+	assert.ErrorIs(t, err, validators.ErrReservedVersionString)
+	assert.ErrorIs(t, err, validators.ErrInvalidNamedArgumentName)
+	assert.ErrorIs(t, err, validators.ErrArgumentValueStartsWithName)
+
+	var found []string
+	for _, problem := range validators.ProblemsOf(err) {
+		assert.NotEmpty(t, problem.Error())
+		found = append(found, problem.Code+" "+problem.Pointer)
+	}
+	assert.Equal(t, []string{
+		"reserved_version /version",
+		"invalid_title /title",
+		"invalid_icon /icons/1/src",
+		"argument_value_starts_with_name /packages/0/runtimeArguments/0/value",
+		"argument_default_starts_with_name /packages/0/runtimeArguments/0/default",
+		"invalid_named_argument /packages/0/packageArguments/0/name",
+		"invalid_transport_url /remotes/1/url",
+	}, found)
+}
+
 func createValidServerWithArgument(arg model.Argument) apiv0.ServerJSON {
 	return apiv0.ServerJSON{
 		Schema:      model.CurrentSchemaURL,
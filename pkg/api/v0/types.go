@@ -7,10 +7,20 @@ import (
 )
 
 type RegistryExtensions struct {
-	Status      model.Status `json:"status" enum:"active,deprecated,deleted" doc:"Server lifecycle status"`
-	PublishedAt time.Time    `json:"publishedAt" format:"date-time" doc:"Timestamp when the server was first published to the registry"`
-	UpdatedAt   time.Time    `json:"updatedAt,omitempty" format:"date-time" doc:"Timestamp when the server entry was last updated"`
-	IsLatest    bool         `json:"isLatest" doc:"Whether this is the latest version of the server"`
+	Status         model.Status `json:"status" enum:"active,deprecated,deleted,draft" doc:"Server lifecycle status"`
+	PublishedAt    time.Time    `json:"publishedAt" format:"date-time" doc:"Timestamp when the server was first published to the registry"`
+	UpdatedAt      time.Time    `json:"updatedAt,omitempty" format:"date-time" doc:"Timestamp when the server entry was last updated"`
+	IsLatest       bool         `json:"isLatest" doc:"Whether this is the latest version of the server"`
+	IsLatestStable bool         `json:"isLatestStable" doc:"Whether this is the latest version of the server that is not a prerelease"`
+
+	DeprecationMessage string      `json:"deprecationMessage,omitempty" doc:"Why the version is deprecated or deleted, and what to do instead"`
+	ReplacedBy         *ReplacedBy `json:"replacedBy,omitempty" doc:"Server that consumers of this version should move to"`
+}
+
+// ReplacedBy identifies the server that replaces a deprecated server version
+type ReplacedBy struct {
+	Name    string `json:"name" minLength:"3" maxLength:"200" pattern:"^[a-zA-Z0-9.-]+/[a-zA-Z0-9._-]+$" doc:"Name of the replacement server" example:"io.github.user/weather-v2"`
+	Version string `json:"version,omitempty" maxLength:"255" doc:"Version of the replacement server; when omitted, its latest version" example:"2.0.0"`
 }
 
 type ResponseMeta struct {
@@ -49,3 +59,31 @@ type Metadata struct {
 	NextCursor string `json:"nextCursor,omitempty" doc:"Pagination cursor for retrieving the next page of results. Use this exact value in the cursor query parameter of your next request."`
 	Count      int    `json:"count" doc:"Number of items in current page"`
 }
+
+// ServerChange is an entry of the change feed
+type ServerChange struct {
+	Seq        int64          `json:"seq" doc:"Sequence number of the change. Sequence numbers increase with every change, but may have gaps." example:"1234"`
+	Type       string         `json:"type" enum:"created,updated,status_changed" doc:"Kind of change: a version was published, its server.json or latest flag changed, or its status changed"`
+	ServerName string         `json:"serverName" example:"io.github.user/weather"`
+	Version    string         `json:"version" example:"1.0.2"`
+	Status     model.Status   `json:"status" enum:"active,deprecated,deleted" doc:"Status of the version as a result of the change"`
+	OccurredAt time.Time      `json:"occurredAt" format:"date-time" doc:"When the change was made"`
+	Server     ServerResponse `json:"server" doc:"Current state of the version, which may include later changes"`
+}
+
+// WebhookEvent is the body of a webhook delivery
+type WebhookEvent struct {
+	Event      string         `json:"event" enum:"server.published,server.updated,server.status_changed" doc:"Kind of event: a version was published, its server.json was edited, or its status changed"`
+	OccurredAt time.Time      `json:"occurredAt" format:"date-time" doc:"When the change was committed"`
+	Server     ServerResponse `json:"server" doc:"State of the version as a result of the change"`
+}
+
+type ServerChangeListResponse struct {
+	Changes  []ServerChange     `json:"changes" doc:"Changes in sequence order"`
+	Metadata ChangeListMetadata `json:"metadata" doc:"Feed position"`
+}
+
+type ChangeListMetadata struct {
+	NextSince int64 `json:"nextSince" doc:"Sequence number to pass as since in your next request. Equal to the requested since when there are no new changes."`
+	Count     int   `json:"count" doc:"Number of changes in current page"`
+}
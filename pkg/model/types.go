@@ -6,6 +6,7 @@ const (
 	StatusActive     Status = "active"
 	StatusDeprecated Status = "deprecated"
 	StatusDeleted    Status = "deleted"
+	StatusDraft      Status = "draft" // published but only visible to its publishers until promoted
 )
 
 type Transport struct {
@@ -13,6 +13,7 @@ import (
 	"io/ioutil"
 	"log"
 	"path/filepath"
+	"strings"
 
 	"github.com/google/uuid"
 	_ "github.com/lib/pq"
@@ -32,7 +33,14 @@ func main() {
 	migrationsDir := "internal/database/migrations"
 	for i := 1; i <= maxMigration; i++ {
 		migrationFile := filepath.Join(migrationsDir, fmt.Sprintf("%03d_*.sql", i))
-		files, err := filepath.Glob(migrationFile)
+		matches, err := filepath.Glob(migrationFile)
+		// Skip the paired down migrations, which revert rather than apply
+		var files []string
+		for _, match := range matches {
+			if !strings.HasSuffix(match, ".down.sql") {
+				files = append(files, match)
+			}
+		}
 		if err != nil || len(files) == 0 {
 			log.Fatalf("Migration file %d not found", i)
 		}
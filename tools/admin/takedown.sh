@@ -4,15 +4,26 @@
 REGISTRY_URL="${REGISTRY_URL:-https://registry.modelcontextprotocol.io}"
 
 if [ -z "$SERVER_NAME" ] || [ -z "$REGISTRY_TOKEN" ]; then
-    echo "Usage: REGISTRY_TOKEN=<token> SERVER_NAME=<server-name> [VERSION=<version>] $0"
+    echo "Usage: REGISTRY_TOKEN=<token> SERVER_NAME=<server-name> [VERSION=<version> | ALL_VERSIONS=1] [REASON=<reason>] $0"
     echo "Example: REGISTRY_TOKEN=token SERVER_NAME=com.example/my-server ./takedown.sh"
     echo "Example: REGISTRY_TOKEN=token SERVER_NAME=com.example/my-server VERSION=1.0.0 ./takedown.sh"
+    echo "Example: REGISTRY_TOKEN=token SERVER_NAME=com.example/my-server ALL_VERSIONS=1 ./takedown.sh"
     exit 1
 fi
 
 # URL encode the server name (replace / with %2F)
 ENCODED_SERVER_NAME=$(echo "$SERVER_NAME" | sed 's|/|%2F|g')
 
+# Mark every version as deleted in one transaction
+if [ -n "$ALL_VERSIONS" ]; then
+    echo "Marking all versions of server $SERVER_NAME as deleted..."
+    curl -X POST "${REGISTRY_URL}/v0/admin/servers/${ENCODED_SERVER_NAME}/status" \
+      -H "Authorization: Bearer ${REGISTRY_TOKEN}" \
+      -H "Content-Type: application/json" \
+      -d "$(jq -n --arg reason "$REASON" '{status: "deleted", reason: $reason}')"
+    exit $?
+fi
+
 # Determine the endpoint based on whether VERSION is provided
 if [ -n "$VERSION" ]; then
     # Mark specific version as deleted
@@ -24,7 +35,13 @@ else
     echo "Marking server $SERVER_NAME as deleted..."
 fi
 
+# Record the reason for the takedown in the audit log, if given
+REASON_ARGS=()
+if [ -n "$REASON" ]; then
+    REASON_ARGS=(-G --data-urlencode "reason=${REASON}")
+fi
+
 # Update server status to deleted
-curl -X PUT "$ENDPOINT" \
+curl -X PUT "$ENDPOINT" "${REASON_ARGS[@]}" \
   -H "Authorization: Bearer ${REGISTRY_TOKEN}" \
   -H "Content-Type: application/json"
\ No newline at end of file